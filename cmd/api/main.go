@@ -14,9 +14,13 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/tmozzze/org_struct_api/internal/config"
 	httpHandler "github.com/tmozzze/org_struct_api/internal/handler/http"
+	"github.com/tmozzze/org_struct_api/internal/metrics"
 	"github.com/tmozzze/org_struct_api/internal/repository/postgres"
 	"github.com/tmozzze/org_struct_api/internal/service"
+	"github.com/tmozzze/org_struct_api/internal/tracing"
 	"github.com/tmozzze/org_struct_api/pkg/database"
+	"github.com/tmozzze/org_struct_api/pkg/logger"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
 )
 
 const (
@@ -43,6 +47,13 @@ const (
 // @host localhost:8080
 // @BasePath /
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT or API key as "Bearer <token>", API key may also be sent in X-API-Key header
+
+// @security BearerAuth
+
 func main() {
 
 	// Init Config
@@ -53,8 +64,14 @@ func main() {
 	log.Info("starting org_struct_api", slog.String("env", cfg.Env))
 	log.Debug("debug messages are enabled")
 
+	// Subcommands: none runs server, "migrate" manages database schema
+	if len(os.Args) > 1 && os.Args[1] != "migrate" {
+		log.Error("unknown command", slog.String("command", os.Args[1]), slog.String("usage", "api [migrate <command>]"))
+		os.Exit(2)
+	}
+
 	// Init DB (GORM)
-	db, err := database.NewPostgresDB(cfg.Postgres)
+	db, err := database.NewPostgresDB(cfg.Postgres, log)
 	if err != nil {
 		log.Error("failed to init database", slog.Any("err", err))
 		os.Exit(1)
@@ -75,32 +92,134 @@ func main() {
 		}
 	}()
 
+	// Init Migrator of embedded migrations
+	migrator, err := database.NewMigrator(cfg.DBDialect, sqlDB)
+	if err != nil {
+		log.Error("failed to init migrations", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	// Migration subcommand: api migrate up|down|status|to VERSION|redo
+	if len(os.Args) > 1 {
+		if err := runMigrate(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Error("failed to migrate", slog.Any("err", err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Run Migrations
-	if err := database.RunMigrations(*cfg, sqlDB); err != nil {
-		log.Error("failed to run migrations", slog.Any("err", err))
+	if cfg.AutoMigrate {
+		results, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Error("failed to run migrations", slog.Any("err", err))
+			os.Exit(1)
+		}
+		log.Info("migrations applied successfully", slog.Int("applied", len(results)), slog.Int64("version", migrator.Latest()))
+	} else {
+		log.Info("auto migration is disabled, readiness waits for \"migrate up\"")
+	}
+
+	// Init Tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, os.Stdout)
+	if err != nil {
+		log.Error("failed to init tracing", slog.Any("err", err))
+		os.Exit(1)
+	}
+	// Flush spans on exit
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Error("failed to flush spans", slog.Any("err", err))
+		}
+	}()
+	log.Info("tracing is initialized", slog.String("exporter", cfg.Tracing.Exporter))
+
+	// Span of every query, query arguments may hold personal data
+	if err := db.Use(otelgorm.NewPlugin(otelgorm.WithoutMetrics(), otelgorm.WithoutQueryVariables())); err != nil {
+		log.Error("failed to init query tracing", slog.Any("err", err))
 		os.Exit(1)
 	}
-	log.Info("migrations applied successfully")
 
 	// Init Repos
 	repo := postgres.NewRepository(db)
 
+	// Init Metrics: pool stats, query durations and business gauges
+	var m *metrics.Metrics
+	metricsCtx, stopMetrics := context.WithCancel(context.Background())
+	defer stopMetrics()
+	if cfg.Metrics.Enabled {
+		m = metrics.New()
+		if err := m.RegisterDB(sqlDB, cfg.Postgres.DBName); err != nil {
+			log.Error("failed to init metrics", slog.Any("err", err))
+			os.Exit(1)
+		}
+		if err := db.Use(m.GORMPlugin()); err != nil {
+			log.Error("failed to init query metrics", slog.Any("err", err))
+			os.Exit(1)
+		}
+		go m.RunTotals(metricsCtx, repo.Department(), cfg.Metrics.RefreshInterval, log)
+	}
+
 	// Init Validator
 	validate := validator.New()
 
 	// Init Service
-	svc := service.NewService(repo, log, validate)
+	svc := service.NewService(repo, log, validate, cfg.Employees)
+
+	// Init Redaction of employee fields, scopes header is trusted only without authentication
+	var scopes httpHandler.ScopeAuthorizer = httpHandler.PrincipalScopes{}
+	if cfg.Auth.Disabled {
+		scopes = httpHandler.HeaderScopes{Header: cfg.Redaction.ScopesHeader}
+	}
+	redactor, err := httpHandler.NewRedactor(cfg.Redaction, scopes)
+	if err != nil {
+		log.Error("failed to init redaction", slog.Any("err", err))
+		os.Exit(1)
+	}
 
 	// Init Handlers
-	handler := httpHandler.NewHandler(svc, log)
+	handler := httpHandler.NewHandler(svc, log, redactor)
+
+	// Health: ready while database is reachable and fully migrated
+	health := httpHandler.NewHealth(log)
+	health.AddCheck("database", sqlDB.PingContext)
+	health.AddCheck("migrations", migrator.CheckMigrated)
 
 	// Router
 	router := httpHandler.NewRouter(handler)
+	router.HandleFunc("GET /healthz", health.Live)
+	router.HandleFunc("GET /readyz", health.Ready)
+	if m != nil {
+		router.Handle("GET /metrics", m.Handler())
+	}
+
+	// Authentication
+	var root http.Handler = router
+	if cfg.Auth.Disabled {
+		log.Warn("authentication is disabled, every endpoint is open")
+	} else {
+		auth, err := service.NewAuthenticator(cfg.Auth, svc.APIKey(), log)
+		if err != nil {
+			log.Error("failed to init authentication", slog.Any("err", err))
+			os.Exit(1)
+		}
+		root = httpHandler.Authenticate(auth, log)(router)
+	}
+
+	// Request ID, access log and panic recovery around everything
+	root = httpHandler.Recover(log)(root)
+	if m != nil {
+		root = httpHandler.Instrument(m, router)(root)
+	}
+	root = httpHandler.RequestID(httpHandler.AccessLog(log, router)(root))
+	root = httpHandler.Trace(router)(root)
 
 	// Start Server
 	server := &http.Server{
 		Addr:         cfg.HTTPServer.Address,
-		Handler:      router,
+		Handler:      root,
 		ReadTimeout:  cfg.HTTPServer.Timeout,
 		WriteTimeout: cfg.HTTPServer.Timeout,
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
@@ -122,6 +241,10 @@ func main() {
 	<-done
 	log.Info("stopping server...")
 
+	// Not ready first, so traffic is drained before server stops accepting it
+	health.SetShuttingDown()
+	time.Sleep(cfg.HTTPServer.ShutdownDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -131,19 +254,17 @@ func main() {
 }
 
 func setupLogger(env string) *slog.Logger {
+	var handler slog.Handler
 	switch env {
 	case envLocal: // Text Debug
-		return slog.New(
-			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
+		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
 	case envDev: // JSON Debug
-		return slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
+		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
 	case envProd: // JSON Info
-		return slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
+		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})
+	default:
+		handler = slog.NewTextHandler(os.Stdout, nil)
 	}
-	return slog.New(slog.NewTextHandler(os.Stdout, nil))
+	// Request ID of context on every record
+	return slog.New(logger.NewContextHandler(handler))
 }
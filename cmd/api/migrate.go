@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tmozzze/org_struct_api/pkg/database"
+)
+
+// migrateUsage - usage of migrate subcommand
+const migrateUsage = `usage: api migrate <command>
+
+commands:
+  up            apply every pending migration
+  down          roll back the newest applied migration
+  status        list migrations with their state
+  to VERSION    apply or roll back migrations until database is at VERSION (0 rolls back everything)
+  redo          roll back the newest applied migration and apply it again`
+
+// runMigrate - runs migrate subcommand with args, writes results to out
+func runMigrate(ctx context.Context, m *database.Migrator, args []string, out io.Writer) error {
+	const op = "main.runMigrate"
+
+	if len(args) == 0 {
+		return fmt.Errorf("%s: no command\n%s", op, migrateUsage)
+	}
+
+	var (
+		results []*database.MigrationResult
+		err     error
+	)
+	switch cmd := args[0]; {
+	case cmd == "up" && len(args) == 1:
+		results, err = m.Up(ctx)
+	case cmd == "down" && len(args) == 1:
+		var result *database.MigrationResult
+		result, err = m.Down(ctx)
+		if result != nil {
+			results = append(results, result)
+		}
+	case cmd == "redo" && len(args) == 1:
+		results, err = m.Redo(ctx)
+	case cmd == "to" && len(args) == 2:
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil || version < 0 {
+			return fmt.Errorf("%s: invalid version '%s'", op, args[1])
+		}
+		results, err = m.To(ctx, version)
+	case cmd == "status" && len(args) == 1:
+		return printMigrationStatus(ctx, m, out)
+	default:
+		return fmt.Errorf("%s: unknown command '%s'\n%s", op, args[0], migrateUsage)
+	}
+
+	for _, result := range results {
+		fmt.Fprintln(out, result)
+	}
+	if errors.Is(err, database.ErrNoMigration) {
+		fmt.Fprintln(out, "no migration to roll back")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(out, "no migrations to apply")
+	}
+
+	version, err := m.Version(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	fmt.Fprintf(out, "database version: %d\n", version)
+
+	return nil
+}
+
+// printMigrationStatus - writes table of migrations with applied time
+func printMigrationStatus(ctx context.Context, m *database.Migrator, out io.Writer) error {
+	const op = "main.printMigrationStatus"
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "APPLIED AT\tMIGRATION")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if !status.AppliedAt.IsZero() {
+			appliedAt = status.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\n", appliedAt, status.Source.Path)
+	}
+
+	return w.Flush()
+}
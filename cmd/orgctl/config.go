@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+// Output formats
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+const (
+	defaultURL     = "http://localhost:8080"
+	defaultProfile = "default"
+)
+
+// Profile - connection settings of one API server
+type Profile struct {
+	URL    string `yaml:"url"`
+	Token  string `yaml:"token"`
+	Output string `yaml:"output"`
+}
+
+// profileFile - profile file, current names profile used without --profile and ORGCTL_PROFILE
+type profileFile struct {
+	Current  string             `yaml:"current"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// globalFlags - settings given on command line, empty when not set
+type globalFlags struct {
+	config  string
+	profile string
+	url     string
+	token   string
+	output  string
+}
+
+// loadProfile - resolves settings from flags, then ORGCTL_* env, then profile file, then defaults
+func loadProfile(flags globalFlags, getenv func(string) string) (Profile, error) {
+	const op = "orgctl.loadProfile"
+
+	path := firstOf(flags.config, getenv("ORGCTL_CONFIG"))
+	explicit := path != ""
+	if !explicit {
+		dir, err := os.UserConfigDir()
+		if err == nil {
+			path = filepath.Join(dir, "orgctl", "config.yaml")
+		}
+	}
+
+	var file profileFile
+	if path != "" {
+		if err := cleanenv.ReadConfig(path, &file); err != nil {
+			// Missing default file is fine, missing explicit file is a mistake
+			if explicit || !errors.Is(err, fs.ErrNotExist) {
+				return Profile{}, fmt.Errorf("%s: failed to read profile file '%s': %w", op, path, err)
+			}
+		}
+	}
+
+	name := firstOf(flags.profile, getenv("ORGCTL_PROFILE"), file.Current)
+	profile, ok := file.Profiles[firstOf(name, defaultProfile)]
+	if !ok && name != "" {
+		return Profile{}, fmt.Errorf("%s: profile '%s' is not in '%s'", op, name, path)
+	}
+
+	resolved := Profile{
+		URL:    firstOf(flags.url, getenv("ORGCTL_URL"), profile.URL, defaultURL),
+		Token:  firstOf(flags.token, getenv("ORGCTL_TOKEN"), profile.Token),
+		Output: firstOf(flags.output, getenv("ORGCTL_OUTPUT"), profile.Output, outputTable),
+	}
+	if resolved.Output != outputTable && resolved.Output != outputJSON {
+		return Profile{}, fmt.Errorf("%s: unknown output '%s', expected %s or %s", op, resolved.Output, outputTable, outputJSON)
+	}
+
+	return resolved, nil
+}
+
+// firstOf - first non empty value
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
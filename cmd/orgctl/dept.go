@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/pkg/client"
+)
+
+// maxDepth - deepest tree one department request returns
+const maxDepth = 5
+
+// deptUsage - usage of dept commands
+const deptUsage = `usage: orgctl dept <command> [flags]
+
+commands:
+  create --name NAME [--parent ID] [--sort-order N] [--type TYPE] [--cost-center CODE] [--budget N]
+  get ID [--depth N] [--employees] [--stats]
+  move ID --parent ID [--sort-order N]
+  delete ID [--reassign-to ID]   cascade delete, or move employees to department
+  tree ID [--depth N] [--employees]`
+
+// runDept - runs dept command
+func runDept(ctx context.Context, c *cli, args []string) error {
+	if len(args) == 0 {
+		return usageError(deptUsage)
+	}
+
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "create":
+		return deptCreate(ctx, c, args)
+	case "get":
+		return deptGet(ctx, c, args)
+	case "move":
+		return deptMove(ctx, c, args)
+	case "delete":
+		return deptDelete(ctx, c, args)
+	case "tree":
+		return deptTree(ctx, c, args)
+	default:
+		return usageError(fmt.Sprintf("unknown dept command '%s'\n%s", cmd, deptUsage))
+	}
+}
+
+func deptCreate(ctx context.Context, c *cli, args []string) error {
+	var req client.CreateDepartmentRequest
+	fs := newFlagSet("dept create", c.stderr)
+	fs.StringVar(&req.Name, "name", "", "department name")
+	fs.Func("parent", "parent department ID, root department without it", intPtrFlag(&req.ParentID))
+	fs.Func("sort-order", "position among siblings, after siblings without it", intPtrFlag(&req.SortOrder))
+	fs.Func("type", "department type code", stringPtrFlag(&req.Type))
+	fs.Func("cost-center", "cost center code", stringPtrFlag(&req.CostCenter))
+	fs.Func("budget", "annual budget", int64PtrFlag(&req.Budget))
+
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	if req.Name == "" {
+		return usageError("--name is required\n" + deptUsage)
+	}
+
+	dept, err := c.api.Department().Create(ctx, &req)
+	if err != nil {
+		return err
+	}
+	return printDepartment(c.stdout, c.output, dept)
+}
+
+func deptGet(ctx context.Context, c *cli, args []string) error {
+	req := client.GetByIDRequest{Depth: 1}
+	fs := newFlagSet("dept get", c.stderr)
+	fs.IntVar(&req.Depth, "depth", req.Depth, "depth of children (1-5)")
+	fs.BoolVar(&req.IncludeEmployees, "employees", false, "with employees")
+	fs.BoolVar(&req.IncludeStats, "stats", false, "with headcount counters")
+
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	dept, err := c.api.Department().GetByID(ctx, ids[0], &req)
+	if err != nil {
+		return err
+	}
+	return printDepartment(c.stdout, c.output, dept)
+}
+
+func deptMove(ctx context.Context, c *cli, args []string) error {
+	var req client.UpdateDepartmentRequest
+	fs := newFlagSet("dept move", c.stderr)
+	fs.Func("parent", "new parent department ID", intPtrFlag(&req.ParentID))
+	fs.Func("sort-order", "position among new siblings, after them without it", intPtrFlag(&req.SortOrder))
+
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if req.ParentID == nil {
+		return usageError("--parent is required\n" + deptUsage)
+	}
+
+	dept, err := c.api.Department().Update(ctx, ids[0], &req)
+	if err != nil {
+		return err
+	}
+	return printDepartment(c.stdout, c.output, dept)
+}
+
+func deptDelete(ctx context.Context, c *cli, args []string) error {
+	req := client.DeleteDepartmentRequest{Mode: client.ModeCascade}
+	fs := newFlagSet("dept delete", c.stderr)
+	fs.Func("reassign-to", "department receiving employees instead of deleting them", intPtrFlag(&req.ReassignToID))
+
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if req.ReassignToID != nil {
+		req.Mode = client.ModeReassign
+	}
+
+	if err := c.api.Department().Delete(ctx, ids[0], &req); err != nil {
+		return err
+	}
+	if c.output == outputJSON {
+		return printJSON(c.stdout, map[string]interface{}{"id": ids[0], "deleted": true})
+	}
+	fmt.Fprintf(c.stdout, "department %d deleted (%s)\n", ids[0], req.Mode)
+	return nil
+}
+
+func deptTree(ctx context.Context, c *cli, args []string) error {
+	req := client.GetByIDRequest{Depth: maxDepth}
+	fs := newFlagSet("dept tree", c.stderr)
+	fs.IntVar(&req.Depth, "depth", req.Depth, "depth of tree (1-5)")
+	fs.BoolVar(&req.IncludeEmployees, "employees", false, "list employees under their department")
+
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	dept, err := c.api.Department().GetByID(ctx, ids[0], &req)
+	if err != nil {
+		return err
+	}
+	if c.output == outputJSON {
+		return printJSON(c.stdout, dept)
+	}
+	printTree(c.stdout, dept)
+	return nil
+}
+
+// runDump - writes whole subtree of department with employees as JSON,
+// subtrees deeper than one request returns are fetched separately
+func runDump(ctx context.Context, c *cli, args []string) error {
+	req := client.GetByIDRequest{Depth: maxDepth, IncludeStats: true, ChildrenLimit: 1000, EmployeesLimit: 1000}
+	fs := newFlagSet("dump", c.stderr)
+	fs.BoolVar(&req.IncludeEmployees, "employees", true, "with employees")
+
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	dept, err := c.api.Department().GetByID(ctx, ids[0], &req)
+	if err != nil {
+		return err
+	}
+	if err := completeTree(ctx, c, dept, req); err != nil {
+		return err
+	}
+
+	return printJSON(c.stdout, dept)
+}
+
+// completeTree - fetches children of departments the depth limit cut off, warns about truncated pages
+func completeTree(ctx context.Context, c *cli, dept *client.DepartmentResponse, req client.GetByIDRequest) error {
+	if dept.ChildrenNextCursor != nil {
+		fmt.Fprintf(c.stderr, "warning: department %d has more than %d children, dump is truncated\n", dept.ID, req.ChildrenLimit)
+	}
+	if dept.EmployeesNextCursor != nil {
+		fmt.Fprintf(c.stderr, "warning: department %d has more than %d employees, dump is truncated\n", dept.ID, req.EmployeesLimit)
+	}
+
+	if len(dept.Children) == 0 && dept.DirectChildrenCount != nil && *dept.DirectChildrenCount > 0 {
+		subtree, err := c.api.Department().GetByID(ctx, dept.ID, &req)
+		if err != nil {
+			return err
+		}
+		dept.Children = subtree.Children
+	}
+
+	for i := range dept.Children {
+		if err := completeTree(ctx, c, &dept.Children[i], req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/pkg/client"
+)
+
+// empUsage - usage of emp commands
+const empUsage = `usage: orgctl emp <command> [flags]
+
+commands:
+  add DEPARTMENT_ID --name NAME (--position TITLE | --position-id ID) [--hired-at YYYY-MM-DD]
+      [--manager ID] [--email EMAIL] [--phone +E164] [--number EMPLOYEE_NUMBER]`
+
+// runEmp - runs emp command
+func runEmp(ctx context.Context, c *cli, args []string) error {
+	if len(args) == 0 {
+		return usageError(empUsage)
+	}
+
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "add":
+		return empAdd(ctx, c, args)
+	default:
+		return usageError(fmt.Sprintf("unknown emp command '%s'\n%s", cmd, empUsage))
+	}
+}
+
+func empAdd(ctx context.Context, c *cli, args []string) error {
+	var req client.CreateEmployeeRequest
+	fs := newFlagSet("emp add", c.stderr)
+	fs.StringVar(&req.FullName, "name", "", "full name")
+	fs.StringVar(&req.Position, "position", "", "position title")
+	fs.Func("position-id", "position from catalog", intPtrFlag(&req.PositionID))
+	fs.Func("hired-at", "hire date, today without it", stringPtrFlag(&req.HiredAt))
+	fs.Func("manager", "manager employee ID", intPtrFlag(&req.ManagerID))
+	fs.Func("email", "work email", stringPtrFlag(&req.Email))
+	fs.Func("phone", "phone in E.164 format", stringPtrFlag(&req.Phone))
+	fs.Func("number", "employee number", stringPtrFlag(&req.EmployeeNumber))
+
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if req.FullName == "" || (req.Position == "" && req.PositionID == nil) {
+		return usageError("--name and --position or --position-id are required\n" + empUsage)
+	}
+
+	emp, err := c.api.Employee().Create(ctx, ids[0], &req)
+	if err != nil {
+		return err
+	}
+	return printEmployee(c.stdout, c.output, emp)
+}
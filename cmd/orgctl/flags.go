@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// errUsage - wrong command line, reported with exit code 2
+var errUsage = errors.New("wrong usage")
+
+// usageErr - wrong command line with message for user
+type usageErr struct {
+	msg string
+}
+
+func (e *usageErr) Error() string        { return e.msg }
+func (e *usageErr) Is(target error) bool { return target == errUsage }
+
+// usageError - error matching errUsage with message for user
+func usageError(msg string) error {
+	return &usageErr{msg: msg}
+}
+
+// newFlagSet - flag set reporting errors instead of exiting
+func newFlagSet(name string, out io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(out)
+	return fs
+}
+
+// parseFlags - parses flags placed before, between or after positional IDs, exactly ids IDs are required
+func parseFlags(fs *flag.FlagSet, args []string, ids int) ([]int, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			// Flag set has already reported error with its defaults
+			return nil, usageError("")
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if len(positional) != ids {
+		return nil, usageError(fmt.Sprintf("%s expects %d ID argument(s), got %d", fs.Name(), ids, len(positional)))
+	}
+
+	result := make([]int, len(positional))
+	for i, arg := range positional {
+		id, err := strconv.Atoi(arg)
+		if err != nil || id <= 0 {
+			return nil, usageError(fmt.Sprintf("invalid ID '%s'", arg))
+		}
+		result[i] = id
+	}
+	return result, nil
+}
+
+// intPtrFlag - sets *dst only when flag is given
+func intPtrFlag(dst **int) func(string) error {
+	return func(s string) error {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		*dst = &v
+		return nil
+	}
+}
+
+// int64PtrFlag - sets *dst only when flag is given
+func int64PtrFlag(dst **int64) func(string) error {
+	return func(s string) error {
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		*dst = &v
+		return nil
+	}
+}
+
+// stringPtrFlag - sets *dst only when flag is given
+func stringPtrFlag(dst **string) func(string) error {
+	return func(s string) error {
+		*dst = &s
+		return nil
+	}
+}
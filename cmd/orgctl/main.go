@@ -0,0 +1,106 @@
+// Command orgctl - command line client of org_struct_api
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/tmozzze/org_struct_api/pkg/client"
+)
+
+// usage - usage of orgctl
+const usage = `usage: orgctl [global flags] <command> [flags]
+
+commands:
+  dept create|get|move|delete|tree   manage departments
+  emp add                            add employee to department
+  dump ID                            write whole subtree of department as JSON
+
+global flags:
+  --config PATH     profile file, $ORGCTL_CONFIG or <user config dir>/orgctl/config.yaml
+  --profile NAME    profile of file, $ORGCTL_PROFILE or "current" of file or "default"
+  --url URL         API address, $ORGCTL_URL or profile url or http://localhost:8080
+  --token TOKEN     JWT or API key, $ORGCTL_TOKEN or profile token
+  -o FORMAT         table or json, $ORGCTL_OUTPUT or profile output or table`
+
+// cli - resolved settings and outputs shared by commands
+type cli struct {
+	api    *client.Client
+	output string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr))
+}
+
+// run - runs orgctl with args and returns exit code: 0 on success, 1 on failure, 2 on wrong usage
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+	var flags globalFlags
+	fs := newFlagSet("orgctl", stderr)
+	fs.Usage = func() { fmt.Fprintln(stderr, usage) }
+	fs.StringVar(&flags.config, "config", "", "profile file")
+	fs.StringVar(&flags.profile, "profile", "", "profile name")
+	fs.StringVar(&flags.url, "url", "", "API address")
+	fs.StringVar(&flags.token, "token", "", "JWT or API key")
+	fs.StringVar(&flags.output, "o", "", "output format (table|json)")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+
+	profile, err := loadProfile(flags, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "orgctl: %s\n", err)
+		return 1
+	}
+	api, err := client.New(profile.URL, client.WithToken(profile.Token))
+	if err != nil {
+		fmt.Fprintf(stderr, "orgctl: %s\n", err)
+		return 1
+	}
+	c := &cli{api: api, output: profile.Output, stdout: stdout, stderr: stderr}
+
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "dept":
+		err = runDept(ctx, c, args)
+	case "emp":
+		err = runEmp(ctx, c, args)
+	case "dump":
+		err = runDump(ctx, c, args)
+	default:
+		err = usageError(fmt.Sprintf("unknown command '%s'\n%s", cmd, usage))
+	}
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		if msg := err.Error(); msg != "" {
+			fmt.Fprintf(stderr, "orgctl: %s\n", msg)
+		}
+		return 2
+	default:
+		fmt.Fprintf(stderr, "orgctl: %s\n", err)
+		return 1
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+// runCLI - runs orgctl against server with empty profile file
+func runCLI(t *testing.T, url string, args ...string) (int, string, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("profiles: {}\n"), 0o600))
+	env := map[string]string{
+		"ORGCTL_URL":    url,
+		"ORGCTL_TOKEN":  "test-token",
+		"ORGCTL_CONFIG": path,
+	}
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, func(k string) string { return env[k] }, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestDeptTree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/departments/1", r.URL.Path)
+		assert.Equal(t, "5", r.URL.Query().Get("depth"))
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(dto.DepartmentResponse{ID: 1, Name: "Company", Children: []dto.DepartmentResponse{
+			{ID: 2, Name: "Engineering", Children: []dto.DepartmentResponse{{ID: 4, Name: "Backend"}}},
+			{ID: 3, Name: "Sales"},
+		}})
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server.URL, "dept", "tree", "1")
+
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "Company [1]\n"+
+		"|-- Engineering [2]\n"+
+		"|   `-- Backend [4]\n"+
+		"`-- Sales [3]\n", stdout)
+}
+
+func TestDump_FetchesDeepSubtrees(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		switch r.URL.Path {
+		case "/departments/1":
+			// Depth limit cut children of department 2
+			json.NewEncoder(w).Encode(dto.DepartmentResponse{ID: 1, Name: "Company", DirectChildrenCount: int64Ptr(1),
+				Children: []dto.DepartmentResponse{{ID: 2, Name: "Deep", DirectChildrenCount: int64Ptr(1)}}})
+		case "/departments/2":
+			json.NewEncoder(w).Encode(dto.DepartmentResponse{ID: 2, Name: "Deep", DirectChildrenCount: int64Ptr(1),
+				Children: []dto.DepartmentResponse{{ID: 3, Name: "Deeper", DirectChildrenCount: int64Ptr(0)}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server.URL, "dump", "1")
+
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, []string{"/departments/1", "/departments/2"}, requested)
+
+	var dump dto.DepartmentResponse
+	require.NoError(t, json.Unmarshal([]byte(stdout), &dump))
+	require.Len(t, dump.Children, 1)
+	require.Len(t, dump.Children[0].Children, 1)
+	assert.Equal(t, "Deeper", dump.Children[0].Children[0].Name)
+}
+
+func TestDump_WarnsAboutTruncatedList(t *testing.T) {
+	next := "cursor"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1000", r.URL.Query().Get("employees_limit"))
+		json.NewEncoder(w).Encode(dto.DepartmentResponse{ID: 1, Name: "Company", DirectChildrenCount: int64Ptr(0),
+			Employees: []dto.EmployeeResponse{{ID: 7, FullName: "Ann"}}, EmployeesNextCursor: &next})
+	}))
+	defer server.Close()
+
+	code, _, stderr := runCLI(t, server.URL, "dump", "1")
+
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "warning: department 1 has more than 1000 employees, dump is truncated\n", stderr)
+}
+
+func TestDeptMove_SendsParent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		var req dto.UpdateDepartmentRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.NotNil(t, req.ParentID)
+		assert.Equal(t, 7, *req.ParentID)
+		assert.Nil(t, req.Name)
+		json.NewEncoder(w).Encode(dto.DepartmentResponse{ID: 3, Name: "Sales", ParentID: req.ParentID})
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server.URL, "-o", "json", "dept", "move", "3", "--parent", "7")
+
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, `"parent_id": 7`)
+}
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"department not found"}`))
+	}))
+	defer server.Close()
+
+	code, _, stderr := runCLI(t, server.URL, "dept", "get", "9")
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "404 Not Found: department not found")
+}
+
+func TestUsageErrors(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"unknown"},
+		{"dept", "get"},
+		{"dept", "move", "1"},
+		{"emp", "add", "1", "--name", "Ann"},
+	}
+	for _, args := range tests {
+		code, _, _ := runCLI(t, "http://127.0.0.1:0", args...)
+		assert.Equal(t, 2, code, args)
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+current: prod
+profiles:
+  prod:
+    url: https://org.example.com
+    token: prod-token
+    output: json
+  staging:
+    url: https://staging.example.com
+`), 0o600))
+
+	env := map[string]string{"ORGCTL_CONFIG": path}
+	getenv := func(k string) string { return env[k] }
+
+	// Current profile of file
+	p, err := loadProfile(globalFlags{}, getenv)
+	require.NoError(t, err)
+	assert.Equal(t, Profile{URL: "https://org.example.com", Token: "prod-token", Output: outputJSON}, p)
+
+	// Env selects profile and overrides its values, flags override env
+	env["ORGCTL_PROFILE"] = "staging"
+	env["ORGCTL_TOKEN"] = "env-token"
+	p, err = loadProfile(globalFlags{output: outputTable}, getenv)
+	require.NoError(t, err)
+	assert.Equal(t, Profile{URL: "https://staging.example.com", Token: "env-token", Output: outputTable}, p)
+
+	_, err = loadProfile(globalFlags{profile: "missing"}, getenv)
+	assert.Error(t, err)
+
+	_, err = loadProfile(globalFlags{output: "yaml"}, getenv)
+	assert.Error(t, err)
+}
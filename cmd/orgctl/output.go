@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/tmozzze/org_struct_api/pkg/client"
+)
+
+// printJSON - writes v as indented JSON
+func printJSON(out io.Writer, v interface{}) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printDepartment - writes department in format, table lists department, its children and employees
+func printDepartment(out io.Writer, format string, dept *client.DepartmentResponse) error {
+	if format == outputJSON {
+		return printJSON(out, dept)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPARENT\tTYPE\tHEAD\tCREATED AT")
+	fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+		dept.ID, dept.Name, optionalInt(dept.ParentID), optionalString(dept.Type), headName(dept.Head), dept.CreatedAt.Format("2006-01-02"))
+
+	if len(dept.Children) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "CHILD ID\tNAME\tTYPE\tSORT ORDER")
+		for _, child := range dept.Children {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\n", child.ID, child.Name, optionalString(child.Type), child.SortOrder)
+		}
+	}
+
+	if len(dept.Employees) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "EMPLOYEE ID\tFULL NAME\tPOSITION\tSTATUS")
+		for _, emp := range dept.Employees {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", emp.ID, emp.FullName, emp.Position, emp.Status)
+		}
+	}
+
+	return w.Flush()
+}
+
+// printEmployee - writes employee in format
+func printEmployee(out io.Writer, format string, emp *client.EmployeeResponse) error {
+	if format == outputJSON {
+		return printJSON(out, emp)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tFULL NAME\tPOSITION\tDEPARTMENT\tMANAGER\tHIRED AT\tSTATUS")
+	fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s\t%s\n",
+		emp.ID, emp.FullName, emp.Position, emp.DepartmentID, optionalInt(emp.ManagerID), optionalString(emp.HiredAt), emp.Status)
+
+	return w.Flush()
+}
+
+// printTree - writes department tree as indented ASCII tree, employees are listed under their department
+func printTree(out io.Writer, dept *client.DepartmentResponse) {
+	fmt.Fprintln(out, treeLabel(*dept))
+	printSubtree(out, *dept, "")
+}
+
+// printSubtree - writes employees and children of dept with prefix of their depth
+func printSubtree(out io.Writer, dept client.DepartmentResponse, prefix string) {
+	items := len(dept.Employees) + len(dept.Children)
+	if dept.ChildrenNextCursor != nil {
+		items++
+	}
+
+	for _, emp := range dept.Employees {
+		items--
+		fmt.Fprintf(out, "%s%s%s, %s (%d)\n", prefix, branch(items == 0), emp.FullName, emp.Position, emp.ID)
+	}
+	for _, child := range dept.Children {
+		items--
+		fmt.Fprintf(out, "%s%s%s\n", prefix, branch(items == 0), treeLabel(child))
+		printSubtree(out, child, prefix+indent(items == 0))
+	}
+	if dept.ChildrenNextCursor != nil {
+		fmt.Fprintf(out, "%s%s...\n", prefix, branch(true))
+	}
+}
+
+// treeLabel - department line of tree
+func treeLabel(dept client.DepartmentResponse) string {
+	label := dept.Name + " [" + strconv.Itoa(dept.ID) + "]"
+	if dept.Type != nil {
+		label += " (" + *dept.Type + ")"
+	}
+	return label
+}
+
+func branch(last bool) string {
+	if last {
+		return "`-- "
+	}
+	return "|-- "
+}
+
+func indent(last bool) string {
+	if last {
+		return "    "
+	}
+	return "|   "
+}
+
+func headName(head *client.EmployeeResponse) string {
+	if head == nil {
+		return "-"
+	}
+	return head.FullName
+}
+
+func optionalInt(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(*v)
+}
+
+func optionalString(v *string) string {
+	if v == nil || *v == "" {
+		return "-"
+	}
+	return *v
+}
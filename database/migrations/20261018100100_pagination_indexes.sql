@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Keyset pagination of children and employees inside a department
+CREATE INDEX IF NOT EXISTS idx_dept_parent_name_id ON departments (parent_id, name, id);
+CREATE INDEX IF NOT EXISTS idx_emp_dept_full_name_id ON employees (department_id, full_name, id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_emp_dept_full_name_id;
+DROP INDEX IF EXISTS idx_dept_parent_name_id;
+-- +goose StatementEnd
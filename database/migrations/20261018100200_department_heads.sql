@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE departments ADD COLUMN IF NOT EXISTS head_employee_id INT REFERENCES employees(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_dept_head_employee_id ON departments (head_employee_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_dept_head_employee_id;
+ALTER TABLE departments DROP COLUMN IF EXISTS head_employee_id;
+-- +goose StatementEnd
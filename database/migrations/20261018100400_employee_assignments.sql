@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS employee_assignments (
+    id SERIAL PRIMARY KEY,
+    employee_id INT NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    department_id INT NOT NULL REFERENCES departments(id) ON DELETE CASCADE,
+    role VARCHAR(200) NOT NULL DEFAULT '',
+    allocation_percent INT NOT NULL CHECK (allocation_percent BETWEEN 1 AND 100),
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_assignment_employee_department ON employee_assignments (employee_id, department_id);
+CREATE UNIQUE INDEX idx_assignment_employee_primary ON employee_assignments (employee_id) WHERE is_primary;
+CREATE INDEX idx_assignment_department_id ON employee_assignments (department_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS employee_assignments;
+-- +goose StatementEnd
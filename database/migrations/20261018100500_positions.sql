@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS positions (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    family VARCHAR(100) NOT NULL DEFAULT '',
+    level INT CHECK (level >= 0),
+    grade_band VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_position_title ON positions (lower(title));
+
+-- One position per case-insensitive title, the most used spelling wins
+INSERT INTO positions (title)
+SELECT DISTINCT ON (lower(btrim(position))) btrim(position)
+FROM employees
+WHERE btrim(position) <> ''
+GROUP BY btrim(position)
+ORDER BY lower(btrim(position)), COUNT(*) DESC, btrim(position);
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS position_id INT REFERENCES positions(id) ON DELETE RESTRICT;
+
+UPDATE employees e
+SET position_id = p.id, position = p.title
+FROM positions p
+WHERE lower(p.title) = lower(btrim(e.position));
+
+CREATE INDEX IF NOT EXISTS idx_emp_position_id ON employees (position_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_emp_position_id;
+ALTER TABLE employees DROP COLUMN IF EXISTS position_id;
+DROP TABLE IF EXISTS positions;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS terminated_at DATE;
+
+ALTER TABLE employees ADD CONSTRAINT chk_emp_status CHECK (status IN ('active', 'on_leave', 'terminated'));
+ALTER TABLE employees ADD CONSTRAINT chk_emp_terminated_at CHECK ((status = 'terminated') = (terminated_at IS NOT NULL));
+ALTER TABLE employees ADD CONSTRAINT chk_emp_terminated_after_hired CHECK (terminated_at IS NULL OR hired_at IS NULL OR terminated_at >= hired_at);
+
+-- Most reads skip terminated employees
+CREATE INDEX IF NOT EXISTS idx_emp_department_current ON employees (department_id) WHERE status <> 'terminated';
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_emp_department_current;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS chk_emp_terminated_after_hired;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS chk_emp_terminated_at;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS chk_emp_status;
+ALTER TABLE employees DROP COLUMN IF EXISTS terminated_at;
+ALTER TABLE employees DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
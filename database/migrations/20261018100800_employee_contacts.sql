@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS email VARCHAR(254);
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS phone VARCHAR(16);
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS employee_number VARCHAR(50);
+
+ALTER TABLE employees ADD CONSTRAINT chk_emp_phone_e164 CHECK (phone ~ '^\+[1-9][0-9]{1,14}$');
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_emp_email ON employees (lower(email));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_emp_employee_number ON employees (employee_number);
+
+-- Source of auto generated employee numbers
+CREATE SEQUENCE IF NOT EXISTS employee_number_seq;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP SEQUENCE IF EXISTS employee_number_seq;
+DROP INDEX IF EXISTS idx_emp_employee_number;
+DROP INDEX IF EXISTS idx_emp_email;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS chk_emp_phone_e164;
+ALTER TABLE employees DROP COLUMN IF EXISTS employee_number;
+ALTER TABLE employees DROP COLUMN IF EXISTS phone;
+ALTER TABLE employees DROP COLUMN IF EXISTS email;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS attribute_definitions (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('department', 'employee')),
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('string', 'number', 'boolean', 'enum')),
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    enum_values JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (entity_type, name)
+);
+
+ALTER TABLE departments ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+-- Containment (@>) filters by attribute values
+CREATE INDEX IF NOT EXISTS idx_dept_attributes ON departments USING GIN (attributes jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_emp_attributes ON employees USING GIN (attributes jsonb_path_ops);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_emp_attributes;
+DROP INDEX IF EXISTS idx_dept_attributes;
+ALTER TABLE employees DROP COLUMN IF EXISTS attributes;
+ALTER TABLE departments DROP COLUMN IF EXISTS attributes;
+DROP TABLE IF EXISTS attribute_definitions;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS department_types (
+    name VARCHAR(50) PRIMARY KEY,
+    root_allowed BOOLEAN NOT NULL DEFAULT TRUE,
+    max_depth INT CHECK (max_depth >= 1),
+    max_children INT CHECK (max_children >= 0),
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Allowed parent/child type pairs, a type without pairs cannot contain anything
+CREATE TABLE IF NOT EXISTS department_type_rules (
+    parent_type VARCHAR(50) NOT NULL REFERENCES department_types(name) ON DELETE CASCADE,
+    child_type VARCHAR(50) NOT NULL REFERENCES department_types(name) ON DELETE CASCADE,
+    PRIMARY KEY (parent_type, child_type)
+);
+
+ALTER TABLE departments ADD COLUMN IF NOT EXISTS type VARCHAR(50) REFERENCES department_types(name) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_dept_type ON departments (type);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_dept_type;
+ALTER TABLE departments DROP COLUMN IF EXISTS type;
+DROP TABLE IF EXISTS department_type_rules;
+DROP TABLE IF EXISTS department_types;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Position among siblings, siblings with equal sort_order are sorted by name
+ALTER TABLE departments ADD COLUMN IF NOT EXISTS sort_order INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_dept_parent_sort ON departments (parent_id, sort_order, name, id);
+
+-- Children are paged by sort order now, name keyset index is unused
+DROP INDEX IF EXISTS idx_dept_parent_name_id;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_dept_parent_name_id ON departments (parent_id, name, id);
+DROP INDEX IF EXISTS idx_dept_parent_sort;
+ALTER TABLE departments DROP COLUMN IF EXISTS sort_order;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Names are stored NFC-normalised with whitespace collapsed to single spaces,
+-- fails on siblings which differ only by case or whitespace, they must be renamed first
+UPDATE departments
+SET name = btrim(regexp_replace(normalize(name, NFC), '[[:space:]\u0085\u00a0\u1680\u2000-\u200a\u2028\u2029\u202f\u205f\u3000]+', ' ', 'g'))
+WHERE name <> btrim(regexp_replace(normalize(name, NFC), '[[:space:]\u0085\u00a0\u1680\u2000-\u200a\u2028\u2029\u202f\u205f\u3000]+', ' ', 'g'));
+
+DROP INDEX IF EXISTS idx_dept_name_parent_id;
+DROP INDEX IF EXISTS idx_dept_name_root;
+
+CREATE UNIQUE INDEX idx_dept_name_parent_id ON departments (lower(name), parent_id) WHERE parent_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_dept_name_root ON departments (lower(name)) WHERE parent_id IS NULL;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_dept_name_parent_id;
+DROP INDEX IF EXISTS idx_dept_name_root;
+
+CREATE UNIQUE INDEX idx_dept_name_parent_id ON departments (name, parent_id) WHERE parent_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_dept_name_root ON departments (name) WHERE parent_id IS NULL;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Cost center codes are stored upper-cased, budget is annual in whole currency units
+ALTER TABLE departments ADD COLUMN IF NOT EXISTS cost_center VARCHAR(20);
+ALTER TABLE departments ADD COLUMN IF NOT EXISTS budget BIGINT CHECK (budget >= 0);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dept_cost_center ON departments (cost_center) WHERE cost_center IS NOT NULL;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_dept_cost_center;
+ALTER TABLE departments DROP COLUMN IF EXISTS budget;
+ALTER TABLE departments DROP COLUMN IF EXISTS cost_center;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Role of subject on department applies to its whole subtree
+CREATE TABLE IF NOT EXISTS department_grants (
+    id SERIAL PRIMARY KEY,
+    subject VARCHAR(200) NOT NULL,
+    department_id INT NOT NULL REFERENCES departments(id) ON DELETE CASCADE,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('viewer', 'editor', 'admin')),
+    granted_by VARCHAR(200),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT uq_grant_subject_department UNIQUE (subject, department_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_grant_department ON department_grants (department_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS department_grants;
+-- +goose StatementEnd
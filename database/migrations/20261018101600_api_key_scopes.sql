@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Space separated scopes unlocking redacted employee fields for the key
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS scopes VARCHAR(500) NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE api_keys DROP COLUMN IF EXISTS scopes;
+-- +goose StatementEnd
@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS - SQL migrations embedded into the binary
+//
+//go:embed *.sql
+var FS embed.FS
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -11,11 +12,17 @@ import (
 
 // Config - application configuration
 type Config struct {
-	Env           string      `yaml:"env" env-default:"local"`
-	HTTPServer    HTTPServer  `yaml:"http_server"`
-	Postgres      PostgresCfg `yaml:"postgres"`
-	MigrationsDir string      `yaml:"migrations_dir" env-default:"./database/migrations"`
-	DBDialect     string      `yaml:"db_dialect" env-default:"postgres"`
+	Env        string       `yaml:"env" env-default:"local"`
+	HTTPServer HTTPServer   `yaml:"http_server"`
+	Postgres   PostgresCfg  `yaml:"postgres"`
+	DBDialect  string       `yaml:"db_dialect" env-default:"postgres"`
+	Employees  EmployeesCfg `yaml:"employees"`
+	Auth       AuthCfg      `yaml:"auth"`
+	Redaction  RedactionCfg `yaml:"redaction"`
+	Metrics    MetricsCfg   `yaml:"metrics"`
+	Tracing    TracingCfg   `yaml:"tracing"`
+	// AutoMigrate - apply pending migrations on server start, otherwise they are applied with "migrate up"
+	AutoMigrate bool `yaml:"auto_migrate" env:"AUTO_MIGRATE" env-default:"true"`
 }
 
 // HTTPServer - configuration for HTTP server
@@ -23,6 +30,75 @@ type HTTPServer struct {
 	Address     string        `yaml:"address" env-default:"localhost:8080"`
 	Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
+	// ShutdownDelay - time between reporting not ready and stopping server, lets load balancer drain traffic
+	ShutdownDelay time.Duration `yaml:"shutdown_delay" env:"HTTP_SHUTDOWN_DELAY" env-default:"5s"`
+}
+
+// EmployeesCfg - configuration for employees
+type EmployeesCfg struct {
+	// NumberPattern - fmt pattern with one integer verb for auto generated employee numbers, empty disables generation
+	NumberPattern string `yaml:"number_pattern" env:"EMPLOYEE_NUMBER_PATTERN" env-default:"EMP-%06d"`
+}
+
+// AuthCfg - configuration for authentication of API requests
+type AuthCfg struct {
+	// Disabled - serve every endpoint without authentication, for local development only
+	Disabled bool `yaml:"disabled" env:"AUTH_DISABLED" env-default:"false"`
+	// BootstrapKey - static admin API key to issue the first keys with, empty disables it
+	BootstrapKey string `yaml:"bootstrap_key" env:"AUTH_BOOTSTRAP_KEY"`
+	JWT          JWTCfg `yaml:"jwt"`
+}
+
+// JWTCfg - keys and claims of accepted JWT bearer tokens, JWTs are rejected when no key is configured
+type JWTCfg struct {
+	// HMACSecret - shared secret of HS256 tokens
+	HMACSecret string `yaml:"hmac_secret" env:"AUTH_JWT_HMAC_SECRET"`
+	// RSAPublicKeyFile - PEM public key of RS256 tokens
+	RSAPublicKeyFile string `yaml:"rsa_public_key_file" env:"AUTH_JWT_RSA_PUBLIC_KEY_FILE"`
+	// JWKSFile - JSON Web Key Set of RS256 tokens, keys are selected by kid
+	JWKSFile string `yaml:"jwks_file" env:"AUTH_JWT_JWKS_FILE"`
+	// Issuer, Audience - required iss and aud claims, empty skips the check
+	Issuer   string `yaml:"issuer" env:"AUTH_JWT_ISSUER"`
+	Audience string `yaml:"audience" env:"AUTH_JWT_AUDIENCE"`
+	// RolesClaim - claim with list of roles, principal with AdminRole is admin
+	RolesClaim string `yaml:"roles_claim" env-default:"roles"`
+	AdminRole  string `yaml:"admin_role" env-default:"admin"`
+	// ScopesClaim - claim with list of scopes unlocking redacted employee fields
+	ScopesClaim string `yaml:"scopes_claim" env-default:"scope"`
+}
+
+// RedactionCfg - policy hiding employee fields from callers without the required scopes
+type RedactionCfg struct {
+	// ScopesHeader - request header with space or comma separated scopes of caller, set by trusted gateway,
+	// read only when authentication is disabled, otherwise scopes come from the authenticated principal
+	ScopesHeader string `yaml:"scopes_header" env:"REDACTION_SCOPES_HEADER" env-default:"X-Scopes"`
+	// Fields - rule by json name of employee field, fields without rule are visible to everyone
+	Fields map[string]RedactionFieldCfg `yaml:"fields"`
+}
+
+// RedactionFieldCfg - scope required to see employee field, without it the field is omitted or masked
+type RedactionFieldCfg struct {
+	Scope string `yaml:"scope"`
+	Mode  string `yaml:"mode"`
+}
+
+// MetricsCfg - configuration of Prometheus metrics served on /metrics without authentication
+type MetricsCfg struct {
+	Enabled bool `yaml:"enabled" env:"METRICS_ENABLED" env-default:"true"`
+	// RefreshInterval - period of recounting departments, employees and tree depth
+	RefreshInterval time.Duration `yaml:"refresh_interval" env:"METRICS_REFRESH_INTERVAL" env-default:"30s"`
+}
+
+// TracingCfg - configuration of OpenTelemetry tracing
+type TracingCfg struct {
+	// Exporter - where spans are sent: none, stdout or otlp
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	// Endpoint - host:port of OTLP HTTP collector, empty uses OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318
+	Endpoint string `yaml:"endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	// Insecure - send spans to OTLP collector over plain HTTP
+	Insecure    bool    `yaml:"insecure" env:"TRACING_OTLP_INSECURE" env-default:"false"`
+	ServiceName string  `yaml:"service_name" env-default:"org_struct_api"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
 }
 
 // PostgresCfg - configuration for PostgreSQL database
@@ -74,5 +150,15 @@ func MustLoad() *Config {
 		log.Fatalf("cannot read config: %s", err)
 	}
 
+	// One integer verb, e.g. EMP-%06d
+	if p := cfg.Employees.NumberPattern; p != "" && strings.Count(p, "%")-2*strings.Count(p, "%%") != 1 {
+		log.Fatalf("employees.number_pattern must contain exactly one integer verb: %q", p)
+	}
+
+	// Gauges are refreshed by ticker, which needs positive period
+	if cfg.Metrics.Enabled && cfg.Metrics.RefreshInterval <= 0 {
+		log.Fatalf("metrics.refresh_interval must be positive: %s", cfg.Metrics.RefreshInterval)
+	}
+
 	return &cfg
 }
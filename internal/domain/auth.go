@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+const (
+	// AuthMethodJWT - principal authenticated by JWT bearer token
+	AuthMethodJWT = "jwt"
+	// AuthMethodAPIKey - principal authenticated by API key
+	AuthMethodAPIKey = "api_key"
+	// APIKeyPrefix - every issued API key starts with it, tells API keys from JWTs
+	APIKeyPrefix = "osk_"
+	// RoleViewer - read employees of department subtree
+	RoleViewer = "viewer"
+	// RoleEditor - change departments and employees of department subtree
+	RoleEditor = "editor"
+	// RoleAdmin - delete departments of department subtree and manage its grants
+	RoleAdmin = "admin"
+)
+
+// RoleRank - rank of role, every role includes the rights of lower ranked ones (0 - unknown role)
+func RoleRank(role string) int {
+	switch role {
+	case RoleViewer:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// Authenticator - resolve principal from bearer credentials, ErrUnauthenticated for invalid ones.
+// Roles of the principal are resolved from department grants of its subject, so any
+// implementation producing stable subjects may be plugged in
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*models.Principal, error)
+}
+
+type principalKey struct{}
+
+// WithPrincipal - put authenticated principal on context
+func WithPrincipal(ctx context.Context, p *models.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext - authenticated principal of the request, false for calls made without authentication
+func PrincipalFromContext(ctx context.Context) (*models.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*models.Principal)
+	return p, ok && p != nil
+}
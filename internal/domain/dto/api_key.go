@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// CreateAPIKeyRequest - request payload for issuing an API key, without expires_at the key never expires,
+// scopes unlock employee fields hidden by redaction policy
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,min=1,max=100"`
+	IsAdmin   bool       `json:"is_admin"`
+	Scopes    []string   `json:"scopes" validate:"max=20,dive,min=1,max=100,excludesall=0x2C0x20"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyResponse - response payload for API key data, the key itself is never returned
+type APIKeyResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	IsAdmin    bool       `json:"is_admin"`
+	Scopes     []string   `json:"scopes,omitempty"`
+	CreatedBy  *string    `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// APIKeyCreatedResponse - response payload for issued API key, key is shown only once
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// NewAPIKeyResponse - convert APIKey model to APIKeyResponse DTO
+func NewAPIKeyResponse(m models.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         m.ID,
+		Name:       m.Name,
+		Prefix:     m.Prefix,
+		IsAdmin:    m.IsAdmin,
+		Scopes:     strings.Fields(m.Scopes),
+		CreatedBy:  m.CreatedBy,
+		CreatedAt:  m.CreatedAt,
+		ExpiresAt:  m.ExpiresAt,
+		LastUsedAt: m.LastUsedAt,
+		RevokedAt:  m.RevokedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// CreateAssignmentRequest - request payload for assigning an employee to a department
+type CreateAssignmentRequest struct {
+	DepartmentID      int    `json:"department_id" validate:"required,gt=0"`
+	Role              string `json:"role" validate:"max=200"`
+	AllocationPercent int    `json:"allocation_percent" validate:"required,min=1,max=100"`
+	IsPrimary         bool   `json:"is_primary"`
+}
+
+// UpdateAssignmentRequest - request payload for updating an assignment
+type UpdateAssignmentRequest struct {
+	Role              *string `json:"role" validate:"omitempty,max=200"`
+	AllocationPercent *int    `json:"allocation_percent" validate:"omitempty,min=1,max=100"`
+}
+
+// AssignmentResponse - response payload for assignment data
+type AssignmentResponse struct {
+	ID                int       `json:"id"`
+	EmployeeID        int       `json:"employee_id"`
+	DepartmentID      int       `json:"department_id"`
+	Role              string    `json:"role"`
+	AllocationPercent int       `json:"allocation_percent"`
+	IsPrimary         bool      `json:"is_primary"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// NewAssignmentResponse - convert EmployeeAssignment model to AssignmentResponse DTO
+func NewAssignmentResponse(m models.EmployeeAssignment) AssignmentResponse {
+	return AssignmentResponse{
+		ID:                m.ID,
+		EmployeeID:        m.EmployeeID,
+		DepartmentID:      m.DepartmentID,
+		Role:              m.Role,
+		AllocationPercent: m.AllocationPercent,
+		IsPrimary:         m.IsPrimary,
+		CreatedAt:         m.CreatedAt,
+	}
+}
+
+// SecondaryMemberResponse - response payload for an employee assigned to a department besides the home one
+type SecondaryMemberResponse struct {
+	AssignmentID      int              `json:"assignment_id"`
+	Role              string           `json:"role"`
+	AllocationPercent int              `json:"allocation_percent"`
+	Employee          EmployeeResponse `json:"employee"`
+}
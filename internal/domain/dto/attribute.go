@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// CreateAttributeRequest - request payload for defining a custom attribute,
+// enum values are required for enum attributes only
+type CreateAttributeRequest struct {
+	EntityType string   `json:"entity_type" validate:"required,oneof=department employee"`
+	Name       string   `json:"name" validate:"required,min=1,max=100"`
+	Type       string   `json:"type" validate:"required,oneof=string number boolean enum"`
+	Required   bool     `json:"required"`
+	EnumValues []string `json:"enum_values" validate:"required_if=Type enum,omitempty,min=1,dive,required,max=200"`
+}
+
+// UpdateAttributeRequest - request payload for updating a custom attribute, name and type are immutable
+type UpdateAttributeRequest struct {
+	Required   *bool    `json:"required"`
+	EnumValues []string `json:"enum_values" validate:"omitempty,min=1,dive,required,max=200"`
+}
+
+// ListAttributesRequest - request payload for listing custom attributes
+type ListAttributesRequest struct {
+	EntityType string `json:"entity_type" validate:"omitempty,oneof=department employee"`
+}
+
+// AttributeResponse - response payload for custom attribute definition
+type AttributeResponse struct {
+	ID         int       `json:"id"`
+	EntityType string    `json:"entity_type"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Required   bool      `json:"required"`
+	EnumValues []string  `json:"enum_values,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewAttributeResponse - convert AttributeDefinition model to AttributeResponse DTO
+func NewAttributeResponse(m models.AttributeDefinition) AttributeResponse {
+	return AttributeResponse{
+		ID:         m.ID,
+		EntityType: m.EntityType,
+		Name:       m.Name,
+		Type:       m.Type,
+		Required:   m.Required,
+		EnumValues: m.EnumValues,
+		CreatedAt:  m.CreatedAt,
+	}
+}
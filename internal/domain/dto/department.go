@@ -6,22 +6,65 @@ import (
 	"github.com/tmozzze/org_struct_api/internal/domain/models"
 )
 
-// CreateDepartmentRequest - request payload for creating a department
+// CreateDepartmentRequest - request payload for creating a department,
+// without sort_order the department is placed after its siblings
 type CreateDepartmentRequest struct {
-	Name     string `json:"name" validate:"required,min=1,max=200"`
-	ParentID *int   `json:"parent_id" validate:"omitempty,gt=0"`
+	Name      string `json:"name" validate:"required,min=1,max=200"`
+	ParentID  *int   `json:"parent_id" validate:"omitempty,gt=0"`
+	SortOrder *int   `json:"sort_order" validate:"omitempty,min=0"`
+
+	Type       *string                `json:"type" validate:"omitempty,max=50"`
+	Attributes map[string]interface{} `json:"attributes"`
+
+	CostCenter *string `json:"cost_center" validate:"omitempty,max=20"`
+	Budget     *int64  `json:"budget" validate:"omitempty,min=0"`
 }
 
 // GetByIDRequest - request payload for getting by id
 type GetByIDRequest struct {
 	Depth            int  `json:"depth" validate:"min=1,max=5"`
 	IncludeEmployees bool `json:"include_employees"`
+	ChildrenLimit    int  `json:"children_limit" validate:"min=0,max=1000"`
+	EmployeesLimit   int  `json:"employees_limit" validate:"min=0,max=1000"`
+	IncludeStats     bool `json:"include_stats"`
+	IncludeSecondary bool `json:"include_secondary"`
+	// IncludeTerminated - include terminated employees in employee lists and secondary members
+	IncludeTerminated bool `json:"include_terminated"`
 }
 
-// UpdateDepartmentRequest - request payload for updating a department
+// UpdateDepartmentRequest - request payload for updating a department,
+// empty type makes the department untyped and attributes replace all custom attributes of the department,
+// moved department without sort_order is placed after its new siblings, empty cost_center removes the cost center,
+// null budget keeps the current one and clear_budget removes it
 type UpdateDepartmentRequest struct {
-	Name     *string `json:"name" validate:"omitempty,min=1,max=200"`
-	ParentID *int    `json:"parent_id" validate:"omitempty,gt=0"`
+	Name      *string `json:"name" validate:"omitempty,min=1,max=200"`
+	ParentID  *int    `json:"parent_id" validate:"omitempty,gt=0"`
+	SortOrder *int    `json:"sort_order" validate:"omitempty,min=0"`
+
+	Type       *string                `json:"type" validate:"omitempty,max=50"`
+	Attributes map[string]interface{} `json:"attributes"`
+
+	CostCenter *string `json:"cost_center" validate:"omitempty,max=20"`
+	Budget     *int64  `json:"budget" validate:"omitempty,min=0"`
+	// ClearBudget - remove the budget, cannot be combined with budget
+	ClearBudget bool `json:"clear_budget" validate:"excluded_with=Budget"`
+}
+
+// ListChildrenRequest - request payload for listing children of a department page by page
+type ListChildrenRequest struct {
+	PageRequest
+	// Attributes - raw custom attribute values to filter by, parsed by attribute type
+	Attributes map[string]string `json:"attributes"`
+}
+
+// SetHeadRequest - request payload for setting department head, null employee_id removes the head
+type SetHeadRequest struct {
+	EmployeeID *int `json:"employee_id" validate:"omitempty,gt=0"`
+}
+
+// ReorderChildrenRequest - request payload for ordering direct children of a department
+type ReorderChildrenRequest struct {
+	ChildIDs []int `json:"child_ids" validate:"required,min=1,dive,gt=0"`
 }
 
 // DeleteDepartmentRequest - request payload for deleting
@@ -30,39 +73,161 @@ type DeleteDepartmentRequest struct {
 	ReassignToID *int   `json:"reassign_to_id" validate:"required_if=Mode reassign,omitempty,gt=0"`
 }
 
+// SearchDepartmentsRequest - request payload for searching departments by name
+type SearchDepartmentsRequest struct {
+	Query string `json:"q" validate:"required,min=1,max=200"`
+	Limit int    `json:"limit" validate:"min=1,max=100"`
+}
+
 // DepartmentResponse - response payload for department data
 type DepartmentResponse struct {
 	ID        int       `json:"id"`
 	Name      string    `json:"name"`
 	ParentID  *int      `json:"parent_id,omitempty"`
+	Type      *string   `json:"type,omitempty"`
+	SortOrder int       `json:"sort_order"`
 	CreatedAt time.Time `json:"created_at"`
 
-	Employees []EmployeeResponse   `json:"employees,omitempty"`
-	Children  []DepartmentResponse `json:"children,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	CostCenter *string `json:"cost_center,omitempty"`
+	Budget     *int64  `json:"budget,omitempty"`
+
+	Head *EmployeeResponse `json:"head,omitempty"`
+
+	DirectEmployeeCount  *int64 `json:"direct_employee_count,omitempty"`
+	TotalEmployeeCount   *int64 `json:"total_employee_count,omitempty"`
+	DirectChildrenCount  *int64 `json:"direct_children_count,omitempty"`
+	TotalDescendantCount *int64 `json:"total_descendant_count,omitempty"`
+
+	Employees           []EmployeeResponse   `json:"employees,omitempty"`
+	EmployeesNextCursor *string              `json:"employees_next_cursor,omitempty"`
+	Children            []DepartmentResponse `json:"children,omitempty"`
+	ChildrenNextCursor  *string              `json:"children_next_cursor,omitempty"`
+
+	SecondaryMembers []SecondaryMemberResponse `json:"secondary_members,omitempty"`
 }
 
 // NewDepartmentResponse - convert Department model to DepartmentResponse DTO
 func NewDepartmentResponse(m models.Department) DepartmentResponse {
+	return NewDepartmentTreeResponse(m, 0, 0)
+}
+
+// NewDepartmentTreeResponse - convert Department model tree to DepartmentResponse DTO,
+// truncating children and employees of every department to limits (0 - no limit) with continuation cursors
+func NewDepartmentTreeResponse(m models.Department, childrenLimit int, employeesLimit int) DepartmentResponse {
 	resp := DepartmentResponse{
-		ID:        m.ID,
-		Name:      m.Name,
-		ParentID:  m.ParentID,
-		CreatedAt: m.CreatedAt,
+		ID:         m.ID,
+		Name:       m.Name,
+		ParentID:   m.ParentID,
+		Type:       m.Type,
+		SortOrder:  m.SortOrder,
+		CreatedAt:  m.CreatedAt,
+		Attributes: m.Attributes,
+		CostCenter: m.CostCenter,
+		Budget:     m.Budget,
+	}
+
+	if m.Head != nil {
+		head := NewEmployeeResponse(*m.Head)
+		resp.Head = &head
+	}
+
+	employees := m.Employees
+	if employeesLimit > 0 && len(employees) > employeesLimit {
+		employees = employees[:employeesLimit]
+		last := employees[len(employees)-1]
+		cursor := EncodeCursor(models.Cursor{Key: last.FullName, ID: last.ID})
+		resp.EmployeesNextCursor = &cursor
 	}
 
-	if len(m.Employees) > 0 {
-		resp.Employees = make([]EmployeeResponse, len(m.Employees))
-		for i, emp := range m.Employees {
+	if len(employees) > 0 {
+		resp.Employees = make([]EmployeeResponse, len(employees))
+		for i, emp := range employees {
 			resp.Employees[i] = NewEmployeeResponse(emp)
 		}
 	}
 
-	if len(m.Children) > 0 {
-		resp.Children = make([]DepartmentResponse, len(m.Children))
-		for i, child := range m.Children {
-			resp.Children[i] = NewDepartmentResponse(child)
+	for _, a := range m.Assignments {
+		if a.IsPrimary || a.Employee == nil {
+			continue
+		}
+		resp.SecondaryMembers = append(resp.SecondaryMembers, SecondaryMemberResponse{
+			AssignmentID:      a.ID,
+			Role:              a.Role,
+			AllocationPercent: a.AllocationPercent,
+			Employee:          NewEmployeeResponse(*a.Employee),
+		})
+	}
+
+	children := m.Children
+	if childrenLimit > 0 && len(children) > childrenLimit {
+		children = children[:childrenLimit]
+		last := children[len(children)-1]
+		cursor := EncodeCursor(models.Cursor{Order: last.SortOrder, Key: last.Name, ID: last.ID})
+		resp.ChildrenNextCursor = &cursor
+	}
+
+	if len(children) > 0 {
+		resp.Children = make([]DepartmentResponse, len(children))
+		for i, child := range children {
+			resp.Children[i] = NewDepartmentTreeResponse(child, childrenLimit, employeesLimit)
 		}
 	}
 
 	return resp
 }
+
+// DepartmentPathItem - ancestor of a department in search results
+type DepartmentPathItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// DepartmentSearchResponse - response payload for a department found by search
+type DepartmentSearchResponse struct {
+	ID       int                  `json:"id"`
+	Name     string               `json:"name"`
+	ParentID *int                 `json:"parent_id,omitempty"`
+	Path     []DepartmentPathItem `json:"path"`
+	Score    float64              `json:"score"`
+}
+
+// NewDepartmentSearchResponse - convert DepartmentSearchResult model to DepartmentSearchResponse DTO
+func NewDepartmentSearchResponse(m models.DepartmentSearchResult) DepartmentSearchResponse {
+	resp := DepartmentSearchResponse{
+		ID:       m.ID,
+		Name:     m.Name,
+		ParentID: m.ParentID,
+		Path:     make([]DepartmentPathItem, len(m.Path)),
+		Score:    m.Score,
+	}
+
+	for i, ancestor := range m.Path {
+		resp.Path[i] = DepartmentPathItem{ID: ancestor.ID, Name: ancestor.Name}
+	}
+
+	return resp
+}
+
+// IDs - return ids of department and all its children in the response tree
+func (r *DepartmentResponse) IDs() []int {
+	ids := []int{r.ID}
+	for i := range r.Children {
+		ids = append(ids, r.Children[i].IDs()...)
+	}
+	return ids
+}
+
+// SetCounts - set headcount counters of department and all its children in the response tree
+func (r *DepartmentResponse) SetCounts(counts map[int]models.DepartmentCounts) {
+	c := counts[r.ID]
+	r.DirectEmployeeCount = &c.DirectEmployeeCount
+	r.TotalEmployeeCount = &c.TotalEmployeeCount
+	r.DirectChildrenCount = &c.DirectChildrenCount
+	r.TotalDescendantCount = &c.TotalDescendantCount
+
+	for i := range r.Children {
+		r.Children[i].SetCounts(counts)
+	}
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// SaveDepartmentTypeRequest - request payload for creating or replacing a department type,
+// root_allowed defaults to true and a type without allowed children cannot contain anything
+type SaveDepartmentTypeRequest struct {
+	RootAllowed     *bool    `json:"root_allowed"`
+	MaxDepth        *int     `json:"max_depth" validate:"omitempty,min=1"`
+	MaxChildren     *int     `json:"max_children" validate:"omitempty,min=0"`
+	AllowedChildren []string `json:"allowed_children" validate:"dive,required,max=50"`
+}
+
+// DepartmentTypeResponse - response payload for department type with its hierarchy rules
+type DepartmentTypeResponse struct {
+	Name            string    `json:"name"`
+	RootAllowed     bool      `json:"root_allowed"`
+	MaxDepth        *int      `json:"max_depth,omitempty"`
+	MaxChildren     *int      `json:"max_children,omitempty"`
+	AllowedChildren []string  `json:"allowed_children"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// NewDepartmentTypeResponse - convert DepartmentType model to DepartmentTypeResponse DTO
+func NewDepartmentTypeResponse(m models.DepartmentType) DepartmentTypeResponse {
+	return DepartmentTypeResponse{
+		Name:            m.Name,
+		RootAllowed:     m.RootAllowed,
+		MaxDepth:        m.MaxDepth,
+		MaxChildren:     m.MaxChildren,
+		AllowedChildren: m.AllowedChildren(),
+		CreatedAt:       m.CreatedAt,
+	}
+}
@@ -6,21 +6,79 @@ import (
 	"github.com/tmozzze/org_struct_api/internal/domain/models"
 )
 
-// CreateEmployeeRequest - request payload for creating an employee
+// position title is still accepted and resolved to the catalogue position, unknown title is added to the catalogue only for admins,
+// position title is still accepted and resolved to the catalogue position,
+// phone is in E.164 format and employee number is generated from the configured pattern when omitted
 type CreateEmployeeRequest struct {
-	FullName string  `json:"full_name" validate:"required,min=1,max=200"`
-	Position string  `json:"position" validate:"required,min=1,max=200"`
-	HiredAt  *string `json:"hired_at" validate:"omitempty,datetime=2006-01-02"`
+	FullName       string  `json:"full_name" validate:"required,min=1,max=200"`
+	Position       string  `json:"position" validate:"required_without=PositionID,max=200"`
+	PositionID     *int    `json:"position_id" validate:"omitempty,gt=0"`
+	HiredAt        *string `json:"hired_at" validate:"omitempty,datetime=2006-01-02"`
+	ManagerID      *int    `json:"manager_id" validate:"omitempty,gt=0"`
+	Email          *string `json:"email" validate:"omitempty,email,max=254"`
+	Phone          *string `json:"phone" validate:"omitempty,e164"`
+	EmployeeNumber *string `json:"employee_number" validate:"omitempty,min=1,max=50"`
+
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// ListEmployeesRequest - request payload for listing employees of a department page by page
+type ListEmployeesRequest struct {
+	PageRequest
+	PositionID        *int `json:"position_id" validate:"omitempty,gt=0"`
+	IncludeTerminated bool `json:"include_terminated"`
+	// Attributes - raw custom attribute values to filter by, parsed by attribute type
+	Attributes map[string]string `json:"attributes"`
+}
+
+// SetManagerRequest - request payload for setting manager of employee, null manager_id removes the manager
+type SetManagerRequest struct {
+	ManagerID *int `json:"manager_id" validate:"omitempty,gt=0"`
+}
+
+// SetStatusRequest - request payload for putting employee on leave or back to active
+type SetStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=active on_leave"`
+}
+
+// TerminateEmployeeRequest - request payload for terminating employee, terminated_at defaults to today
+type TerminateEmployeeRequest struct {
+	TerminatedAt *string `json:"terminated_at" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// RehireEmployeeRequest - request payload for rehiring terminated employee, hired_at defaults to today
+type RehireEmployeeRequest struct {
+	HiredAt *string `json:"hired_at" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// SetAttributesRequest - request payload for replacing custom attributes of an entity
+type SetAttributesRequest struct {
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// SpanOfControlRequest - request payload for span of control report
+type SpanOfControlRequest struct {
+	Min int `json:"min" validate:"min=0"`
+	Max int `json:"max" validate:"gtefield=Min"`
 }
 
 // EmployeeResponse - response payload for employee data
 type EmployeeResponse struct {
-	ID           int       `json:"id"`
-	DepartmentID int       `json:"department_id"`
-	FullName     string    `json:"full_name"`
-	Position     string    `json:"position"`
-	HiredAt      *string   `json:"hired_at"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID             int       `json:"id"`
+	DepartmentID   int       `json:"department_id"`
+	ManagerID      *int      `json:"manager_id,omitempty"`
+	FullName       string    `json:"full_name"`
+	PositionID     *int      `json:"position_id,omitempty"`
+	Position       string    `json:"position"`
+	HiredAt        *string   `json:"hired_at"`
+	Email          *string   `json:"email,omitempty"`
+	Phone          *string   `json:"phone,omitempty"`
+	EmployeeNumber *string   `json:"employee_number,omitempty"`
+	Status         string    `json:"status"`
+	TerminatedAt   *string   `json:"terminated_at,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
 }
 
 // NewEmployeeResponse - convert Employee model to EmployeeResponse DTO
@@ -30,12 +88,39 @@ func NewEmployeeResponse(m models.Employee) EmployeeResponse {
 		str := m.HiredAt.Format("2006-01-02")
 		hiredAtStr = &str
 	}
+	var terminatedAtStr *string
+	if m.TerminatedAt != nil {
+		str := m.TerminatedAt.Format("2006-01-02")
+		terminatedAtStr = &str
+	}
 	return EmployeeResponse{
-		ID:           m.ID,
-		DepartmentID: m.DepartmentID,
-		FullName:     m.FullName,
-		Position:     m.Position,
-		HiredAt:      hiredAtStr,
-		CreatedAt:    m.CreatedAt,
+		ID:             m.ID,
+		DepartmentID:   m.DepartmentID,
+		ManagerID:      m.ManagerID,
+		FullName:       m.FullName,
+		PositionID:     m.PositionID,
+		Position:       m.Position,
+		HiredAt:        hiredAtStr,
+		Email:          m.Email,
+		Phone:          m.Phone,
+		EmployeeNumber: m.EmployeeNumber,
+		Status:         m.Status,
+		TerminatedAt:   terminatedAtStr,
+		CreatedAt:      m.CreatedAt,
+		Attributes:     m.Attributes,
 	}
 }
+
+// ManagerChainItem - head of a department in the management chain of an employee
+type ManagerChainItem struct {
+	DepartmentID   int              `json:"department_id"`
+	DepartmentName string           `json:"department_name"`
+	Head           EmployeeResponse `json:"head"`
+}
+
+// ManagerSpanResponse - response payload for manager span of control
+type ManagerSpanResponse struct {
+	Manager       EmployeeResponse `json:"manager"`
+	DirectReports int64            `json:"direct_reports"`
+	Status        string           `json:"status"`
+}
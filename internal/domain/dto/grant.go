@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// SaveGrantRequest - request payload for granting role on department, replaces role of subject granted before
+type SaveGrantRequest struct {
+	// Subject - sub claim of JWT or api-key:<id> of API key
+	Subject string `json:"subject" validate:"required,max=200"`
+	Role    string `json:"role" validate:"required,oneof=viewer editor admin"`
+}
+
+// GrantResponse - response payload for department grant data
+type GrantResponse struct {
+	ID           int       `json:"id"`
+	Subject      string    `json:"subject"`
+	DepartmentID int       `json:"department_id"`
+	Role         string    `json:"role"`
+	GrantedBy    *string   `json:"granted_by,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewGrantResponse - convert DepartmentGrant model to GrantResponse DTO
+func NewGrantResponse(m models.DepartmentGrant) GrantResponse {
+	return GrantResponse{
+		ID:           m.ID,
+		Subject:      m.Subject,
+		DepartmentID: m.DepartmentID,
+		Role:         m.Role,
+		GrantedBy:    m.GrantedBy,
+		CreatedAt:    m.CreatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// PageRequest - request payload for cursor pagination
+type PageRequest struct {
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit" validate:"min=1,max=1000"`
+}
+
+// DepartmentsPage - page of departments with cursor of the next page
+type DepartmentsPage struct {
+	Items      []DepartmentResponse `json:"items"`
+	NextCursor *string              `json:"next_cursor,omitempty"`
+}
+
+// EmployeesPage - page of employees with cursor of the next page
+type EmployeesPage struct {
+	Items      []EmployeeResponse `json:"items"`
+	NextCursor *string            `json:"next_cursor,omitempty"`
+}
+
+// EncodeCursor - encode cursor to opaque string
+func EncodeCursor(c models.Cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor - decode opaque string to cursor, empty string means first page
+func DecodeCursor(s string) (*models.Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+
+	var c models.Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cursor: %w", err)
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// CreatePositionRequest - request payload for creating a position
+type CreatePositionRequest struct {
+	Title     string `json:"title" validate:"required,min=1,max=200"`
+	Family    string `json:"family" validate:"max=100"`
+	Level     *int   `json:"level" validate:"omitempty,min=0"`
+	GradeBand string `json:"grade_band" validate:"max=50"`
+}
+
+// UpdatePositionRequest - request payload for updating a position
+type UpdatePositionRequest struct {
+	Title     *string `json:"title" validate:"omitempty,min=1,max=200"`
+	Family    *string `json:"family" validate:"omitempty,max=100"`
+	Level     *int    `json:"level" validate:"omitempty,min=0"`
+	GradeBand *string `json:"grade_band" validate:"omitempty,max=50"`
+}
+
+// PositionResponse - response payload for position data
+type PositionResponse struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	Family    string    `json:"family"`
+	Level     *int      `json:"level"`
+	GradeBand string    `json:"grade_band"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewPositionResponse - convert Position model to PositionResponse DTO
+func NewPositionResponse(m models.Position) PositionResponse {
+	return PositionResponse{
+		ID:        m.ID,
+		Title:     m.Title,
+		Family:    m.Family,
+		Level:     m.Level,
+		GradeBand: m.GradeBand,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -0,0 +1,165 @@
+package dto
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// RedactOmit - field is removed from the response
+	RedactOmit = "omit"
+	// RedactMask - field is kept with most of its characters replaced by '*'
+	RedactMask = "mask"
+)
+
+// redactableFields - json names of EmployeeResponse fields a policy may hide, attributes can only be omitted
+var redactableFields = []string{"hired_at", "terminated_at", "email", "phone", "employee_number", "attributes"}
+
+// RedactionRule - scope required to see employee field and how the field is hidden without it
+type RedactionRule struct {
+	Scope string
+	Mode  string
+}
+
+// RedactionPolicy - redaction rules by json name of EmployeeResponse field
+type RedactionPolicy map[string]RedactionRule
+
+// NewRedactionPolicy - check rules name redactable fields with known modes, empty mode omits the field
+func NewRedactionPolicy(rules map[string]RedactionRule) (RedactionPolicy, error) {
+	policy := make(RedactionPolicy, len(rules))
+	for field, rule := range rules {
+		if !slices.Contains(redactableFields, field) {
+			return nil, fmt.Errorf("field '%s' can not be redacted, redactable fields: %s", field, strings.Join(redactableFields, ", "))
+		}
+		if rule.Scope == "" {
+			return nil, fmt.Errorf("field '%s': scope is required", field)
+		}
+		switch rule.Mode {
+		case "":
+			rule.Mode = RedactOmit
+		case RedactOmit:
+		case RedactMask:
+			if field == "attributes" {
+				return nil, fmt.Errorf("field '%s' can only be omitted", field)
+			}
+		default:
+			return nil, fmt.Errorf("field '%s': unknown mode '%s'", field, rule.Mode)
+		}
+		policy[field] = rule
+	}
+	return policy, nil
+}
+
+// For - redaction of caller with scopes, nil when caller may see every field
+func (p RedactionPolicy) For(scopes []string) *Redaction {
+	hidden := make(map[string]string)
+	for field, rule := range p {
+		if !slices.Contains(scopes, rule.Scope) {
+			hidden[field] = rule.Mode
+		}
+	}
+	if len(hidden) == 0 {
+		return nil
+	}
+	return &Redaction{hidden: hidden}
+}
+
+// Redaction - employee fields hidden from one caller, nil Redaction hides nothing
+type Redaction struct {
+	// hidden - mode by json name of hidden field
+	hidden map[string]string
+}
+
+// Employee - hide fields of employee
+func (r *Redaction) Employee(e *EmployeeResponse) {
+	if r == nil || e == nil {
+		return
+	}
+	e.HiredAt = r.redactDate("hired_at", e.HiredAt)
+	e.TerminatedAt = r.redactDate("terminated_at", e.TerminatedAt)
+	e.Email = r.redact("email", e.Email, maskEmail)
+	e.Phone = r.redact("phone", e.Phone, maskTail)
+	e.EmployeeNumber = r.redact("employee_number", e.EmployeeNumber, maskTail)
+	if _, ok := r.hidden["attributes"]; ok {
+		e.Attributes = nil
+	}
+}
+
+// Employees - hide fields of every employee
+func (r *Redaction) Employees(items []EmployeeResponse) {
+	if r == nil {
+		return
+	}
+	for i := range items {
+		r.Employee(&items[i])
+	}
+}
+
+// Department - hide fields of head, employees and secondary members of department and its children
+func (r *Redaction) Department(d *DepartmentResponse) {
+	if r == nil || d == nil {
+		return
+	}
+	r.Employee(d.Head)
+	r.Employees(d.Employees)
+	for i := range d.SecondaryMembers {
+		r.Employee(&d.SecondaryMembers[i].Employee)
+	}
+	r.Departments(d.Children)
+}
+
+// Departments - hide employee fields of every department
+func (r *Redaction) Departments(items []DepartmentResponse) {
+	if r == nil {
+		return
+	}
+	for i := range items {
+		r.Department(&items[i])
+	}
+}
+
+// redact - omit or mask hidden field value
+func (r *Redaction) redact(field string, value *string, mask func(string) string) *string {
+	mode, ok := r.hidden[field]
+	if !ok || value == nil {
+		return value
+	}
+	if mode == RedactOmit {
+		return nil
+	}
+	masked := mask(*value)
+	return &masked
+}
+
+// redactDate - masked date keeps only the year
+func (r *Redaction) redactDate(field string, value *string) *string {
+	return r.redact(field, value, func(date string) string {
+		if len(date) < 4 {
+			return "****"
+		}
+		return date[:4] + "-**-**"
+	})
+}
+
+// maskEmail - keep first character of local part and the domain, j***@example.com
+func maskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return maskTail(email)
+	}
+	first := []rune(local)[0]
+	return string(first) + "***@" + domain
+}
+
+// maskTail - replace letters and digits except the last two, +*********67
+func maskTail(value string) string {
+	runes := []rune(value)
+	for i := 0; i < len(runes)-2; i++ {
+		if unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) {
+			runes[i] = '*'
+		}
+	}
+	return string(runes)
+}
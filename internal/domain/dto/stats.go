@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"math"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// PositionCountResponse - response payload for number of employees holding a position
+type PositionCountResponse struct {
+	PositionID *int   `json:"position_id"`
+	Position   string `json:"position"`
+	Count      int64  `json:"count"`
+}
+
+// DepartmentStatsResponse - response payload for aggregated department subtree statistics
+type DepartmentStatsResponse struct {
+	DepartmentID          int                     `json:"department_id"`
+	DirectEmployeeCount   int64                   `json:"direct_employee_count"`
+	TotalEmployeeCount    int64                   `json:"total_employee_count"`
+	DirectChildrenCount   int64                   `json:"direct_children_count"`
+	TotalDescendantCount  int64                   `json:"total_descendant_count"`
+	MaxDepth              int                     `json:"max_depth"`
+	AverageTenureDays     *float64                `json:"average_tenure_days"`
+	EmployeesWithHireDate int64                   `json:"employees_with_hire_date"`
+	Positions             []PositionCountResponse `json:"positions"`
+}
+
+// NewDepartmentStatsResponse - convert DepartmentStats model to DepartmentStatsResponse DTO
+func NewDepartmentStatsResponse(m models.DepartmentStats) DepartmentStatsResponse {
+	resp := DepartmentStatsResponse{
+		DepartmentID:          m.DepartmentID,
+		DirectEmployeeCount:   m.DirectEmployeeCount,
+		TotalEmployeeCount:    m.TotalEmployeeCount,
+		DirectChildrenCount:   m.DirectChildrenCount,
+		TotalDescendantCount:  m.TotalDescendantCount,
+		MaxDepth:              m.MaxDepth,
+		AverageTenureDays:     m.AverageTenureDays,
+		EmployeesWithHireDate: m.EmployeesWithHire,
+		Positions:             make([]PositionCountResponse, len(m.Positions)),
+	}
+
+	for i, p := range m.Positions {
+		resp.Positions[i] = PositionCountResponse{PositionID: p.PositionID, Position: p.Position, Count: p.Count}
+	}
+
+	return resp
+}
+
+// DepartmentBudgetResponse - response payload for budget of a department compared with its headcount,
+// totals roll up the whole subtree
+type DepartmentBudgetResponse struct {
+	DepartmentID   int     `json:"department_id"`
+	ParentID       *int    `json:"parent_id"`
+	Name           string  `json:"name"`
+	CostCenter     *string `json:"cost_center"`
+	Depth          int     `json:"depth"`
+	Budget         *int64  `json:"budget"`
+	TotalBudget    int64   `json:"total_budget"`
+	Headcount      int64   `json:"headcount"`
+	TotalHeadcount int64   `json:"total_headcount"`
+	// BudgetPerHead - total budget per employee of the subtree, null without employees
+	BudgetPerHead *float64 `json:"budget_per_head"`
+}
+
+// NewDepartmentBudgetResponse - convert DepartmentBudget model to DepartmentBudgetResponse DTO
+func NewDepartmentBudgetResponse(m models.DepartmentBudget) DepartmentBudgetResponse {
+	resp := DepartmentBudgetResponse{
+		DepartmentID:   m.DepartmentID,
+		ParentID:       m.ParentID,
+		Name:           m.Name,
+		CostCenter:     m.CostCenter,
+		Depth:          m.Depth,
+		Budget:         m.Budget,
+		TotalBudget:    m.TotalBudget,
+		Headcount:      m.Headcount,
+		TotalHeadcount: m.TotalHeadcount,
+	}
+
+	if m.TotalHeadcount > 0 {
+		perHead := math.Round(float64(m.TotalBudget)/float64(m.TotalHeadcount)*100) / 100
+		resp.BudgetPerHead = &perHead
+	}
+
+	return resp
+}
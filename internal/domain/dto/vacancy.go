@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// CreateVacancyRequest - request payload for opening a vacancy in a department
+type CreateVacancyRequest struct {
+	PositionID      int     `json:"position_id" validate:"required,gt=0"`
+	TargetStartDate *string `json:"target_start_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// UpdateVacancyRequest - request payload for updating an open vacancy, status may only become cancelled
+type UpdateVacancyRequest struct {
+	PositionID      *int    `json:"position_id" validate:"omitempty,gt=0"`
+	TargetStartDate *string `json:"target_start_date" validate:"omitempty,datetime=2006-01-02"`
+	Status          *string `json:"status" validate:"omitempty,oneof=cancelled"`
+}
+
+// ListVacanciesRequest - request payload for listing vacancies of a department
+type ListVacanciesRequest struct {
+	Status string `json:"status" validate:"omitempty,oneof=open filled cancelled"`
+}
+
+// FillVacancyRequest - request payload for hiring an employee into a vacancy,
+// hired_at defaults to target start date of the vacancy
+type FillVacancyRequest struct {
+	FullName       string  `json:"full_name" validate:"required,min=1,max=200"`
+	HiredAt        *string `json:"hired_at" validate:"omitempty,datetime=2006-01-02"`
+	ManagerID      *int    `json:"manager_id" validate:"omitempty,gt=0"`
+	Email          *string `json:"email" validate:"omitempty,email,max=254"`
+	Phone          *string `json:"phone" validate:"omitempty,e164"`
+	EmployeeNumber *string `json:"employee_number" validate:"omitempty,min=1,max=50"`
+
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// VacancyResponse - response payload for vacancy data
+type VacancyResponse struct {
+	ID              int       `json:"id"`
+	DepartmentID    int       `json:"department_id"`
+	PositionID      int       `json:"position_id"`
+	Position        string    `json:"position,omitempty"`
+	TargetStartDate *string   `json:"target_start_date"`
+	Status          string    `json:"status"`
+	EmployeeID      *int      `json:"employee_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// NewVacancyResponse - convert Vacancy model to VacancyResponse DTO
+func NewVacancyResponse(m models.Vacancy) VacancyResponse {
+	var targetStr *string
+	if m.TargetStartDate != nil {
+		str := m.TargetStartDate.Format("2006-01-02")
+		targetStr = &str
+	}
+
+	resp := VacancyResponse{
+		ID:              m.ID,
+		DepartmentID:    m.DepartmentID,
+		PositionID:      m.PositionID,
+		TargetStartDate: targetStr,
+		Status:          m.Status,
+		EmployeeID:      m.EmployeeID,
+		CreatedAt:       m.CreatedAt,
+	}
+	if m.Position != nil {
+		resp.Position = m.Position.Title
+	}
+
+	return resp
+}
+
+// FillVacancyResponse - response payload for filled vacancy with the hired employee
+type FillVacancyResponse struct {
+	Vacancy  VacancyResponse  `json:"vacancy"`
+	Employee EmployeeResponse `json:"employee"`
+}
+
+// HeadcountResponse - response payload for actual vs planned headcount of a department subtree
+type HeadcountResponse struct {
+	DepartmentID     int    `json:"department_id"`
+	ParentID         *int   `json:"parent_id"`
+	Name             string `json:"name"`
+	Depth            int    `json:"depth"`
+	ActualHeadcount  int64  `json:"actual_headcount"`
+	OpenVacancies    int64  `json:"open_vacancies"`
+	PlannedHeadcount int64  `json:"planned_headcount"`
+}
+
+// NewHeadcountResponse - convert DepartmentHeadcount model to HeadcountResponse DTO
+func NewHeadcountResponse(m models.DepartmentHeadcount) HeadcountResponse {
+	return HeadcountResponse{
+		DepartmentID:     m.DepartmentID,
+		ParentID:         m.ParentID,
+		Name:             m.Name,
+		Depth:            m.Depth,
+		ActualHeadcount:  m.ActualHeadcount,
+		OpenVacancies:    m.OpenVacancies,
+		PlannedHeadcount: m.ActualHeadcount + m.OpenVacancies,
+	}
+}
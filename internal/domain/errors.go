@@ -6,13 +6,60 @@ var (
 	ErrNotFound           = errors.New("not found")
 	ErrDepartmentNotFound = errors.New("department not found")
 	ErrParentNotFound     = errors.New("parent not found")
+	ErrEmployeeNotFound   = errors.New("employee not found")
+	ErrManagerNotFound    = errors.New("manager not found")
+	ErrAssignmentNotFound = errors.New("assignment not found")
+	ErrPositionNotFound   = errors.New("position not found")
+	ErrVacancyNotFound    = errors.New("vacancy not found")
+	ErrAttributeNotFound  = errors.New("attribute not found")
 
-	ErrDuplicateName = errors.New("duplicate name")
-	ErrAlreadyExist  = errors.New("entity already exists")
+	ErrDepartmentTypeNotFound = errors.New("department type not found")
+	ErrAPIKeyNotFound         = errors.New("api key not found")
+	ErrGrantNotFound          = errors.New("grant not found")
+
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("permission denied")
+
+	ErrDuplicateName           = errors.New("duplicate name")
+	ErrAlreadyExist            = errors.New("entity already exists")
+	ErrDuplicateEmail          = errors.New("email is already in use")
+	ErrDuplicateEmployeeNumber = errors.New("employee number is already in use")
+	ErrDuplicateCostCenter     = errors.New("cost center is already in use")
+	ErrPositionInUse           = errors.New("position is used by employees or vacancies")
+	ErrVacancyClosed           = errors.New("vacancy is not open")
+	ErrDepartmentTypeInUse     = errors.New("department type is used by departments")
+
+	ErrEmployeeTerminated    = errors.New("employee is terminated")
+	ErrEmployeeNotTerminated = errors.New("employee is not terminated")
 
 	ErrCycleConstraint  = errors.New("cycle constraint")
+	ErrHierarchyRule    = errors.New("department hierarchy rule violated")
 	ErrLengthConstraint = errors.New("length constraint")
 	ErrEmptyConstraint  = errors.New("empty constraint")
 
+	ErrAllocationConstraint = errors.New("allocations sum exceeds 100 percent")
+
 	ErrInvalidReassignToID = errors.New("invalid reassign_to_id")
+	ErrInvalidCursor       = errors.New("invalid cursor")
+	ErrInvalidHead         = errors.New("head must belong to the department or one of its ancestors")
+	ErrInvalidPrimary      = errors.New("primary assignment must be in the home department")
+	ErrInvalidTerminatedAt = errors.New("terminated_at must not be before hired_at")
+	ErrInvalidRehiredAt    = errors.New("hired_at of rehire must not be before terminated_at")
+	ErrInvalidAttribute    = errors.New("attributes do not match the attribute schema")
+	ErrInvalidChildOrder   = errors.New("child_ids must list every direct child exactly once")
+	ErrInvalidExpiresAt    = errors.New("expires_at must be in the future")
 )
+
+// ConflictError - conflict with an existing entity, Existing is reported to the client with the error
+type ConflictError struct {
+	Err      error
+	Existing interface{}
+}
+
+func (e *ConflictError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
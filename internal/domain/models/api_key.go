@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// APIKey - API key issued to a client, only the hash of the key is stored
+type APIKey struct {
+	ID      int    `json:"id" gorm:"primaryKey"`
+	Name    string `json:"name" gorm:"type:varchar(100);not null"`
+	Prefix  string `json:"prefix" gorm:"type:varchar(16);not null"`
+	KeyHash string `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	IsAdmin bool   `json:"is_admin" gorm:"not null;default:false"`
+	// Scopes - space separated scopes unlocking redacted employee fields
+	Scopes     string     `json:"scopes" gorm:"type:varchar(500);not null;default:''"`
+	CreatedBy  *string    `json:"created_by" gorm:"type:varchar(200)"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// Active - key is neither revoked nor expired at now
+func (k APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// Principal - authenticated caller of the API
+type Principal struct {
+	// Subject - sub claim of JWT or api-key:<id> of API key
+	Subject string `json:"subject"`
+	// Method - jwt or api_key
+	Method string `json:"method"`
+	Admin  bool   `json:"admin"`
+	// Scopes - scope claim of JWT or scopes of API key, unlock redacted employee fields
+	Scopes []string `json:"scopes,omitempty"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// EmployeeAssignment - allocation of an employee to a department, primary one is in the home department
+type EmployeeAssignment struct {
+	ID                int       `json:"id" gorm:"primaryKey"`
+	EmployeeID        int       `json:"employee_id" gorm:"not null;index:idx_assignment_employee_department,unique"`
+	DepartmentID      int       `json:"department_id" gorm:"not null;index:idx_assignment_employee_department,unique"`
+	Role              string    `json:"role" gorm:"type:varchar(200);not null"`
+	AllocationPercent int       `json:"allocation_percent" gorm:"not null"`
+	IsPrimary         bool      `json:"is_primary" gorm:"not null;default:false"`
+	CreatedAt         time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	Employee *Employee `json:"employee,omitempty" gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE"`
+}
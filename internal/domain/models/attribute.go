@@ -0,0 +1,73 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AttributeDefinition - admin defined custom attribute of departments or employees
+type AttributeDefinition struct {
+	ID         int        `json:"id" gorm:"primaryKey"`
+	EntityType string     `json:"entity_type" gorm:"type:varchar(20);not null;uniqueIndex:idx_attr_entity_name"`
+	Name       string     `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_attr_entity_name"`
+	Type       string     `json:"type" gorm:"type:varchar(20);not null"`
+	Required   bool       `json:"required" gorm:"not null;default:false"`
+	EnumValues StringList `json:"enum_values" gorm:"type:jsonb;not null;default:'[]'"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Attributes - custom attribute values stored as JSONB object
+type Attributes map[string]interface{}
+
+// Value - encode attributes to JSON, nil is stored as empty object
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan - decode attributes from JSON
+func (a *Attributes) Scan(src interface{}) error {
+	return scanJSON(src, a)
+}
+
+// StringList - list of strings stored as JSONB array
+type StringList []string
+
+// Value - encode list to JSON, nil is stored as empty array
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan - decode list from JSON
+func (l *StringList) Scan(src interface{}) error {
+	return scanJSON(src, l)
+}
+
+// scanJSON - decode JSON column value into dst
+func scanJSON(src interface{}, dst interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dst)
+	case string:
+		return json.Unmarshal([]byte(v), dst)
+	default:
+		return fmt.Errorf("unsupported JSON column type %T", src)
+	}
+}
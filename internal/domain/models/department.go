@@ -7,8 +7,34 @@ type Department struct {
 	ID        int       `json:"id" gorm:"primaryKey"`
 	Name      string    `json:"name" gorm:"type:varchar(200);not null;index:idx_parent_name,unique"`
 	ParentID  *int      `json:"parent_id" gorm:"index:idx_parent_name,unique"`
+	Type      *string   `json:"type" gorm:"type:varchar(50);index"`
+	SortOrder int       `json:"sort_order" gorm:"not null;default:0"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 
+	CostCenter *string `json:"cost_center" gorm:"type:varchar(20)"`
+	// Budget - annual budget in whole currency units
+	Budget *int64 `json:"budget"`
+
+	Attributes Attributes `json:"attributes" gorm:"type:jsonb;not null;default:'{}'"`
+
+	HeadEmployeeID *int      `json:"head_employee_id"`
+	Head           *Employee `json:"head,omitempty" gorm:"foreignKey:HeadEmployeeID;constraint:OnDelete:SET NULL"`
+
 	Employees []Employee   `json:"employees,omitempty" gorm:"foreignKey:DepartmentID;constraint:OnDelete:CASCADE"`
 	Children  []Department `json:"children,omitempty" gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE"`
+
+	Assignments []EmployeeAssignment `json:"assignments,omitempty" gorm:"foreignKey:DepartmentID;constraint:OnDelete:CASCADE"`
+}
+
+// DepartmentAncestor - ancestor of a department in the path from root
+type DepartmentAncestor struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// DepartmentSearchResult - department found by name search with its ancestor path
+type DepartmentSearchResult struct {
+	Department
+	Path  []DepartmentAncestor `json:"path"`
+	Score float64              `json:"score"`
 }
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// DepartmentType - type of departments with hierarchy rules, untyped departments are not restricted
+type DepartmentType struct {
+	Name string `json:"name" gorm:"primaryKey;type:varchar(50)"`
+	// RootAllowed - department of the type may have no parent
+	RootAllowed bool `json:"root_allowed" gorm:"not null"`
+	// MaxDepth - deepest level of department of the type, root is level 1 (nil - no limit)
+	MaxDepth *int `json:"max_depth"`
+	// MaxChildren - max direct children of department of the type (nil - no limit)
+	MaxChildren *int      `json:"max_children"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	Rules []DepartmentTypeRule `json:"rules,omitempty" gorm:"foreignKey:ParentType;references:Name;constraint:OnDelete:CASCADE"`
+}
+
+// DepartmentTypeRule - allowed pair of parent and child department types
+type DepartmentTypeRule struct {
+	ParentType string `json:"parent_type" gorm:"primaryKey;type:varchar(50)"`
+	ChildType  string `json:"child_type" gorm:"primaryKey;type:varchar(50)"`
+}
+
+// AllowedChildren - types of departments allowed as children
+func (t DepartmentType) AllowedChildren() []string {
+	children := make([]string, len(t.Rules))
+	for i, rule := range t.Rules {
+		children[i] = rule.ChildType
+	}
+	return children
+}
+
+// AllowsChild - check department of childType may be a child
+func (t DepartmentType) AllowsChild(childType string) bool {
+	return slices.Contains(t.AllowedChildren(), childType)
+}
+
+// DepartmentNode - department of a subtree with depth relative to the subtree root
+type DepartmentNode struct {
+	ID       int     `json:"id"`
+	ParentID *int    `json:"parent_id"`
+	Type     *string `json:"type"`
+	Depth    int     `json:"depth"`
+}
@@ -4,10 +4,24 @@ import "time"
 
 // Employee - represent an employee in organisation
 type Employee struct {
-	ID           int        `json:"id" gorm:"primaryKey"`
-	DepartmentID int        `json:"department_id" gorm:"not null;index"`
-	FullName     string     `json:"full_name" gorm:"type:varchar(200);not null"`
-	Position     string     `json:"position" gorm:"type:varchar(200);not null"`
-	HiredAt      *time.Time `json:"hired_at" gorm:"type:date"`
-	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	ID             int        `json:"id" gorm:"primaryKey"`
+	DepartmentID   int        `json:"department_id" gorm:"not null;index"`
+	ManagerID      *int       `json:"manager_id" gorm:"index"`
+	FullName       string     `json:"full_name" gorm:"type:varchar(200);not null"`
+	PositionID     *int       `json:"position_id" gorm:"index"`
+	Position       string     `json:"position" gorm:"type:varchar(200);not null"`
+	HiredAt        *time.Time `json:"hired_at" gorm:"type:date"`
+	Email          *string    `json:"email" gorm:"type:varchar(254)"`
+	Phone          *string    `json:"phone" gorm:"type:varchar(16)"`
+	EmployeeNumber *string    `json:"employee_number" gorm:"type:varchar(50);uniqueIndex:idx_emp_employee_number"`
+	Status         string     `json:"status" gorm:"type:varchar(20);not null;default:active"`
+	TerminatedAt   *time.Time `json:"terminated_at" gorm:"type:date"`
+	Attributes     Attributes `json:"attributes" gorm:"type:jsonb;not null;default:'{}'"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// ManagerSpan - manager with number of direct reports
+type ManagerSpan struct {
+	Employee
+	DirectReports int64 `json:"direct_reports"`
 }
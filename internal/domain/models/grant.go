@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// DepartmentGrant - role of subject on department and its whole subtree
+type DepartmentGrant struct {
+	ID           int       `json:"id" gorm:"primaryKey"`
+	Subject      string    `json:"subject" gorm:"type:varchar(200);not null"`
+	DepartmentID int       `json:"department_id" gorm:"not null"`
+	Role         string    `json:"role" gorm:"type:varchar(10);not null"`
+	GrantedBy    *string   `json:"granted_by" gorm:"type:varchar(200)"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Cursor - position in a sorted list after which the next page starts
+type Cursor struct {
+	Order int    `json:"o,omitempty"`
+	Key   string `json:"k"`
+	ID    int    `json:"id"`
+}
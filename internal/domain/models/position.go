@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Position - represent a position in the positions catalogue
+type Position struct {
+	ID        int       `json:"id" gorm:"primaryKey"`
+	Title     string    `json:"title" gorm:"type:varchar(200);not null"`
+	Family    string    `json:"family" gorm:"type:varchar(100);not null"`
+	Level     *int      `json:"level"`
+	GradeBand string    `json:"grade_band" gorm:"type:varchar(50);not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,48 @@
+package models
+
+// DepartmentCounts - headcount counters of a department and its subtree
+type DepartmentCounts struct {
+	DepartmentID         int   `json:"department_id"`
+	DirectEmployeeCount  int64 `json:"direct_employee_count"`
+	TotalEmployeeCount   int64 `json:"total_employee_count"`
+	DirectChildrenCount  int64 `json:"direct_children_count"`
+	TotalDescendantCount int64 `json:"total_descendant_count"`
+	MaxDepth             int   `json:"max_depth"`
+}
+
+// PositionCount - number of employees holding a position
+type PositionCount struct {
+	PositionID *int   `json:"position_id"`
+	Position   string `json:"position"`
+	Count      int64  `json:"count"`
+}
+
+// DepartmentStats - aggregated statistics of a department subtree
+type DepartmentStats struct {
+	DepartmentCounts
+	AverageTenureDays *float64        `json:"average_tenure_days"`
+	EmployeesWithHire int64           `json:"employees_with_hire_date"`
+	Positions         []PositionCount `json:"positions"`
+}
+
+// DepartmentBudget - budget and headcount of a department and roll-up over its subtree
+type DepartmentBudget struct {
+	DepartmentID   int     `json:"department_id"`
+	ParentID       *int    `json:"parent_id"`
+	Name           string  `json:"name"`
+	CostCenter     *string `json:"cost_center"`
+	Depth          int     `json:"depth"`
+	Budget         *int64  `json:"budget"`
+	TotalBudget    int64   `json:"total_budget"`
+	Headcount      int64   `json:"headcount"`
+	TotalHeadcount int64   `json:"total_headcount"`
+}
+
+// OrgTotals - size of the whole organization
+type OrgTotals struct {
+	Departments int64 `json:"departments"`
+	// Employees - employees which are not terminated
+	Employees int64 `json:"employees"`
+	// MaxDepth - depth of the deepest department, root departments have depth 0
+	MaxDepth int `json:"max_depth"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Vacancy - represent a planned hire for a position in a department
+type Vacancy struct {
+	ID              int        `json:"id" gorm:"primaryKey"`
+	DepartmentID    int        `json:"department_id" gorm:"not null;index:idx_vacancy_department_status"`
+	PositionID      int        `json:"position_id" gorm:"not null;index"`
+	TargetStartDate *time.Time `json:"target_start_date" gorm:"type:date"`
+	Status          string     `json:"status" gorm:"type:varchar(20);not null;default:open;index:idx_vacancy_department_status"`
+	EmployeeID      *int       `json:"employee_id"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+
+	Position *Position `json:"position,omitempty" gorm:"foreignKey:PositionID;constraint:OnDelete:RESTRICT"`
+}
+
+// DepartmentHeadcount - actual and planned headcount of a department subtree
+type DepartmentHeadcount struct {
+	DepartmentID    int    `json:"department_id"`
+	ParentID        *int   `json:"parent_id"`
+	Name            string `json:"name"`
+	Depth           int    `json:"depth"`
+	ActualHeadcount int64  `json:"actual_headcount"`
+	OpenVacancies   int64  `json:"open_vacancies"`
+}
@@ -2,30 +2,175 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/tmozzze/org_struct_api/internal/domain/models"
 )
 
+// TreeOptions - options for loading department tree
+type TreeOptions struct {
+	Depth            int
+	IncludeEmployees bool
+	// ChildrenLimit - max children loaded per department, one extra row is loaded to detect truncation (0 - no limit)
+	ChildrenLimit int
+	// EmployeesLimit - max employees loaded per department, one extra row is loaded to detect truncation (0 - no limit)
+	EmployeesLimit int
+	// IncludeSecondary - load secondary (non primary) assignments with their employees
+	IncludeSecondary bool
+	// IncludeTerminated - load terminated employees too
+	IncludeTerminated bool
+}
+
+// EmployeeFilter - filter of employee lists
+type EmployeeFilter struct {
+	PositionID        *int
+	IncludeTerminated bool
+	// Attributes - custom attribute values the employee must have
+	Attributes models.Attributes
+}
+
+// DepartmentFilter - filter of department lists
+type DepartmentFilter struct {
+	// Attributes - custom attribute values the department must have
+	Attributes models.Attributes
+}
+
 // Repository - interface for data repositories
 type Repository interface {
 	Department() DepartmentRepository
 	Employee() EmployeeRepository
+	Assignment() AssignmentRepository
+	Position() PositionRepository
+	Vacancy() VacancyRepository
+	Attribute() AttributeRepository
+	DepartmentType() DepartmentTypeRepository
+	APIKey() APIKeyRepository
+	Grant() GrantRepository
+	// Transaction - runs fn with repositories of one transaction, rolled back if fn returns error
+	Transaction(ctx context.Context, fn func(repo Repository) error) error
 }
 
 // DepartmentRepository - interface for department data operations
 type DepartmentRepository interface {
 	Create(ctx context.Context, dept *models.Department) error
-	GetByID(ctx context.Context, id int, depth int, includeEmployees bool) (*models.Department, error)
+	GetByID(ctx context.Context, id int, opts TreeOptions) (*models.Department, error)
 	Update(ctx context.Context, id int, updates map[string]interface{}) error
 	Delete(ctx context.Context, id int) error
 	DeleteWithReassign(ctx context.Context, id int, reassignToID int) error
 	GetByNameAndParent(ctx context.Context, name string, parentID *int) (*models.Department, error)
+	GetByCostCenter(ctx context.Context, costCenter string) (*models.Department, error)
 	GetByIDSimple(ctx context.Context, id int) (*models.Department, error)
 	Exists(ctx context.Context, id int) (bool, error)
+	Search(ctx context.Context, query string, limit int) ([]models.DepartmentSearchResult, error)
+	ListChildren(ctx context.Context, parentID int, filter DepartmentFilter, after *models.Cursor, limit int) ([]models.Department, error)
+	GetCounts(ctx context.Context, ids []int) (map[int]models.DepartmentCounts, error)
+	GetStats(ctx context.Context, id int) (*models.DepartmentStats, error)
+	// GetBudget - budget roll-up of department and every department in its subtree, empty when department does not exist
+	GetBudget(ctx context.Context, id int) ([]models.DepartmentBudget, error)
+	// GetTotals - department and employee counts and tree depth of the whole organization
+	GetTotals(ctx context.Context) (*models.OrgTotals, error)
+	GetAncestors(ctx context.Context, id int) ([]models.Department, error)
+	// GetSubtree - departments under ids with depth relative to them, ids have depth 0
+	GetSubtree(ctx context.Context, ids []int) ([]models.DepartmentNode, error)
+	// NextSortOrder - sort order which places a new department after its siblings
+	NextSortOrder(ctx context.Context, parentID *int) (int, error)
+	Reorder(ctx context.Context, parentID int, childIDs []int) error
+	// UnsetInvalidHeads - unset heads working outside their department and its ancestors,
+	// checks subtree of department and departments headed by its employees
+	UnsetInvalidHeads(ctx context.Context, id int) error
 }
 
 // EmployeeRepository - interface for employee data operations
 type EmployeeRepository interface {
 	Create(ctx context.Context, emp *models.Employee) error
+	GetByID(ctx context.Context, id int) (*models.Employee, error)
+	Update(ctx context.Context, id int, updates map[string]interface{}) error
+	Exists(ctx context.Context, id int) (bool, error)
+	// Lock - lock employee row until the end of transaction, serializes changes of its assignments
+	Lock(ctx context.Context, id int) error
+	ListDirectReports(ctx context.Context, managerID int) ([]models.Employee, error)
+	ListAllReports(ctx context.Context, managerID int) ([]models.Employee, error)
+	GetManagementChain(ctx context.Context, id int) ([]models.Employee, error)
+	GetSpanOfControl(ctx context.Context) ([]models.ManagerSpan, error)
 	UpdateDepartmentForEmployees(ctx context.Context, oldDeptID int, newDeptID int) error
+	ListByDepartment(ctx context.Context, deptID int, filter EmployeeFilter, after *models.Cursor, limit int) ([]models.Employee, error)
+	Terminate(ctx context.Context, id int, terminatedAt time.Time) error
+	GetByEmail(ctx context.Context, email string) (*models.Employee, error)
+	GetByNumber(ctx context.Context, number string) (*models.Employee, error)
+	NextNumber(ctx context.Context) (int64, error)
+}
+
+// AssignmentRepository - interface for employee assignment data operations
+type AssignmentRepository interface {
+	Create(ctx context.Context, a *models.EmployeeAssignment) error
+	GetByID(ctx context.Context, id int) (*models.EmployeeAssignment, error)
+	ListByEmployee(ctx context.Context, employeeID int) ([]models.EmployeeAssignment, error)
+	Update(ctx context.Context, id int, updates map[string]interface{}) error
+	Delete(ctx context.Context, id int) error
+	// SumAllocation - sum of employee allocations, excluding assignment excludeID (0 - exclude nothing)
+	SumAllocation(ctx context.Context, employeeID int, excludeID int) (int, error)
+}
+
+// PositionRepository - interface for positions catalogue data operations
+type PositionRepository interface {
+	Create(ctx context.Context, pos *models.Position) error
+	GetByID(ctx context.Context, id int) (*models.Position, error)
+	GetByTitle(ctx context.Context, title string) (*models.Position, error)
+	List(ctx context.Context) ([]models.Position, error)
+	Update(ctx context.Context, id int, updates map[string]interface{}) error
+	Delete(ctx context.Context, id int) error
+	IsUsed(ctx context.Context, id int) (bool, error)
+}
+
+// VacancyRepository - interface for vacancy data operations
+type VacancyRepository interface {
+	Create(ctx context.Context, v *models.Vacancy) error
+	GetByID(ctx context.Context, id int) (*models.Vacancy, error)
+	ListByDepartment(ctx context.Context, deptID int, status string) ([]models.Vacancy, error)
+	Update(ctx context.Context, id int, updates map[string]interface{}) error
+	SetStatus(ctx context.Context, id int, from string, to string) error
+	GetHeadcount(ctx context.Context, deptID int) ([]models.DepartmentHeadcount, error)
+}
+
+// AttributeRepository - interface for custom attribute definitions data operations
+type AttributeRepository interface {
+	Create(ctx context.Context, def *models.AttributeDefinition) error
+	GetByID(ctx context.Context, id int) (*models.AttributeDefinition, error)
+	GetByName(ctx context.Context, entityType string, name string) (*models.AttributeDefinition, error)
+	// List - list definitions of entity type (empty - all types)
+	List(ctx context.Context, entityType string) ([]models.AttributeDefinition, error)
+	Update(ctx context.Context, id int, updates map[string]interface{}) error
+	// Delete - delete definition and remove its values from entities
+	Delete(ctx context.Context, id int) error
+}
+
+// DepartmentTypeRepository - interface for department types and hierarchy rules data operations
+type DepartmentTypeRepository interface {
+	GetByName(ctx context.Context, name string) (*models.DepartmentType, error)
+	List(ctx context.Context) ([]models.DepartmentType, error)
+	// Save - create or replace department type with its allowed children
+	Save(ctx context.Context, t *models.DepartmentType) error
+	Delete(ctx context.Context, name string) error
+	IsUsed(ctx context.Context, name string) (bool, error)
+}
+
+// APIKeyRepository - interface for API key data operations
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	// GetByHash - key with hash, ErrNotFound when there is none
+	GetByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	List(ctx context.Context) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id int, at time.Time) error
+	// Touch - record use of key, at most once a minute
+	Touch(ctx context.Context, id int, at time.Time) error
+}
+
+// GrantRepository - interface for department grants data operations
+type GrantRepository interface {
+	// Save - create grant or replace role of subject on department
+	Save(ctx context.Context, g *models.DepartmentGrant) error
+	ListByDepartment(ctx context.Context, deptID int) ([]models.DepartmentGrant, error)
+	Delete(ctx context.Context, deptID int, id int) error
+	// GetRoles - roles of subject granted on department or any of its ancestors
+	GetRoles(ctx context.Context, subject string, deptID int) ([]string, error)
 }
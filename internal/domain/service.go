@@ -13,12 +13,62 @@ const (
 	ModeReassign = "reassign"
 	// DateFormat - standard date format for the application
 	DateFormat = "2006-01-02"
+	// DefaultSearchLimit - default number of departments returned by search
+	DefaultSearchLimit = 20
+	// MaxSearchLimit - max number of departments returned by search
+	MaxSearchLimit = 100
+	// DefaultMinSpan - managers with fewer direct reports are flagged in span of control report
+	DefaultMinSpan = 2
+	// DefaultMaxSpan - managers with more direct reports are flagged in span of control report
+	DefaultMaxSpan = 10
+	// SpanStatusOK - number of direct reports is within limits
+	SpanStatusOK = "ok"
+	// SpanStatusTooFew - manager has fewer direct reports than min
+	SpanStatusTooFew = "too_few"
+	// SpanStatusTooMany - manager has more direct reports than max
+	SpanStatusTooMany = "too_many"
+	// MaxAllocationPercent - max sum of employee allocations across departments
+	MaxAllocationPercent = 100
+	// VacancyStatusOpen - vacancy is planned and not yet filled
+	VacancyStatusOpen = "open"
+	// VacancyStatusFilled - vacancy is closed by hiring an employee
+	VacancyStatusFilled = "filled"
+	// VacancyStatusCancelled - vacancy is closed without hiring
+	VacancyStatusCancelled = "cancelled"
+	// EmployeeStatusActive - employee is working
+	EmployeeStatusActive = "active"
+	// EmployeeStatusOnLeave - employee is on leave and keeps the position
+	EmployeeStatusOnLeave = "on_leave"
+	// EmployeeStatusTerminated - employee left the organisation
+	EmployeeStatusTerminated = "terminated"
+	// AttributeEntityDepartment - custom attribute of departments
+	AttributeEntityDepartment = "department"
+	// AttributeEntityEmployee - custom attribute of employees
+	AttributeEntityEmployee = "employee"
+	// AttributeTypeString - attribute value is any string
+	AttributeTypeString = "string"
+	// AttributeTypeNumber - attribute value is a number
+	AttributeTypeNumber = "number"
+	// AttributeTypeBoolean - attribute value is true or false
+	AttributeTypeBoolean = "boolean"
+	// AttributeTypeEnum - attribute value is one of enum values
+	AttributeTypeEnum = "enum"
+	// DefaultPageLimit - default number of children or employees in one page
+	DefaultPageLimit = 100
+	// MaxPageLimit - max number of children or employees in one page
+	MaxPageLimit = 1000
 )
 
 // Service -
 type Service interface {
 	Employee() EmployeeService
 	Department() DepartmentService
+	Position() PositionService
+	Vacancy() VacancyService
+	Attribute() AttributeService
+	DepartmentType() DepartmentTypeService
+	APIKey() APIKeyService
+	Grant() GrantService
 }
 
 // DepartmentService - interface for department business logic
@@ -27,9 +77,78 @@ type DepartmentService interface {
 	GetByID(ctx context.Context, id int, req *dto.GetByIDRequest) (*dto.DepartmentResponse, error)
 	Update(ctx context.Context, id int, req *dto.UpdateDepartmentRequest) (*dto.DepartmentResponse, error)
 	Delete(ctx context.Context, id int, req *dto.DeleteDepartmentRequest) error
+	Search(ctx context.Context, req *dto.SearchDepartmentsRequest) ([]dto.DepartmentSearchResponse, error)
+	ListChildren(ctx context.Context, id int, req *dto.ListChildrenRequest) (*dto.DepartmentsPage, error)
+	GetStats(ctx context.Context, id int) (*dto.DepartmentStatsResponse, error)
+	GetBudget(ctx context.Context, id int) ([]dto.DepartmentBudgetResponse, error)
+	SetHead(ctx context.Context, id int, req *dto.SetHeadRequest) (*dto.DepartmentResponse, error)
+	Reorder(ctx context.Context, id int, req *dto.ReorderChildrenRequest) ([]dto.DepartmentResponse, error)
 }
 
 // EmployeeService - interface for employee business logic
 type EmployeeService interface {
 	Create(ctx context.Context, deptID int, req *dto.CreateEmployeeRequest) (*dto.EmployeeResponse, error)
+	ListByDepartment(ctx context.Context, deptID int, req *dto.ListEmployeesRequest) (*dto.EmployeesPage, error)
+	GetManagerChain(ctx context.Context, id int) ([]dto.ManagerChainItem, error)
+	SetManager(ctx context.Context, id int, req *dto.SetManagerRequest) (*dto.EmployeeResponse, error)
+	ListDirectReports(ctx context.Context, id int) ([]dto.EmployeeResponse, error)
+	ListAllReports(ctx context.Context, id int) ([]dto.EmployeeResponse, error)
+	GetManagementChain(ctx context.Context, id int) ([]dto.EmployeeResponse, error)
+	GetSpanOfControl(ctx context.Context, req *dto.SpanOfControlRequest) ([]dto.ManagerSpanResponse, error)
+	ListAssignments(ctx context.Context, id int) ([]dto.AssignmentResponse, error)
+	CreateAssignment(ctx context.Context, id int, req *dto.CreateAssignmentRequest) (*dto.AssignmentResponse, error)
+	UpdateAssignment(ctx context.Context, id int, assignmentID int, req *dto.UpdateAssignmentRequest) (*dto.AssignmentResponse, error)
+	DeleteAssignment(ctx context.Context, id int, assignmentID int) error
+	SetStatus(ctx context.Context, id int, req *dto.SetStatusRequest) (*dto.EmployeeResponse, error)
+	Terminate(ctx context.Context, id int, req *dto.TerminateEmployeeRequest) (*dto.EmployeeResponse, error)
+	Rehire(ctx context.Context, id int, req *dto.RehireEmployeeRequest) (*dto.EmployeeResponse, error)
+	SetAttributes(ctx context.Context, id int, req *dto.SetAttributesRequest) (*dto.EmployeeResponse, error)
+}
+
+// PositionService - interface for positions catalogue business logic
+type PositionService interface {
+	Create(ctx context.Context, req *dto.CreatePositionRequest) (*dto.PositionResponse, error)
+	GetByID(ctx context.Context, id int) (*dto.PositionResponse, error)
+	List(ctx context.Context) ([]dto.PositionResponse, error)
+	Update(ctx context.Context, id int, req *dto.UpdatePositionRequest) (*dto.PositionResponse, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// VacancyService - interface for headcount planning business logic
+type VacancyService interface {
+	Create(ctx context.Context, deptID int, req *dto.CreateVacancyRequest) (*dto.VacancyResponse, error)
+	List(ctx context.Context, deptID int, req *dto.ListVacanciesRequest) ([]dto.VacancyResponse, error)
+	Update(ctx context.Context, deptID int, vacancyID int, req *dto.UpdateVacancyRequest) (*dto.VacancyResponse, error)
+	Fill(ctx context.Context, deptID int, vacancyID int, req *dto.FillVacancyRequest) (*dto.FillVacancyResponse, error)
+	GetHeadcount(ctx context.Context, deptID int) ([]dto.HeadcountResponse, error)
+}
+
+// AttributeService - interface for custom attribute schema business logic
+type AttributeService interface {
+	Create(ctx context.Context, req *dto.CreateAttributeRequest) (*dto.AttributeResponse, error)
+	List(ctx context.Context, req *dto.ListAttributesRequest) ([]dto.AttributeResponse, error)
+	Update(ctx context.Context, id int, req *dto.UpdateAttributeRequest) (*dto.AttributeResponse, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// DepartmentTypeService - interface for department types and hierarchy rules business logic
+type DepartmentTypeService interface {
+	List(ctx context.Context) ([]dto.DepartmentTypeResponse, error)
+	Save(ctx context.Context, name string, req *dto.SaveDepartmentTypeRequest) (*dto.DepartmentTypeResponse, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// APIKeyService - interface for API key business logic, API keys authenticate principals
+type APIKeyService interface {
+	Authenticator
+	Create(ctx context.Context, req *dto.CreateAPIKeyRequest) (*dto.APIKeyCreatedResponse, error)
+	List(ctx context.Context) ([]dto.APIKeyResponse, error)
+	Revoke(ctx context.Context, id int) error
+}
+
+// GrantService - interface for department grants business logic
+type GrantService interface {
+	List(ctx context.Context, deptID int) ([]dto.GrantResponse, error)
+	Save(ctx context.Context, deptID int, req *dto.SaveGrantRequest) (*dto.GrantResponse, error)
+	Delete(ctx context.Context, deptID int, id int) error
 }
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// CreateAPIKey godoc
+// @Summary Issue API key
+// @Description Issue API key, the key is returned only in this response, admin only
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.CreateAPIKeyRequest true "API key data"
+// @Success 201 {object} dto.APIKeyCreatedResponse
+// @Failure 400 {object} errorResponse
+// @Failure 401 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Router /auth/keys [post]
+func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CreateAPIKey"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting issuing api key")
+
+	var req dto.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.APIKey().Create(r.Context(), &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "issued api key", "id", resp.ID, "prefix", resp.Prefix)
+	renderJSON(w, http.StatusCreated, resp)
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description Return issued API keys without the keys themselves, admin only
+// @Tags auth
+// @Produce json
+// @Success 200 {array} dto.APIKeyResponse
+// @Failure 401 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Router /auth/keys [get]
+func (h *Handler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListAPIKeys"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing api keys")
+
+	resp, err := h.services.APIKey().List(r.Context())
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "listed api keys", "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke API key
+// @Description Revoke API key, requests with it are rejected with 401, admin only
+// @Tags auth
+// @Param id path int true "API key ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /auth/keys/{id} [delete]
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.RevokeAPIKey"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting revoking api key")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrAPIKeyNotFound)
+		return
+	}
+
+	if err := h.services.APIKey().Revoke(r.Context(), id); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "revoked api key", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
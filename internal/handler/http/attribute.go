@@ -0,0 +1,206 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// attributeFilterPrefix - prefix of query params filtering by custom attribute values
+const attributeFilterPrefix = "attr."
+
+// CreateAttribute godoc
+// @Summary Create custom attribute
+// @Description Define custom attribute of departments or employees
+// @Tags attributes
+// @Accept json
+// @Produce json
+// @Param input body dto.CreateAttributeRequest true "Attribute definition"
+// @Success 201 {object} dto.AttributeResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /attributes [post]
+func (h *Handler) CreateAttribute(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CreateAttribute"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting creating attribute")
+
+	var req dto.CreateAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Attribute().Create(r.Context(), &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "created attribute", "entity_type", req.EntityType, "name", req.Name)
+	renderJSON(w, http.StatusCreated, resp)
+}
+
+// ListAttributes godoc
+// @Summary List custom attributes
+// @Description Return custom attribute definitions, optionally of one entity type
+// @Tags attributes
+// @Produce json
+// @Param entity_type query string false "Entity type" Enums(department, employee)
+// @Success 200 {array} dto.AttributeResponse
+// @Failure 400 {object} errorResponse
+// @Router /attributes [get]
+func (h *Handler) ListAttributes(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListAttributes"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing attributes")
+
+	req := &dto.ListAttributesRequest{EntityType: r.URL.Query().Get("entity_type")}
+
+	resp, err := h.services.Attribute().List(r.Context(), req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "listed attributes", "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// UpdateAttribute godoc
+// @Summary Update custom attribute
+// @Description Update required flag or enum values of custom attribute
+// @Tags attributes
+// @Accept json
+// @Produce json
+// @Param id path int true "Attribute ID"
+// @Param input body dto.UpdateAttributeRequest true "New data"
+// @Success 200 {object} dto.AttributeResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /attributes/{id} [patch]
+func (h *Handler) UpdateAttribute(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.UpdateAttribute"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting updating attribute")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrAttributeNotFound)
+		return
+	}
+
+	var req dto.UpdateAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Attribute().Update(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "updated attribute", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// DeleteAttribute godoc
+// @Summary Delete custom attribute
+// @Description Delete custom attribute and remove its values from departments or employees
+// @Tags attributes
+// @Param id path int true "Attribute ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /attributes/{id} [delete]
+func (h *Handler) DeleteAttribute(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.DeleteAttribute"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting deleting attribute")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrAttributeNotFound)
+		return
+	}
+
+	if err := h.services.Attribute().Delete(r.Context(), id); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "deleted attribute", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetEmployeeAttributes godoc
+// @Summary Set employee attributes
+// @Description Replace custom attributes of employee, values are checked against the employee attribute schema
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param input body dto.SetAttributesRequest true "New attributes"
+// @Success 200 {object} dto.EmployeeResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /employees/{id}/attributes [put]
+func (h *Handler) SetEmployeeAttributes(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetEmployeeAttributes"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting setting employee attributes")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	var req dto.SetAttributesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Employee().SetAttributes(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employee(resp)
+
+	log.InfoContext(r.Context(), "set employee attributes", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// parseAttributeFilter - read attr.<name>=<value> query params
+func parseAttributeFilter(r *http.Request) map[string]string {
+	var filter map[string]string
+	for key, values := range r.URL.Query() {
+		name, ok := strings.CutPrefix(key, attributeFilterPrefix)
+		if !ok || name == "" || len(values) == 0 {
+			continue
+		}
+		if filter == nil {
+			filter = make(map[string]string)
+		}
+		filter[name] = values[0]
+	}
+	return filter
+}
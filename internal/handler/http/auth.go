@@ -0,0 +1,50 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+)
+
+// publicPaths - path prefixes served without authentication
+var publicPaths = []string{"/swagger/", "/metrics", "/healthz", "/readyz"}
+
+// Authenticate - middleware rejecting requests without valid credentials with 401,
+// accepts "Authorization: Bearer <token>" or "X-API-Key: <key>" and puts principal on request context
+func Authenticate(auth domain.Authenticator, log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "handler.Authenticate"
+
+			for _, prefix := range publicPaths {
+				if strings.HasPrefix(r.URL.Path, prefix) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			principal, err := auth.Authenticate(r.Context(), bearerToken(r))
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				handleError(w, r, log, op, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// bearerToken - credentials of request, empty when there are none
+func bearerToken(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return strings.TrimSpace(key)
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
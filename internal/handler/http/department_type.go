@@ -0,0 +1,96 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// ListDepartmentTypes godoc
+// @Summary List department types
+// @Description Return department types with their hierarchy rules
+// @Tags department-types
+// @Produce json
+// @Success 200 {array} dto.DepartmentTypeResponse
+// @Router /department-types [get]
+func (h *Handler) ListDepartmentTypes(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListDepartmentTypes"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing department types")
+
+	resp, err := h.services.DepartmentType().List(r.Context())
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "listed department types", "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// SaveDepartmentType godoc
+// @Summary Save department type
+// @Description Create or replace department type with allowed child types, max depth and max children
+// @Tags department-types
+// @Accept json
+// @Produce json
+// @Param name path string true "Department type name"
+// @Param input body dto.SaveDepartmentTypeRequest true "Hierarchy rules"
+// @Success 200 {object} dto.DepartmentTypeResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /department-types/{name} [put]
+func (h *Handler) SaveDepartmentType(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SaveDepartmentType"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting saving department type")
+
+	name := r.PathValue("name")
+
+	var req dto.SaveDepartmentTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.DepartmentType().Save(r.Context(), name, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "saved department type", "name", name)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// DeleteDepartmentType godoc
+// @Summary Delete department type
+// @Description Delete department type which no department has
+// @Tags department-types
+// @Param name path string true "Department type name"
+// @Success 204 "No Content"
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /department-types/{name} [delete]
+func (h *Handler) DeleteDepartmentType(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.DeleteDepartmentType"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting deleting department type")
+
+	name := r.PathValue("name")
+
+	if err := h.services.DepartmentType().Delete(r.Context(), name); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "deleted department type", "name", name)
+	w.WriteHeader(http.StatusNoContent)
+}
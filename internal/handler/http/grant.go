@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// ListGrants godoc
+// @Summary List department grants
+// @Description Return roles granted on department, roles granted on ancestors apply too, requires admin role on department
+// @Tags grants
+// @Produce json
+// @Param id path int true "Department ID"
+// @Success 200 {array} dto.GrantResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/grants [get]
+func (h *Handler) ListGrants(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListGrants"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing grants")
+
+	deptID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrDepartmentNotFound)
+		return
+	}
+
+	resp, err := h.services.Grant().List(r.Context(), deptID)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "listed grants", "department_id", deptID, "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// SaveGrant godoc
+// @Summary Grant role on department
+// @Description Grant viewer, editor or admin role on department and its subtree, replaces role of subject granted before, requires admin role on department
+// @Tags grants
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param input body dto.SaveGrantRequest true "Subject and role"
+// @Success 200 {object} dto.GrantResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/grants [put]
+func (h *Handler) SaveGrant(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SaveGrant"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting saving grant")
+
+	deptID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrDepartmentNotFound)
+		return
+	}
+
+	var req dto.SaveGrantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Grant().Save(r.Context(), deptID, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "saved grant", "department_id", deptID, "subject", resp.Subject, "role", resp.Role)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// DeleteGrant godoc
+// @Summary Delete department grant
+// @Description Delete role granted on department, requires admin role on department
+// @Tags grants
+// @Param id path int true "Department ID"
+// @Param grant_id path int true "Grant ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/grants/{grant_id} [delete]
+func (h *Handler) DeleteGrant(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.DeleteGrant"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting deleting grant")
+
+	deptID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrDepartmentNotFound)
+		return
+	}
+	grantID, err := strconv.Atoi(r.PathValue("grant_id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrGrantNotFound)
+		return
+	}
+
+	if err := h.services.Grant().Delete(r.Context(), deptID, grantID); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "deleted grant", "department_id", deptID, "grant_id", grantID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -14,47 +14,53 @@ import (
 type Handler struct {
 	services domain.Service
 	log      *slog.Logger
+	redactor *Redactor
 }
 
-// NewHandler - constructor for Handler
-func NewHandler(services domain.Service, log *slog.Logger) *Handler {
+// NewHandler - constructor for Handler, nil redactor shows every employee field
+func NewHandler(services domain.Service, log *slog.Logger, redactor *Redactor) *Handler {
 	return &Handler{
 		services: services,
 		log:      log,
+		redactor: redactor,
 	}
 }
 
 // CreateDepartment godoc
 // @Summary Create department
-// @Description Create department
+// @Description Create department, name is unique among siblings ignoring case and whitespace, 409 reports the conflicting department
 // @Tags departments
 // @Accept json
 // @Produce json
 // @Param input body dto.CreateDepartmentRequest true "Department data"
 // @Success 201 {object} dto.DepartmentResponse
 // @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
 // @Failure 409 {object} errorResponse
+// @Failure 422 {object} errorResponse
 // @Router /departments [post]
 func (h *Handler) CreateDepartment(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.CreateDepartment"
 
 	log := h.log.With(slog.String("op", op))
-	log.Debug("starting creating department")
+	log.DebugContext(r.Context(), "starting creating department")
 
 	var req dto.CreateDepartmentRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		handleError(w, h.log, op, err)
+		handleError(w, r, h.log, op, err)
 		return
 	}
 
 	resp, err := h.services.Department().Create(r.Context(), &req)
 	if err != nil {
-		handleError(w, h.log, op, err)
+		handleError(w, r, h.log, op, err)
 		return
 	}
 
-	log.Info("created department", "name", req.Name)
+	h.redactor.For(r).Department(resp)
+
+	log.InfoContext(r.Context(), "created department", "name", req.Name)
 	renderJSON(w, http.StatusCreated, resp)
 }
 
@@ -67,19 +73,25 @@ func (h *Handler) CreateDepartment(w http.ResponseWriter, r *http.Request) {
 // @Param id path int true "Department ID"
 // @Param depth query int false "Tree depth (1-5)" default(1)
 // @Param include_employees query bool false "With employees" default(true)
+// @Param children_limit query int false "Max children per department (1-1000)" default(100)
+// @Param employees_limit query int false "Max employees per department (1-1000)" default(100)
+// @Param include_stats query bool false "With headcount counters" default(false)
+// @Param include_secondary query bool false "With secondary members" default(false)
+// @Param include_terminated query bool false "With terminated employees" default(false)
 // @Success 200 {object} dto.DepartmentResponse
+// @Failure 403 {object} errorResponse
 // @Failure 404 {object} errorResponse
 // @Router /departments/{id} [get]
 func (h *Handler) GetDepartment(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.GetDepartment"
 
 	log := h.log.With(slog.String("op", op))
-	log.Debug("starting getting department")
+	log.DebugContext(r.Context(), "starting getting department")
 
 	idStr := r.PathValue("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		handleError(w, h.log, op, domain.ErrNotFound)
+		handleError(w, r, h.log, op, domain.ErrNotFound)
 		return
 	}
 
@@ -94,24 +106,179 @@ func (h *Handler) GetDepartment(w http.ResponseWriter, r *http.Request) {
 		includeEmployees = false
 	}
 
+	childrenLimit, _ := strconv.Atoi(query.Get("children_limit"))
+	employeesLimit, _ := strconv.Atoi(query.Get("employees_limit"))
+
 	req := &dto.GetByIDRequest{
-		Depth:            depth,
-		IncludeEmployees: includeEmployees,
+		Depth:             depth,
+		IncludeEmployees:  includeEmployees,
+		ChildrenLimit:     childrenLimit,
+		EmployeesLimit:    employeesLimit,
+		IncludeStats:      query.Get("include_stats") == "true",
+		IncludeSecondary:  query.Get("include_secondary") == "true",
+		IncludeTerminated: query.Get("include_terminated") == "true",
 	}
 
 	resp, err := h.services.Department().GetByID(r.Context(), id, req)
 	if err != nil {
-		handleError(w, h.log, op, err)
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Department(resp)
+
+	log.InfoContext(r.Context(), "got department", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// ListChildren godoc
+// @Summary List department children
+// @Description Return direct children of department page by page,
+// @Description attr.<name>=<value> query params filter children by custom attribute values
+// @Tags departments
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param cursor query string false "Cursor of the next page"
+// @Param limit query int false "Page size (1-1000)" default(100)
+// @Success 200 {object} dto.DepartmentsPage
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/children [get]
+func (h *Handler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListChildren"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing children")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrNotFound)
+		return
+	}
+
+	req := &dto.ListChildrenRequest{
+		PageRequest: *parsePageRequest(r),
+		Attributes:  parseAttributeFilter(r),
+	}
+
+	resp, err := h.services.Department().ListChildren(r.Context(), id, req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Departments(resp.Items)
+
+	log.InfoContext(r.Context(), "listed children", "id", id, "count", len(resp.Items))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// GetDepartmentStats godoc
+// @Summary Get department statistics
+// @Description Return headcount, tenure and position statistics of department subtree
+// @Tags departments
+// @Produce json
+// @Param id path int true "Department ID"
+// @Success 200 {object} dto.DepartmentStatsResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/stats [get]
+func (h *Handler) GetDepartmentStats(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetDepartmentStats"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting getting department stats")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrNotFound)
+		return
+	}
+
+	resp, err := h.services.Department().GetStats(r.Context(), id)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
 		return
 	}
 
-	log.Info("got department", "id", id)
+	log.InfoContext(r.Context(), "got department stats", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// GetDepartmentBudget godoc
+// @Summary Get department budget
+// @Description Return budget and headcount of department and every department of its subtree, totals roll up the subtree
+// @Tags departments
+// @Produce json
+// @Param id path int true "Department ID"
+// @Success 200 {array} dto.DepartmentBudgetResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/budget [get]
+func (h *Handler) GetDepartmentBudget(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetDepartmentBudget"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting getting department budget")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrNotFound)
+		return
+	}
+
+	resp, err := h.services.Department().GetBudget(r.Context(), id)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "got department budget", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// SearchDepartments godoc
+// @Summary Search departments
+// @Description Case and accent insensitive prefix and fuzzy search by department name with ancestor path
+// @Tags departments
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Max results (1-100)" default(20)
+// @Success 200 {array} dto.DepartmentSearchResponse
+// @Failure 400 {object} errorResponse
+// @Router /departments/search [get]
+func (h *Handler) SearchDepartments(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SearchDepartments"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting searching departments")
+
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	req := &dto.SearchDepartmentsRequest{
+		Query: query.Get("q"),
+		Limit: limit,
+	}
+
+	resp, err := h.services.Department().Search(r.Context(), req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "searched departments", "q", req.Query, "found", len(resp))
 	renderJSON(w, http.StatusOK, resp)
 }
 
 // UpdateDepartment godoc
 // @Summary Update department
-// @Description Update name and parent ID
+// @Description Update name, parent ID, type, attributes, cost center and budget, moved or retyped subtree must satisfy hierarchy rules, clear_budget removes the budget,
+// @Description heads of moved subtree working outside its new ancestors are removed
 // @Tags departments
 // @Accept json
 // @Produce json
@@ -119,49 +286,144 @@ func (h *Handler) GetDepartment(w http.ResponseWriter, r *http.Request) {
 // @Param input body dto.UpdateDepartmentRequest true "New data"
 // @Success 200 {object} dto.DepartmentResponse
 // @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
 // @Failure 409 {object} errorResponse
+// @Failure 422 {object} errorResponse
 // @Router /departments/{id} [patch]
 func (h *Handler) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.UpdateDepartment"
 
 	log := h.log.With(slog.String("op", op))
-	log.Debug("starting update department")
+	log.DebugContext(r.Context(), "starting update department")
 
 	idStr := r.PathValue("id")
 	id, _ := strconv.Atoi(idStr)
 
 	var req dto.UpdateDepartmentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		handleError(w, h.log, op, err)
+		handleError(w, r, h.log, op, err)
 		return
 	}
 
 	resp, err := h.services.Department().Update(r.Context(), id, &req)
 	if err != nil {
-		handleError(w, h.log, op, err)
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Department(resp)
+
+	log.InfoContext(r.Context(), "updated department", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// SetDepartmentHead godoc
+// @Summary Set department head
+// @Description Set head of department from its own or ancestor employees, null employee_id removes the head
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param input body dto.SetHeadRequest true "Head employee"
+// @Success 200 {object} dto.DepartmentResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/head [put]
+func (h *Handler) SetDepartmentHead(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetDepartmentHead"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting setting department head")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrNotFound)
+		return
+	}
+
+	var req dto.SetHeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Department().SetHead(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Department(resp)
+
+	log.InfoContext(r.Context(), "set department head", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// ReorderChildren godoc
+// @Summary Reorder child departments
+// @Description Set order of direct children of department, child_ids must list every child exactly once
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param input body dto.ReorderChildrenRequest true "Ordered child IDs"
+// @Success 200 {array} dto.DepartmentResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/reorder [post]
+func (h *Handler) ReorderChildren(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ReorderChildren"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting reordering children")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrNotFound)
 		return
 	}
 
-	log.Info("updated department", "id", id)
+	var req dto.ReorderChildrenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Department().Reorder(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Departments(resp)
+
+	log.InfoContext(r.Context(), "reordered children", "id", id, "count", len(resp))
 	renderJSON(w, http.StatusOK, resp)
 }
 
 // DeleteDepartment godoc
 // @Summary Delete department
-// @Description Delete department in cascade mode or reassign mode
+// @Description Delete department in cascade mode or reassign mode, reassign moves employees to another department, sub-departments are deleted
 // @Tags departments
 // @Param id path int true "Department ID"
 // @Param mode query string false "Delete mode (cascade|reassign)" Enums(cascade, reassign) default(cascade)
 // @Param reassign_to_department_id query int false "New department ID (need for reassign mode)"
 // @Success 204 "No Content"
 // @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
 // @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 422 {object} errorResponse
 // @Router /departments/{id} [delete]
 func (h *Handler) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.DeleteDepartment"
 
 	log := h.log.With(slog.String("op", op))
-	log.Debug("starting deleting department")
+	log.DebugContext(r.Context(), "starting deleting department")
 
 	idStr := r.PathValue("id")
 	id, _ := strconv.Atoi(idStr)
@@ -176,7 +438,7 @@ func (h *Handler) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
 	if mode == domain.ModeReassign {
 		val, err := strconv.Atoi(query.Get("reassign_to_department_id"))
 		if err != nil {
-			handleError(w, h.log, op, domain.ErrInvalidReassignToID)
+			handleError(w, r, h.log, op, domain.ErrInvalidReassignToID)
 			return
 		}
 		reassignID = &val
@@ -188,11 +450,11 @@ func (h *Handler) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.services.Department().Delete(r.Context(), id, req); err != nil {
-		handleError(w, h.log, op, err)
+		handleError(w, r, h.log, op, err)
 		return
 	}
 
-	log.Info("deleted department", "id", id)
+	log.InfoContext(r.Context(), "deleted department", "id", id)
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -206,29 +468,476 @@ func (h *Handler) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
 // @Param input body dto.CreateEmployeeRequest true "Employee data"
 // @Success 201 {object} dto.EmployeeResponse
 // @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
 // @Failure 404 {object} errorResponse
 // @Router /departments/{id}/employees [post]
 func (h *Handler) CreateEmployee(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.CreateEmployee"
 
 	log := h.log.With(slog.String("op", op))
-	log.Debug("starting creating employee")
+	log.DebugContext(r.Context(), "starting creating employee")
 
 	idStr := r.PathValue("id")
 	deptID, _ := strconv.Atoi(idStr)
 
 	var req dto.CreateEmployeeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		handleError(w, h.log, op, err)
+		handleError(w, r, h.log, op, err)
 		return
 	}
 
 	resp, err := h.services.Employee().Create(r.Context(), deptID, &req)
 	if err != nil {
-		handleError(w, h.log, op, err)
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employee(resp)
+
+	log.InfoContext(r.Context(), "created employee", "dept_id", deptID)
+	renderJSON(w, http.StatusCreated, resp)
+}
+
+// ListEmployees godoc
+// @Summary List department employees
+// @Description Return employees of department page by page,
+// @Description attr.<name>=<value> query params filter employees by custom attribute values
+// @Tags employees
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param cursor query string false "Cursor of the next page"
+// @Param limit query int false "Page size (1-1000)" default(100)
+// @Param position_id query int false "Filter by position ID"
+// @Param include_terminated query bool false "With terminated employees" default(false)
+// @Success 200 {object} dto.EmployeesPage
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/employees [get]
+func (h *Handler) ListEmployees(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListEmployees"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing employees")
+
+	idStr := r.PathValue("id")
+	deptID, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrNotFound)
+		return
+	}
+
+	req := &dto.ListEmployeesRequest{PageRequest: *parsePageRequest(r)}
+	if positionID, err := strconv.Atoi(r.URL.Query().Get("position_id")); err == nil {
+		req.PositionID = &positionID
+	}
+	req.IncludeTerminated = r.URL.Query().Get("include_terminated") == "true"
+	req.Attributes = parseAttributeFilter(r)
+
+	resp, err := h.services.Employee().ListByDepartment(r.Context(), deptID, req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employees(resp.Items)
+
+	log.InfoContext(r.Context(), "listed employees", "dept_id", deptID, "count", len(resp.Items))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// parsePageRequest - read cursor and limit query params
+func parsePageRequest(r *http.Request) *dto.PageRequest {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	return &dto.PageRequest{
+		Cursor: query.Get("cursor"),
+		Limit:  limit,
+	}
+}
+
+// GetManagerChain godoc
+// @Summary Get employee manager chain
+// @Description Return heads of employee department and its ancestors, from the closest up to root
+// @Tags employees
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {array} dto.ManagerChainItem
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /employees/{id}/manager-chain [get]
+func (h *Handler) GetManagerChain(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetManagerChain"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting getting manager chain")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	resp, err := h.services.Employee().GetManagerChain(r.Context(), id)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	redaction := h.redactor.For(r)
+	for i := range resp {
+		redaction.Employee(&resp[i].Head)
+	}
+
+	log.InfoContext(r.Context(), "got manager chain", "id", id, "length", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// SetManager godoc
+// @Summary Set employee manager
+// @Description Set direct manager of employee, null manager_id removes the manager
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param input body dto.SetManagerRequest true "Manager"
+// @Success 200 {object} dto.EmployeeResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /employees/{id}/manager [put]
+func (h *Handler) SetManager(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetManager"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting setting manager")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	var req dto.SetManagerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Employee().SetManager(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employee(resp)
+
+	log.InfoContext(r.Context(), "set manager", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// ListDirectReports godoc
+// @Summary List direct reports
+// @Description Return employees reporting directly to employee
+// @Tags employees
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {array} dto.EmployeeResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /employees/{id}/reports [get]
+func (h *Handler) ListDirectReports(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListDirectReports"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing direct reports")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	resp, err := h.services.Employee().ListDirectReports(r.Context(), id)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employees(resp)
+
+	log.InfoContext(r.Context(), "listed direct reports", "id", id, "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// ListAllReports godoc
+// @Summary List all reports
+// @Description Return employees reporting to employee directly or indirectly, level by level
+// @Tags employees
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {array} dto.EmployeeResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /employees/{id}/reports/all [get]
+func (h *Handler) ListAllReports(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListAllReports"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing all reports")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	resp, err := h.services.Employee().ListAllReports(r.Context(), id)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employees(resp)
+
+	log.InfoContext(r.Context(), "listed all reports", "id", id, "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// GetManagementChain godoc
+// @Summary Get employee management chain
+// @Description Return managers of employee from direct manager up to the top
+// @Tags employees
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {array} dto.EmployeeResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /employees/{id}/management-chain [get]
+func (h *Handler) GetManagementChain(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetManagementChain"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting getting management chain")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	resp, err := h.services.Employee().GetManagementChain(r.Context(), id)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employees(resp)
+
+	log.InfoContext(r.Context(), "got management chain", "id", id, "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// GetSpanOfControl godoc
+// @Summary Span of control report
+// @Description Return managers with number of direct reports, flagging too many or too few
+// @Tags reports
+// @Produce json
+// @Param min query int false "Min direct reports" default(2)
+// @Param max query int false "Max direct reports" default(10)
+// @Success 200 {array} dto.ManagerSpanResponse
+// @Router /reports/span-of-control [get]
+func (h *Handler) GetSpanOfControl(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetSpanOfControl"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting getting span of control")
+
+	query := r.URL.Query()
+	minSpan, _ := strconv.Atoi(query.Get("min"))
+	maxSpan, _ := strconv.Atoi(query.Get("max"))
+
+	req := &dto.SpanOfControlRequest{
+		Min: minSpan,
+		Max: maxSpan,
+	}
+
+	resp, err := h.services.Employee().GetSpanOfControl(r.Context(), req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	redaction := h.redactor.For(r)
+	for i := range resp {
+		redaction.Employee(&resp[i].Manager)
+	}
+
+	log.InfoContext(r.Context(), "got span of control", "managers", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// ListAssignments godoc
+// @Summary List employee assignments
+// @Description Return department assignments of employee, primary first
+// @Tags assignments
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {array} dto.AssignmentResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /employees/{id}/assignments [get]
+func (h *Handler) ListAssignments(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListAssignments"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing assignments")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	resp, err := h.services.Employee().ListAssignments(r.Context(), id)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "listed assignments", "id", id, "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// CreateAssignment godoc
+// @Summary Assign employee to department
+// @Description Assign employee to a department with role and allocation, allocations sum up to 100 percent
+// @Tags assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param input body dto.CreateAssignmentRequest true "Assignment data"
+// @Success 201 {object} dto.AssignmentResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /employees/{id}/assignments [post]
+func (h *Handler) CreateAssignment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CreateAssignment"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting creating assignment")
+
+	idStr := r.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	var req dto.CreateAssignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
 		return
 	}
 
-	log.Info("created employee", "dept_id", deptID)
+	resp, err := h.services.Employee().CreateAssignment(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "created assignment", "id", id, "dept_id", req.DepartmentID)
 	renderJSON(w, http.StatusCreated, resp)
 }
+
+// UpdateAssignment godoc
+// @Summary Update employee assignment
+// @Description Update role or allocation of employee assignment
+// @Tags assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param assignment_id path int true "Assignment ID"
+// @Param input body dto.UpdateAssignmentRequest true "New data"
+// @Success 200 {object} dto.AssignmentResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /employees/{id}/assignments/{assignment_id} [patch]
+func (h *Handler) UpdateAssignment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.UpdateAssignment"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting updating assignment")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+	assignmentID, err := strconv.Atoi(r.PathValue("assignment_id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrAssignmentNotFound)
+		return
+	}
+
+	var req dto.UpdateAssignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Employee().UpdateAssignment(r.Context(), id, assignmentID, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "updated assignment", "id", id, "assignment_id", assignmentID)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// DeleteAssignment godoc
+// @Summary Delete employee assignment
+// @Description Remove employee from a department assignment
+// @Tags assignments
+// @Param id path int true "Employee ID"
+// @Param assignment_id path int true "Assignment ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /employees/{id}/assignments/{assignment_id} [delete]
+func (h *Handler) DeleteAssignment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.DeleteAssignment"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting deleting assignment")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+	assignmentID, err := strconv.Atoi(r.PathValue("assignment_id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrAssignmentNotFound)
+		return
+	}
+
+	if err := h.services.Employee().DeleteAssignment(r.Context(), id, assignmentID); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "deleted assignment", "id", id, "assignment_id", assignmentID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -4,16 +4,29 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tmozzze/org_struct_api/internal/config"
 	"github.com/tmozzze/org_struct_api/internal/domain"
 	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"github.com/tmozzze/org_struct_api/internal/metrics"
+	pkglogger "github.com/tmozzze/org_struct_api/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // MOCKS
@@ -50,112 +63,1808 @@ func (m *MockDepartmentService) Delete(ctx context.Context, id int, req *dto.Del
 	return m.Called(ctx, id, req).Error(0)
 }
 
+func (m *MockDepartmentService) Search(ctx context.Context, req *dto.SearchDepartmentsRequest) ([]dto.DepartmentSearchResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.DepartmentSearchResponse), args.Error(1)
+}
+
+func (m *MockDepartmentService) ListChildren(ctx context.Context, id int, req *dto.ListChildrenRequest) (*dto.DepartmentsPage, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.DepartmentsPage), args.Error(1)
+}
+
+func (m *MockDepartmentService) GetStats(ctx context.Context, id int) (*dto.DepartmentStatsResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.DepartmentStatsResponse), args.Error(1)
+}
+
+func (m *MockDepartmentService) GetBudget(ctx context.Context, id int) ([]dto.DepartmentBudgetResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.DepartmentBudgetResponse), args.Error(1)
+}
+
+func (m *MockDepartmentService) SetHead(ctx context.Context, id int, req *dto.SetHeadRequest) (*dto.DepartmentResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.DepartmentResponse), args.Error(1)
+}
+
+func (m *MockDepartmentService) Reorder(ctx context.Context, id int, req *dto.ReorderChildrenRequest) ([]dto.DepartmentResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.DepartmentResponse), args.Error(1)
+}
+
 type MockEmployeeService struct {
 	mock.Mock
 }
 
-func (m *MockEmployeeService) Create(ctx context.Context, deptID int, req *dto.CreateEmployeeRequest) (*dto.EmployeeResponse, error) {
-	args := m.Called(ctx, deptID, req)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*dto.EmployeeResponse), args.Error(1)
+func (m *MockEmployeeService) SetManager(ctx context.Context, id int, req *dto.SetManagerRequest) (*dto.EmployeeResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.EmployeeResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) ListDirectReports(ctx context.Context, id int) ([]dto.EmployeeResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.EmployeeResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) ListAllReports(ctx context.Context, id int) ([]dto.EmployeeResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.EmployeeResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) GetManagementChain(ctx context.Context, id int) ([]dto.EmployeeResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.EmployeeResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) GetSpanOfControl(ctx context.Context, req *dto.SpanOfControlRequest) ([]dto.ManagerSpanResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ManagerSpanResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) ListAssignments(ctx context.Context, id int) ([]dto.AssignmentResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.AssignmentResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) CreateAssignment(ctx context.Context, id int, req *dto.CreateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AssignmentResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) UpdateAssignment(ctx context.Context, id int, assignmentID int, req *dto.UpdateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	args := m.Called(ctx, id, assignmentID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AssignmentResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) DeleteAssignment(ctx context.Context, id int, assignmentID int) error {
+	return m.Called(ctx, id, assignmentID).Error(0)
+}
+
+func (m *MockEmployeeService) GetManagerChain(ctx context.Context, id int) ([]dto.ManagerChainItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ManagerChainItem), args.Error(1)
+}
+
+func (m *MockEmployeeService) ListByDepartment(ctx context.Context, deptID int, req *dto.ListEmployeesRequest) (*dto.EmployeesPage, error) {
+	args := m.Called(ctx, deptID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.EmployeesPage), args.Error(1)
+}
+
+func (m *MockEmployeeService) SetStatus(ctx context.Context, id int, req *dto.SetStatusRequest) (*dto.EmployeeResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.EmployeeResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) Terminate(ctx context.Context, id int, req *dto.TerminateEmployeeRequest) (*dto.EmployeeResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.EmployeeResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) Rehire(ctx context.Context, id int, req *dto.RehireEmployeeRequest) (*dto.EmployeeResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.EmployeeResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) SetAttributes(ctx context.Context, id int, req *dto.SetAttributesRequest) (*dto.EmployeeResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.EmployeeResponse), args.Error(1)
+}
+
+func (m *MockEmployeeService) Create(ctx context.Context, deptID int, req *dto.CreateEmployeeRequest) (*dto.EmployeeResponse, error) {
+	args := m.Called(ctx, deptID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.EmployeeResponse), args.Error(1)
+}
+
+type MockPositionService struct {
+	mock.Mock
+}
+
+func (m *MockPositionService) Create(ctx context.Context, req *dto.CreatePositionRequest) (*dto.PositionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PositionResponse), args.Error(1)
+}
+
+func (m *MockPositionService) GetByID(ctx context.Context, id int) (*dto.PositionResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PositionResponse), args.Error(1)
+}
+
+func (m *MockPositionService) List(ctx context.Context) ([]dto.PositionResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.PositionResponse), args.Error(1)
+}
+
+func (m *MockPositionService) Update(ctx context.Context, id int, req *dto.UpdatePositionRequest) (*dto.PositionResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PositionResponse), args.Error(1)
+}
+
+func (m *MockPositionService) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockVacancyService struct {
+	mock.Mock
+}
+
+func (m *MockVacancyService) Create(ctx context.Context, deptID int, req *dto.CreateVacancyRequest) (*dto.VacancyResponse, error) {
+	args := m.Called(ctx, deptID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VacancyResponse), args.Error(1)
+}
+
+func (m *MockVacancyService) List(ctx context.Context, deptID int, req *dto.ListVacanciesRequest) ([]dto.VacancyResponse, error) {
+	args := m.Called(ctx, deptID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.VacancyResponse), args.Error(1)
+}
+
+func (m *MockVacancyService) Update(ctx context.Context, deptID int, vacancyID int, req *dto.UpdateVacancyRequest) (*dto.VacancyResponse, error) {
+	args := m.Called(ctx, deptID, vacancyID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VacancyResponse), args.Error(1)
+}
+
+func (m *MockVacancyService) Fill(ctx context.Context, deptID int, vacancyID int, req *dto.FillVacancyRequest) (*dto.FillVacancyResponse, error) {
+	args := m.Called(ctx, deptID, vacancyID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.FillVacancyResponse), args.Error(1)
+}
+
+func (m *MockVacancyService) GetHeadcount(ctx context.Context, deptID int) ([]dto.HeadcountResponse, error) {
+	args := m.Called(ctx, deptID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.HeadcountResponse), args.Error(1)
+}
+
+type MockAttributeService struct {
+	mock.Mock
+}
+
+func (m *MockAttributeService) Create(ctx context.Context, req *dto.CreateAttributeRequest) (*dto.AttributeResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AttributeResponse), args.Error(1)
+}
+
+func (m *MockAttributeService) List(ctx context.Context, req *dto.ListAttributesRequest) ([]dto.AttributeResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.AttributeResponse), args.Error(1)
+}
+
+func (m *MockAttributeService) Update(ctx context.Context, id int, req *dto.UpdateAttributeRequest) (*dto.AttributeResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AttributeResponse), args.Error(1)
+}
+
+func (m *MockAttributeService) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockDepartmentTypeService struct {
+	mock.Mock
+}
+
+func (m *MockDepartmentTypeService) List(ctx context.Context) ([]dto.DepartmentTypeResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.DepartmentTypeResponse), args.Error(1)
+}
+
+func (m *MockDepartmentTypeService) Save(ctx context.Context, name string, req *dto.SaveDepartmentTypeRequest) (*dto.DepartmentTypeResponse, error) {
+	args := m.Called(ctx, name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.DepartmentTypeResponse), args.Error(1)
+}
+
+func (m *MockDepartmentTypeService) Delete(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyService) Authenticate(ctx context.Context, token string) (*models.Principal, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Principal), args.Error(1)
+}
+
+func (m *MockAPIKeyService) Create(ctx context.Context, req *dto.CreateAPIKeyRequest) (*dto.APIKeyCreatedResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.APIKeyCreatedResponse), args.Error(1)
+}
+
+func (m *MockAPIKeyService) List(ctx context.Context) ([]dto.APIKeyResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.APIKeyResponse), args.Error(1)
+}
+
+func (m *MockAPIKeyService) Revoke(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockGrantService struct {
+	mock.Mock
+}
+
+func (m *MockGrantService) List(ctx context.Context, deptID int) ([]dto.GrantResponse, error) {
+	args := m.Called(ctx, deptID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.GrantResponse), args.Error(1)
+}
+
+func (m *MockGrantService) Save(ctx context.Context, deptID int, req *dto.SaveGrantRequest) (*dto.GrantResponse, error) {
+	args := m.Called(ctx, deptID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.GrantResponse), args.Error(1)
+}
+
+func (m *MockGrantService) Delete(ctx context.Context, deptID int, id int) error {
+	args := m.Called(ctx, deptID, id)
+	return args.Error(0)
+}
+
+type MockService struct {
+	mock.Mock
+	dept *MockDepartmentService
+	emp  *MockEmployeeService
+	pos  *MockPositionService
+	vac  *MockVacancyService
+	attr *MockAttributeService
+	typ  *MockDepartmentTypeService
+	key  *MockAPIKeyService
+	gr   *MockGrantService
+}
+
+func (m *MockService) Department() domain.DepartmentService { return m.dept }
+func (m *MockService) Employee() domain.EmployeeService     { return m.emp }
+func (m *MockService) Position() domain.PositionService     { return m.pos }
+func (m *MockService) Vacancy() domain.VacancyService       { return m.vac }
+func (m *MockService) Attribute() domain.AttributeService   { return m.attr }
+func (m *MockService) DepartmentType() domain.DepartmentTypeService {
+	return m.typ
+}
+func (m *MockService) APIKey() domain.APIKeyService { return m.key }
+func (m *MockService) Grant() domain.GrantService   { return m.gr }
+
+func setupTest(t *testing.T) (*MockDepartmentService, *MockEmployeeService, *http.ServeMux) {
+	mocks, mux := setupMocks(t)
+	return mocks.dept, mocks.emp, mux
+}
+
+type MockTotalsSource struct {
+	mock.Mock
+}
+
+func (m *MockTotalsSource) GetTotals(ctx context.Context) (*models.OrgTotals, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrgTotals), args.Error(1)
+}
+
+func setupMocks(t *testing.T) (*MockService, *http.ServeMux) {
+	return setupRedactedMocks(t, nil)
+}
+
+func setupRedactedMocks(t *testing.T, redactor *Redactor) (*MockService, *http.ServeMux) {
+	// mock
+	mockSrv := &MockService{
+		dept: new(MockDepartmentService),
+		emp:  new(MockEmployeeService),
+		pos:  new(MockPositionService),
+		vac:  new(MockVacancyService),
+		attr: new(MockAttributeService),
+		typ:  new(MockDepartmentTypeService),
+		key:  new(MockAPIKeyService),
+		gr:   new(MockGrantService),
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := NewHandler(mockSrv, logger, redactor)
+	mux := NewRouter(h)
+
+	return mockSrv, mux
+}
+
+// TESTS
+
+func TestHandler_CreateDepartment(t *testing.T) {
+	mockDept, _, mux := setupTest(t)
+
+	t.Run("Success", func(t *testing.T) {
+		req := dto.CreateDepartmentRequest{Name: "IT"}
+		resp := &dto.DepartmentResponse{ID: 1, Name: "IT"}
+
+		mockDept.On("Create", mock.Anything, mock.MatchedBy(func(r *dto.CreateDepartmentRequest) bool {
+			return r.Name == "IT"
+		})).Return(resp, nil).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/departments", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Duplicate Name Reports Existing", func(t *testing.T) {
+		existing := dto.DepartmentResponse{ID: 7, Name: "Sales"}
+		conflict := &domain.ConflictError{Err: domain.ErrDuplicateName, Existing: existing}
+
+		mockDept.On("Create", mock.Anything, mock.MatchedBy(func(r *dto.CreateDepartmentRequest) bool {
+			return r.Name == "sales"
+		})).Return(nil, fmt.Errorf("service.department.Create: %w", conflict)).Once()
+
+		r := httptest.NewRequest("POST", "/departments", bytes.NewBufferString(`{"name":"sales"}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var body struct {
+			Error    string                 `json:"error"`
+			Conflict dto.DepartmentResponse `json:"conflict"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, 7, body.Conflict.ID)
+		assert.Equal(t, "Sales", body.Conflict.Name)
+	})
+}
+
+func TestHandler_GetDepartment(t *testing.T) {
+	mockDept, _, mux := setupTest(t)
+
+	t.Run("Success", func(t *testing.T) {
+		deptID := 1
+		// Хендлер по умолчанию ставит depth=1 и include_employees=true
+		expectedReq := &dto.GetByIDRequest{Depth: 1, IncludeEmployees: true}
+		resp := &dto.DepartmentResponse{ID: 1, Name: "IT"}
+
+		mockDept.On("GetByID", mock.Anything, deptID, expectedReq).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockDept.On("GetByID", mock.Anything, 99, mock.Anything).Return(nil, domain.ErrDepartmentNotFound).Once()
+
+		r := httptest.NewRequest("GET", "/departments/99", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_DeleteDepartment(t *testing.T) {
+	mockDept, _, mux := setupTest(t)
+
+	t.Run("Success Cascade", func(t *testing.T) {
+		// По умолчанию mode=cascade
+		req := &dto.DeleteDepartmentRequest{Mode: "cascade", ReassignToID: nil}
+
+		mockDept.On("Delete", mock.Anything, 1, req).Return(nil).Once()
+
+		r := httptest.NewRequest("DELETE", "/departments/1", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestHandler_SearchDepartments(t *testing.T) {
+	mockDept, _, mux := setupTest(t)
+
+	t.Run("Success", func(t *testing.T) {
+		expectedReq := &dto.SearchDepartmentsRequest{Query: "qa", Limit: 5}
+		resp := []dto.DepartmentSearchResponse{
+			{ID: 3, Name: "QA", Path: []dto.DepartmentPathItem{{ID: 1, Name: "IT"}}},
+			{ID: 4, Name: "QA", Path: []dto.DepartmentPathItem{{ID: 2, Name: "Mobile"}}},
+		}
+
+		mockDept.On("Search", mock.Anything, expectedReq).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/search?q=qa&limit=5", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var got []dto.DepartmentSearchResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Len(t, got, 2)
+		assert.Equal(t, "Mobile", got[1].Path[0].Name)
+	})
+
+	t.Run("Empty Query", func(t *testing.T) {
+		mockDept.On("Search", mock.Anything, mock.Anything).Return(nil, domain.ErrEmptyConstraint).Once()
+
+		r := httptest.NewRequest("GET", "/departments/search", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_ListChildren(t *testing.T) {
+	mockDept, _, mux := setupTest(t)
+
+	t.Run("Success", func(t *testing.T) {
+		expectedReq := &dto.ListChildrenRequest{PageRequest: dto.PageRequest{Cursor: "abc", Limit: 10}}
+		next := "def"
+		resp := &dto.DepartmentsPage{Items: []dto.DepartmentResponse{{ID: 2, Name: "Backend"}}, NextCursor: &next}
+
+		mockDept.On("ListChildren", mock.Anything, 1, expectedReq).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/children?cursor=abc&limit=10", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"next_cursor":"def"`)
+	})
+
+	t.Run("Filter By Attributes", func(t *testing.T) {
+		expectedReq := &dto.ListChildrenRequest{Attributes: map[string]string{"location": "Berlin", "remote": "true"}}
+		resp := &dto.DepartmentsPage{Items: []dto.DepartmentResponse{{ID: 2, Name: "Backend"}}}
+
+		mockDept.On("ListChildren", mock.Anything, 1, expectedReq).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/children?attr.location=Berlin&attr.remote=true&attr.=x", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		mockDept.On("ListChildren", mock.Anything, 1, mock.Anything).Return(nil, domain.ErrInvalidCursor).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/children?cursor=bad", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_ListEmployees(t *testing.T) {
+	_, mockEmp, mux := setupTest(t)
+
+	t.Run("Success", func(t *testing.T) {
+		resp := &dto.EmployeesPage{Items: []dto.EmployeeResponse{{ID: 1, DepartmentID: 1, FullName: "Oleg Moroz"}}}
+
+		mockEmp.On("ListByDepartment", mock.Anything, 1, &dto.ListEmployeesRequest{}).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/employees", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Filter By Position", func(t *testing.T) {
+		resp := &dto.EmployeesPage{Items: []dto.EmployeeResponse{{ID: 1, DepartmentID: 1, FullName: "Oleg Moroz"}}}
+
+		mockEmp.On("ListByDepartment", mock.Anything, 1, &dto.ListEmployeesRequest{PositionID: ptr(3)}).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/employees?position_id=3", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestHandler_Attributes(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	mockAttr := mocks.attr
+
+	t.Run("Create Success", func(t *testing.T) {
+		req := &dto.CreateAttributeRequest{EntityType: "employee", Name: "grade", Type: "enum", EnumValues: []string{"junior", "senior"}}
+		resp := &dto.AttributeResponse{ID: 1, EntityType: "employee", Name: "grade", Type: "enum", EnumValues: []string{"junior", "senior"}}
+
+		mockAttr.On("Create", mock.Anything, req).Return(resp, nil).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/attributes", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List By Entity Type", func(t *testing.T) {
+		mockAttr.On("List", mock.Anything, &dto.ListAttributesRequest{EntityType: "department"}).
+			Return([]dto.AttributeResponse{{ID: 2, EntityType: "department", Name: "location", Type: "string"}}, nil).Once()
+
+		r := httptest.NewRequest("GET", "/attributes?entity_type=department", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"location"`)
+	})
+
+	t.Run("Delete Not Found", func(t *testing.T) {
+		mockAttr.On("Delete", mock.Anything, 9).Return(domain.ErrAttributeNotFound).Once()
+
+		r := httptest.NewRequest("DELETE", "/attributes/9", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Set Employee Attributes Invalid", func(t *testing.T) {
+		mocks.emp.On("SetAttributes", mock.Anything, 1, &dto.SetAttributesRequest{Attributes: map[string]interface{}{"grade": "lead"}}).
+			Return(nil, domain.ErrInvalidAttribute).Once()
+
+		r := httptest.NewRequest("PUT", "/employees/1/attributes", bytes.NewBufferString(`{"attributes":{"grade":"lead"}}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_DepartmentTypes(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	mockType := mocks.typ
+
+	t.Run("Save Success", func(t *testing.T) {
+		maxChildren := 5
+		req := &dto.SaveDepartmentTypeRequest{MaxChildren: &maxChildren, AllowedChildren: []string{"team"}}
+		resp := &dto.DepartmentTypeResponse{Name: "department", RootAllowed: true, MaxChildren: &maxChildren, AllowedChildren: []string{"team"}}
+
+		mockType.On("Save", mock.Anything, "department", req).Return(resp, nil).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("PUT", "/department-types/department", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"allowed_children":["team"]`)
+	})
+
+	t.Run("Delete In Use", func(t *testing.T) {
+		mockType.On("Delete", mock.Anything, "team").Return(domain.ErrDepartmentTypeInUse).Once()
+
+		r := httptest.NewRequest("DELETE", "/department-types/team", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Create Department Violates Hierarchy", func(t *testing.T) {
+		parentID := 1
+		deptType := "division"
+		req := &dto.CreateDepartmentRequest{Name: "Sales", ParentID: &parentID, Type: &deptType}
+
+		mocks.dept.On("Create", mock.Anything, req).Return(nil, domain.ErrHierarchyRule).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/departments", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func TestHandler_APIKeys(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	mockKey := mocks.key
+
+	t.Run("Create Success", func(t *testing.T) {
+		req := &dto.CreateAPIKeyRequest{Name: "ci"}
+		resp := &dto.APIKeyCreatedResponse{
+			APIKeyResponse: dto.APIKeyResponse{ID: 1, Name: "ci", Prefix: "osk_abcdefgh"},
+			Key:            "osk_abcdefghijkl",
+		}
+
+		mockKey.On("Create", mock.Anything, req).Return(resp, nil).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/auth/keys", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"key":"osk_abcdefghijkl"`)
+	})
+
+	t.Run("Revoke Forbidden", func(t *testing.T) {
+		mockKey.On("Revoke", mock.Anything, 1).Return(fmt.Errorf("wrap: %w", domain.ErrForbidden)).Once()
+
+		r := httptest.NewRequest("DELETE", "/auth/keys/1", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Revoke Not Found", func(t *testing.T) {
+		mockKey.On("Revoke", mock.Anything, 9).Return(domain.ErrAPIKeyNotFound).Once()
+
+		r := httptest.NewRequest("DELETE", "/auth/keys/9", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_Grants(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	mockGrant := mocks.gr
+
+	t.Run("Save Success", func(t *testing.T) {
+		req := &dto.SaveGrantRequest{Subject: "carol", Role: "editor"}
+		resp := &dto.GrantResponse{ID: 1, Subject: "carol", DepartmentID: 4, Role: "editor"}
+
+		mockGrant.On("Save", mock.Anything, 4, req).Return(resp, nil).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("PUT", "/departments/4/grants", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"role":"editor"`)
+	})
+
+	t.Run("List Forbidden", func(t *testing.T) {
+		mockGrant.On("List", mock.Anything, 4).Return(nil, fmt.Errorf("wrap: %w", domain.ErrForbidden)).Once()
+
+		r := httptest.NewRequest("GET", "/departments/4/grants", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Delete Not Found", func(t *testing.T) {
+		mockGrant.On("Delete", mock.Anything, 4, 9).Return(domain.ErrGrantNotFound).Once()
+
+		r := httptest.NewRequest("DELETE", "/departments/4/grants/9", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Create Department Forbidden", func(t *testing.T) {
+		req := &dto.CreateDepartmentRequest{Name: "Sales"}
+
+		mocks.dept.On("Create", mock.Anything, req).Return(nil, fmt.Errorf("wrap: %w", domain.ErrForbidden)).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/departments", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Reads Without Viewer Role Forbidden", func(t *testing.T) {
+		forbidden := fmt.Errorf("wrap: %w", domain.ErrForbidden)
+		mocks.dept.On("GetByID", mock.Anything, 4, mock.Anything).Return(nil, forbidden).Once()
+		mocks.dept.On("GetStats", mock.Anything, 4).Return(nil, forbidden).Once()
+		mocks.dept.On("GetBudget", mock.Anything, 4).Return(nil, forbidden).Once()
+		mocks.vac.On("List", mock.Anything, 4, mock.Anything).Return(nil, forbidden).Once()
+		mocks.vac.On("GetHeadcount", mock.Anything, 4).Return(nil, forbidden).Once()
+
+		for _, target := range []string{
+			"/departments/4?include_employees=true",
+			"/departments/4/stats",
+			"/departments/4/budget",
+			"/departments/4/vacancies",
+			"/departments/4/headcount",
+		} {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", target, nil))
+
+			assert.Equal(t, http.StatusForbidden, w.Code, target)
+		}
+	})
+}
+
+func TestHandler_Redaction(t *testing.T) {
+	redactor, err := NewRedactor(config.RedactionCfg{
+		Fields: map[string]config.RedactionFieldCfg{
+			"hired_at":        {Scope: "employees:hr"},
+			"employee_number": {Scope: "employees:hr", Mode: "mask"},
+			"email":           {Scope: "employees:contacts", Mode: "mask"},
+			"phone":           {Scope: "employees:contacts", Mode: "mask"},
+		},
+	}, HeaderScopes{Header: "X-Scopes"})
+	assert.NoError(t, err)
+
+	mocks, mux := setupRedactedMocks(t, redactor)
+
+	employee := func() dto.EmployeeResponse {
+		hiredAt, email, phone, number := "2021-03-15", "anna.lis@example.com", "+79991234567", "EMP-000042"
+		return dto.EmployeeResponse{
+			ID: 42, DepartmentID: 1, FullName: "Anna Lis", Position: "Seller",
+			HiredAt: &hiredAt, Email: &email, Phone: &phone, EmployeeNumber: &number, Status: "active",
+		}
+	}
+
+	listEmployees := func(t *testing.T, scopes string) dto.EmployeeResponse {
+		mocks.emp.On("ListByDepartment", mock.Anything, 1, mock.Anything).
+			Return(&dto.EmployeesPage{Items: []dto.EmployeeResponse{employee()}}, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/employees", nil)
+		if scopes != "" {
+			r.Header.Set("X-Scopes", scopes)
+		}
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page dto.EmployeesPage
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Items, 1)
+		return page.Items[0]
+	}
+
+	t.Run("No Scopes", func(t *testing.T) {
+		emp := listEmployees(t, "")
+
+		assert.Equal(t, "Anna Lis", emp.FullName)
+		assert.Nil(t, emp.HiredAt)
+		assert.Equal(t, "***-****42", *emp.EmployeeNumber)
+		assert.Equal(t, "a***@example.com", *emp.Email)
+		assert.Equal(t, "+*********67", *emp.Phone)
+	})
+
+	t.Run("Contacts Scope", func(t *testing.T) {
+		emp := listEmployees(t, "employees:contacts")
+
+		assert.Equal(t, "anna.lis@example.com", *emp.Email)
+		assert.Equal(t, "+79991234567", *emp.Phone)
+		assert.Nil(t, emp.HiredAt)
+		assert.Equal(t, "***-****42", *emp.EmployeeNumber)
+	})
+
+	t.Run("HR Scope", func(t *testing.T) {
+		emp := listEmployees(t, "employees:hr")
+
+		assert.Equal(t, "2021-03-15", *emp.HiredAt)
+		assert.Equal(t, "EMP-000042", *emp.EmployeeNumber)
+		assert.Equal(t, "a***@example.com", *emp.Email)
+	})
+
+	t.Run("All Scopes", func(t *testing.T) {
+		emp := listEmployees(t, "employees:hr, employees:contacts")
+
+		assert.Equal(t, employee(), emp)
+	})
+
+	t.Run("Department Tree", func(t *testing.T) {
+		head := employee()
+		child := dto.DepartmentResponse{ID: 2, Name: "East", Employees: []dto.EmployeeResponse{employee()}}
+		resp := &dto.DepartmentResponse{
+			ID: 1, Name: "Sales", Head: &head,
+			Employees:        []dto.EmployeeResponse{employee()},
+			Children:         []dto.DepartmentResponse{child},
+			SecondaryMembers: []dto.SecondaryMemberResponse{{AssignmentID: 1, Employee: employee()}},
+		}
+		mocks.dept.On("GetByID", mock.Anything, 1, mock.Anything).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1?depth=2", nil)
+		r.Header.Set("X-Scopes", "employees:hr")
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.NotContains(t, body, "anna.lis@example.com", "email of every employee in the tree is masked")
+		assert.NotContains(t, body, "+79991234567")
+		assert.Equal(t, 4, strings.Count(body, `"hired_at":"2021-03-15"`))
+	})
+
+	t.Run("Invalid Policy", func(t *testing.T) {
+		_, err := NewRedactor(config.RedactionCfg{
+			Fields: map[string]config.RedactionFieldCfg{"full_name": {Scope: "employees:hr"}},
+		}, HeaderScopes{Header: "X-Scopes"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHandler_RedactionPrincipalScopes(t *testing.T) {
+	redactor, err := NewRedactor(config.RedactionCfg{
+		Fields: map[string]config.RedactionFieldCfg{
+			"email": {Scope: "employees:contacts", Mode: "mask"},
+		},
+	}, PrincipalScopes{})
+	assert.NoError(t, err)
+
+	mocks, mux := setupRedactedMocks(t, redactor)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := Authenticate(mocks.key, logger)(mux)
+
+	listEmployees := func(t *testing.T, key string, scopes string) dto.EmployeeResponse {
+		email := "anna.lis@example.com"
+		mocks.emp.On("ListByDepartment", mock.Anything, 1, mock.Anything).
+			Return(&dto.EmployeesPage{Items: []dto.EmployeeResponse{{ID: 42, DepartmentID: 1, FullName: "Anna Lis", Email: &email}}}, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/employees", nil)
+		r.Header.Set("Authorization", "Bearer "+key)
+		if scopes != "" {
+			r.Header.Set("X-Scopes", scopes)
+		}
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var page dto.EmployeesPage
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Items, 1)
+		return page.Items[0]
+	}
+
+	mocks.key.On("Authenticate", mock.Anything, "osk_plain").
+		Return(&models.Principal{Subject: "api-key:1", Method: domain.AuthMethodAPIKey}, nil)
+	mocks.key.On("Authenticate", mock.Anything, "osk_contacts").
+		Return(&models.Principal{Subject: "api-key:2", Method: domain.AuthMethodAPIKey, Scopes: []string{"employees:contacts"}}, nil)
+
+	t.Run("Client Header Ignored", func(t *testing.T) {
+		emp := listEmployees(t, "osk_plain", "employees:contacts")
+
+		assert.Equal(t, "a***@example.com", *emp.Email)
+	})
+
+	t.Run("Principal Scopes", func(t *testing.T) {
+		emp := listEmployees(t, "osk_contacts", "")
+
+		assert.Equal(t, "anna.lis@example.com", *emp.Email)
+	})
+}
+
+func TestHandler_Authenticate(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := Authenticate(mocks.key, logger)(mux)
+
+	t.Run("No Credentials", func(t *testing.T) {
+		mocks.key.On("Authenticate", mock.Anything, "").Return(nil, domain.ErrUnauthenticated).Once()
+
+		r := httptest.NewRequest("GET", "/positions", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("Principal On Context", func(t *testing.T) {
+		principal := &models.Principal{Subject: "api-key:1", Method: domain.AuthMethodAPIKey, Admin: true}
+		mocks.key.On("Authenticate", mock.Anything, "osk_valid").Return(principal, nil).Once()
+		mocks.key.On("List", mock.MatchedBy(func(ctx context.Context) bool {
+			p, ok := domain.PrincipalFromContext(ctx)
+			return ok && p.Subject == "api-key:1"
+		})).Return([]dto.APIKeyResponse{}, nil).Once()
+
+		r := httptest.NewRequest("GET", "/auth/keys", nil)
+		r.Header.Set("Authorization", "Bearer osk_valid")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mocks.key.AssertExpectations(t)
+	})
+
+	t.Run("X-API-Key Header", func(t *testing.T) {
+		mocks.key.On("Authenticate", mock.Anything, "osk_header").Return(nil, domain.ErrUnauthenticated).Once()
+
+		r := httptest.NewRequest("GET", "/positions", nil)
+		r.Header.Set("X-API-Key", "osk_header")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Swagger Is Public", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/swagger/doc.json", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.NotEqual(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestHandler_Middleware(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	var logs bytes.Buffer
+	log := slog.New(pkglogger.NewContextHandler(slog.NewJSONHandler(&logs, nil)))
+	handler := RequestID(AccessLog(log, mux)(Recover(log)(mux)))
+
+	// lastLog - last record written by middleware
+	lastLog := func(t *testing.T) map[string]interface{} {
+		lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+		var record map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &record))
+		return record
+	}
+
+	t.Run("Generates Request ID", func(t *testing.T) {
+		logs.Reset()
+		mocks.key.On("List", mock.MatchedBy(func(ctx context.Context) bool {
+			return pkglogger.RequestID(ctx) != ""
+		})).Return([]dto.APIKeyResponse{}, nil).Once()
+
+		r := httptest.NewRequest("GET", "/auth/keys", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, w.Header().Get(RequestIDHeader), 32)
+		mocks.key.AssertExpectations(t)
+	})
+
+	t.Run("Access Log", func(t *testing.T) {
+		logs.Reset()
+		mocks.key.On("Revoke", mock.Anything, 7).Return(nil).Once()
+
+		r := httptest.NewRequest("DELETE", "/auth/keys/7", nil)
+		r.Header.Set(RequestIDHeader, "req-42")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, "req-42", w.Header().Get(RequestIDHeader))
+		record := lastLog(t)
+		assert.Equal(t, "request", record["msg"])
+		assert.Equal(t, "req-42", record["request_id"])
+		assert.Equal(t, "DELETE", record["method"])
+		assert.Equal(t, "DELETE /auth/keys/{id}", record["route"])
+		assert.Equal(t, float64(http.StatusNoContent), record["status"])
+		assert.Equal(t, float64(0), record["bytes"])
+		assert.Contains(t, record, "latency")
+	})
+
+	t.Run("Invalid Request ID Replaced", func(t *testing.T) {
+		mocks.key.On("List", mock.Anything).Return([]dto.APIKeyResponse{}, nil).Once()
+
+		r := httptest.NewRequest("GET", "/auth/keys", nil)
+		r.Header.Set(RequestIDHeader, "bad id\twith spaces")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.NotEqual(t, "bad id\twith spaces", w.Header().Get(RequestIDHeader))
+		assert.NotEmpty(t, w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("Panic Recovered", func(t *testing.T) {
+		logs.Reset()
+		mocks.key.On("List", mock.Anything).Run(func(mock.Arguments) {
+			panic("boom")
+		}).Once()
+
+		r := httptest.NewRequest("GET", "/auth/keys", nil)
+		r.Header.Set(RequestIDHeader, "req-panic")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, `{"error":"internal server error"}`, w.Body.String())
+		assert.Contains(t, logs.String(), `"msg":"panic recovered"`)
+		record := lastLog(t)
+		assert.Equal(t, "req-panic", record["request_id"])
+		assert.Equal(t, float64(http.StatusInternalServerError), record["status"])
+	})
+}
+
+func TestHandler_Metrics(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	m := metrics.New()
+	mux.Handle("GET /metrics", m.Handler())
+	handler := Instrument(m, mux)(mux)
+
+	mocks.key.On("Revoke", mock.Anything, 3).Return(nil).Once()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("DELETE", "/auth/keys/3", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/no-such-route", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	totals := new(MockTotalsSource)
+	totals.On("GetTotals", mock.Anything).Return(&models.OrgTotals{Departments: 12, Employees: 40, MaxDepth: 3}, nil).Once()
+	assert.NoError(t, m.RefreshTotals(context.Background(), totals))
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `org_struct_http_requests_total{method="DELETE",route="DELETE /auth/keys/{id}",status="204"} 1`)
+	assert.Contains(t, body, `org_struct_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `org_struct_http_request_duration_seconds_count{method="DELETE",route="DELETE /auth/keys/{id}",status="204"} 1`)
+	assert.Contains(t, body, "org_struct_departments 12")
+	assert.Contains(t, body, "org_struct_employees 40")
+	assert.Contains(t, body, "org_struct_department_tree_max_depth 3")
+	totals.AssertExpectations(t)
+}
+
+func TestHandler_Trace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	mocks, mux := setupMocks(t)
+	var logs bytes.Buffer
+	log := slog.New(pkglogger.NewContextHandler(slog.NewJSONHandler(&logs, nil)))
+	handler := Trace(mux)(AccessLog(log, mux)(mux))
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	mocks.key.On("Revoke", mock.MatchedBy(func(ctx context.Context) bool {
+		return trace.SpanContextFromContext(ctx).TraceID().String() == traceID
+	}), 5).Return(nil).Once()
+
+	r := httptest.NewRequest("DELETE", "/auth/keys/5", nil)
+	r.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mocks.key.AssertExpectations(t)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "DELETE /auth/keys/{id}", spans[0].Name())
+		assert.Equal(t, traceID, spans[0].SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+		assert.Contains(t, spans[0].Attributes(), attribute.String("http.route", "DELETE /auth/keys/{id}"))
+	}
+	assert.Contains(t, logs.String(), `"trace_id":"`+traceID+`"`)
+}
+
+func TestHandler_Health(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	health := NewHealth(logger)
+	var dbErr error
+	health.AddCheck("database", func(ctx context.Context) error { return dbErr })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", health.Live)
+	mux.HandleFunc("GET /readyz", health.Ready)
+
+	get := func(path string) (*httptest.ResponseRecorder, dto.HealthResponse) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		var resp dto.HealthResponse
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return w, resp
+	}
+
+	t.Run("Live", func(t *testing.T) {
+		w, resp := get("/healthz")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, dto.HealthStatusOK, resp.Status)
+	})
+
+	t.Run("Ready", func(t *testing.T) {
+		w, resp := get("/readyz")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, map[string]string{"database": "ok"}, resp.Checks)
+	})
+
+	t.Run("Check Failed", func(t *testing.T) {
+		dbErr = fmt.Errorf("connection refused")
+		defer func() { dbErr = nil }()
+
+		w, resp := get("/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, dto.HealthStatusUnavailable, resp.Status)
+		assert.Equal(t, "connection refused", resp.Checks["database"])
+	})
+
+	t.Run("Shutting Down", func(t *testing.T) {
+		health.SetShuttingDown()
+
+		w, resp := get("/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, dto.HealthStatusUnavailable, resp.Status)
+
+		w, _ = get("/healthz")
+		assert.Equal(t, http.StatusOK, w.Code, "process is still alive")
+	})
+
+	t.Run("Public", func(t *testing.T) {
+		keys := new(MockAPIKeyService)
+		w := httptest.NewRecorder()
+		Authenticate(keys, logger)(mux).ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		keys.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_ReorderChildren(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	mockDept := mocks.dept
+
+	t.Run("Success", func(t *testing.T) {
+		req := &dto.ReorderChildrenRequest{ChildIDs: []int{3, 2}}
+		resp := []dto.DepartmentResponse{{ID: 3, Name: "QA", SortOrder: 1}, {ID: 2, Name: "Dev", SortOrder: 2}}
+
+		mockDept.On("Reorder", mock.Anything, 1, req).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("POST", "/departments/1/reorder", bytes.NewBufferString(`{"child_ids":[3,2]}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var got []dto.DepartmentResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, []int{3, 2}, []int{got[0].ID, got[1].ID})
+	})
+
+	t.Run("Missing Child", func(t *testing.T) {
+		req := &dto.ReorderChildrenRequest{ChildIDs: []int{3}}
+
+		mockDept.On("Reorder", mock.Anything, 1, req).Return(nil, domain.ErrInvalidChildOrder).Once()
+
+		r := httptest.NewRequest("POST", "/departments/1/reorder", bytes.NewBufferString(`{"child_ids":[3]}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_GetDepartmentBudget(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	mockDept := mocks.dept
+
+	t.Run("Success", func(t *testing.T) {
+		budget := int64(500000)
+		perHead := 125000.0
+		resp := []dto.DepartmentBudgetResponse{
+			{DepartmentID: 1, Name: "Sales", Budget: &budget, TotalBudget: 500000, Headcount: 4, TotalHeadcount: 4, BudgetPerHead: &perHead},
+		}
+
+		mockDept.On("GetBudget", mock.Anything, 1).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/budget", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"budget_per_head":125000`)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockDept.On("GetBudget", mock.Anything, 99).Return(nil, domain.ErrDepartmentNotFound).Once()
+
+		r := httptest.NewRequest("GET", "/departments/99/budget", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_GetDepartmentStats(t *testing.T) {
+	mockDept, _, mux := setupTest(t)
+
+	t.Run("Success", func(t *testing.T) {
+		resp := &dto.DepartmentStatsResponse{
+			DepartmentID:       1,
+			TotalEmployeeCount: 12,
+			Positions:          []dto.PositionCountResponse{{Position: "Developer", Count: 10}},
+		}
+
+		mockDept.On("GetStats", mock.Anything, 1).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/stats", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"total_employee_count":12`)
+	})
+
+	t.Run("Include Stats Query", func(t *testing.T) {
+		expectedReq := &dto.GetByIDRequest{Depth: 1, IncludeEmployees: true, IncludeStats: true}
+
+		mockDept.On("GetByID", mock.Anything, 1, expectedReq).Return(&dto.DepartmentResponse{ID: 1}, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1?include_stats=true", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestHandler_SetDepartmentHead(t *testing.T) {
+	mockDept, _, mux := setupTest(t)
+
+	t.Run("Success", func(t *testing.T) {
+		headID := 5
+		resp := &dto.DepartmentResponse{ID: 1, Name: "IT", Head: &dto.EmployeeResponse{ID: headID}}
+
+		mockDept.On("SetHead", mock.Anything, 1, &dto.SetHeadRequest{EmployeeID: &headID}).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("PUT", "/departments/1/head", bytes.NewBufferString(`{"employee_id":5}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Head Outside Hierarchy", func(t *testing.T) {
+		mockDept.On("SetHead", mock.Anything, 1, mock.Anything).Return(nil, domain.ErrInvalidHead).Once()
+
+		r := httptest.NewRequest("PUT", "/departments/1/head", bytes.NewBufferString(`{"employee_id":9}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
-type MockService struct {
-	mock.Mock
-	dept domain.DepartmentService
-	emp  domain.EmployeeService
+func TestHandler_GetManagerChain(t *testing.T) {
+	_, mockEmp, mux := setupTest(t)
+
+	t.Run("Employee Not Found", func(t *testing.T) {
+		mockEmp.On("GetManagerChain", mock.Anything, 99).Return(nil, domain.ErrEmployeeNotFound).Once()
+
+		r := httptest.NewRequest("GET", "/employees/99/manager-chain", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
 }
 
-func (m *MockService) Department() domain.DepartmentService { return m.dept }
-func (m *MockService) Employee() domain.EmployeeService     { return m.emp }
+func TestHandler_SetManager(t *testing.T) {
+	_, mockEmp, mux := setupTest(t)
 
-func setupTest(t *testing.T) (*MockDepartmentService, *MockEmployeeService, *http.ServeMux) {
-	mockDept := new(MockDepartmentService)
-	mockEmp := new(MockEmployeeService)
+	t.Run("Cycle", func(t *testing.T) {
+		mockEmp.On("SetManager", mock.Anything, 1, &dto.SetManagerRequest{ManagerID: ptr(3)}).Return(nil, domain.ErrCycleConstraint).Once()
 
-	// mock
-	mockSrv := &MockService{
-		dept: mockDept,
-		emp:  mockEmp,
-	}
+		r := httptest.NewRequest("PUT", "/employees/1/manager", bytes.NewBufferString(`{"manager_id":3}`))
+		w := httptest.NewRecorder()
 
-	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	h := NewHandler(mockSrv, logger)
-	mux := NewRouter(h)
+		mux.ServeHTTP(w, r)
 
-	return mockDept, mockEmp, mux
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
 }
 
-// TESTS
+func TestHandler_ListAllReports(t *testing.T) {
+	_, mockEmp, mux := setupTest(t)
 
-func TestHandler_CreateDepartment(t *testing.T) {
-	mockDept, _, mux := setupTest(t)
+	t.Run("Success", func(t *testing.T) {
+		resp := []dto.EmployeeResponse{{ID: 2, ManagerID: ptr(1)}, {ID: 3, ManagerID: ptr(2)}}
+
+		mockEmp.On("ListAllReports", mock.Anything, 1).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/employees/1/reports/all", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"manager_id":2`)
+	})
+}
+
+func TestHandler_GetSpanOfControl(t *testing.T) {
+	_, mockEmp, mux := setupTest(t)
 
 	t.Run("Success", func(t *testing.T) {
-		req := dto.CreateDepartmentRequest{Name: "IT"}
-		resp := &dto.DepartmentResponse{ID: 1, Name: "IT"}
+		mockEmp.On("GetSpanOfControl", mock.Anything, &dto.SpanOfControlRequest{Min: 3, Max: 8}).
+			Return([]dto.ManagerSpanResponse{{Manager: dto.EmployeeResponse{ID: 1}, DirectReports: 9, Status: domain.SpanStatusTooMany}}, nil).Once()
 
-		mockDept.On("Create", mock.Anything, mock.MatchedBy(func(r *dto.CreateDepartmentRequest) bool {
-			return r.Name == "IT"
-		})).Return(resp, nil).Once()
+		r := httptest.NewRequest("GET", "/reports/span-of-control?min=3&max=8", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"too_many"`)
+	})
+}
+
+func TestHandler_Assignments(t *testing.T) {
+	_, mockEmp, mux := setupTest(t)
+
+	t.Run("Create Success", func(t *testing.T) {
+		req := &dto.CreateAssignmentRequest{DepartmentID: 7, Role: "Guild", AllocationPercent: 20}
+		resp := &dto.AssignmentResponse{ID: 1, EmployeeID: 1, DepartmentID: 7, AllocationPercent: 20}
+
+		mockEmp.On("CreateAssignment", mock.Anything, 1, req).Return(resp, nil).Once()
 
 		body, _ := json.Marshal(req)
-		r := httptest.NewRequest("POST", "/departments", bytes.NewBuffer(body))
+		r := httptest.NewRequest("POST", "/employees/1/assignments", bytes.NewBuffer(body))
 		w := httptest.NewRecorder()
 
 		mux.ServeHTTP(w, r)
 
 		assert.Equal(t, http.StatusCreated, w.Code)
 	})
+
+	t.Run("Create Allocation Exceeded", func(t *testing.T) {
+		mockEmp.On("CreateAssignment", mock.Anything, 1, mock.Anything).Return(nil, domain.ErrAllocationConstraint).Once()
+
+		r := httptest.NewRequest("POST", "/employees/1/assignments", bytes.NewBufferString(`{"department_id":7,"allocation_percent":90}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Delete Success", func(t *testing.T) {
+		mockEmp.On("DeleteAssignment", mock.Anything, 1, 3).Return(nil).Once()
+
+		r := httptest.NewRequest("DELETE", "/employees/1/assignments/3", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
 }
 
-func TestHandler_GetDepartment(t *testing.T) {
-	mockDept, _, mux := setupTest(t)
+func TestHandler_Positions(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	mockPos := mocks.pos
 
-	t.Run("Success", func(t *testing.T) {
-		deptID := 1
-		// Хендлер по умолчанию ставит depth=1 и include_employees=true
-		expectedReq := &dto.GetByIDRequest{Depth: 1, IncludeEmployees: true}
-		resp := &dto.DepartmentResponse{ID: 1, Name: "IT"}
+	t.Run("Create Success", func(t *testing.T) {
+		req := &dto.CreatePositionRequest{Title: "Developer", Family: "Engineering", Level: ptr(2)}
+		resp := &dto.PositionResponse{ID: 1, Title: "Developer", Family: "Engineering", Level: ptr(2)}
 
-		mockDept.On("GetByID", mock.Anything, deptID, expectedReq).Return(resp, nil).Once()
+		mockPos.On("Create", mock.Anything, req).Return(resp, nil).Once()
 
-		r := httptest.NewRequest("GET", "/departments/1", nil)
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/positions", bytes.NewBuffer(body))
 		w := httptest.NewRecorder()
 
 		mux.ServeHTTP(w, r)
 
-		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusCreated, w.Code)
 	})
 
-	t.Run("Not Found", func(t *testing.T) {
-		mockDept.On("GetByID", mock.Anything, 99, mock.Anything).Return(nil, domain.ErrDepartmentNotFound).Once()
+	t.Run("Create Duplicate", func(t *testing.T) {
+		mockPos.On("Create", mock.Anything, mock.Anything).Return(nil, domain.ErrDuplicateName).Once()
 
-		r := httptest.NewRequest("GET", "/departments/99", nil)
+		r := httptest.NewRequest("POST", "/positions", bytes.NewBufferString(`{"title":"developer"}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Get Not Found", func(t *testing.T) {
+		mockPos.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrPositionNotFound).Once()
+
+		r := httptest.NewRequest("GET", "/positions/9", nil)
 		w := httptest.NewRecorder()
 
 		mux.ServeHTTP(w, r)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
+
+	t.Run("Delete In Use", func(t *testing.T) {
+		mockPos.On("Delete", mock.Anything, 1).Return(domain.ErrPositionInUse).Once()
+
+		r := httptest.NewRequest("DELETE", "/positions/1", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
 }
 
-func TestHandler_DeleteDepartment(t *testing.T) {
-	mockDept, _, mux := setupTest(t)
+func TestHandler_CreateEmployee(t *testing.T) {
+	_, mockEmp, mux := setupTest(t)
 
-	t.Run("Success Cascade", func(t *testing.T) {
-		// По умолчанию mode=cascade
-		req := &dto.DeleteDepartmentRequest{Mode: "cascade", ReassignToID: nil}
+	t.Run("Success With Contacts", func(t *testing.T) {
+		email := "oleg.moroz@example.com"
+		phone := "+79151234567"
+		req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: "Developer", Email: &email, Phone: &phone}
+		number := "EMP-000001"
+		resp := &dto.EmployeeResponse{ID: 1, DepartmentID: 1, FullName: "Oleg Moroz", Email: &email, Phone: &phone, EmployeeNumber: &number}
 
-		mockDept.On("Delete", mock.Anything, 1, req).Return(nil).Once()
+		mockEmp.On("Create", mock.Anything, 1, req).Return(resp, nil).Once()
 
-		r := httptest.NewRequest("DELETE", "/departments/1", nil)
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/departments/1/employees", bytes.NewBuffer(body))
 		w := httptest.NewRecorder()
 
 		mux.ServeHTTP(w, r)
 
-		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Duplicate Email", func(t *testing.T) {
+		mockEmp.On("Create", mock.Anything, 1, mock.Anything).Return(nil, domain.ErrDuplicateEmail).Once()
+
+		r := httptest.NewRequest("POST", "/departments/1/employees", bytes.NewBufferString(`{"full_name":"Oleg Moroz","position":"Developer","email":"OLEG.MOROZ@example.com"}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Duplicate Employee Number", func(t *testing.T) {
+		mockEmp.On("Create", mock.Anything, 1, mock.Anything).Return(nil, domain.ErrDuplicateEmployeeNumber).Once()
+
+		r := httptest.NewRequest("POST", "/departments/1/employees", bytes.NewBufferString(`{"full_name":"Oleg Moroz","position":"Developer","employee_number":"EMP-000001"}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Invalid Email And Phone", func(t *testing.T) {
+		email := "not-an-email"
+		phone := "8 915 123"
+		invalid := validator.New().Struct(&dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: "Developer", Email: &email, Phone: &phone})
+		mockEmp.On("Create", mock.Anything, 1, mock.Anything).
+			Return(nil, fmt.Errorf("service.employee.Create: validation failed: %w", invalid)).Once()
+
+		r := httptest.NewRequest("POST", "/departments/1/employees", bytes.NewBufferString(`{"full_name":"Oleg Moroz","position":"Developer","email":"not-an-email","phone":"8 915 123"}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "'email' tag")
+		assert.Contains(t, w.Body.String(), "'e164' tag")
+	})
+}
+
+func TestHandler_EmployeeLifecycle(t *testing.T) {
+	_, mockEmp, mux := setupTest(t)
+
+	t.Run("Terminate Without Body", func(t *testing.T) {
+		resp := &dto.EmployeeResponse{ID: 1, Status: domain.EmployeeStatusTerminated}
+
+		mockEmp.On("Terminate", mock.Anything, 1, &dto.TerminateEmployeeRequest{}).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("POST", "/employees/1/terminate", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Terminate Before Hire", func(t *testing.T) {
+		mockEmp.On("Terminate", mock.Anything, 2, mock.Anything).Return(nil, domain.ErrInvalidTerminatedAt).Once()
+
+		r := httptest.NewRequest("POST", "/employees/2/terminate", bytes.NewBufferString(`{"terminated_at":"2020-01-01"}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Rehire Active", func(t *testing.T) {
+		mockEmp.On("Rehire", mock.Anything, 1, mock.Anything).Return(nil, domain.ErrEmployeeNotTerminated).Once()
+
+		r := httptest.NewRequest("POST", "/employees/1/rehire", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Set On Leave", func(t *testing.T) {
+		req := &dto.SetStatusRequest{Status: domain.EmployeeStatusOnLeave}
+		resp := &dto.EmployeeResponse{ID: 1, Status: domain.EmployeeStatusOnLeave}
+
+		mockEmp.On("SetStatus", mock.Anything, 1, req).Return(resp, nil).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("PUT", "/employees/1/status", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("List Including Terminated", func(t *testing.T) {
+		mockEmp.On("ListByDepartment", mock.Anything, 1, &dto.ListEmployeesRequest{IncludeTerminated: true}).
+			Return(&dto.EmployeesPage{}, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/employees?include_terminated=true", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestHandler_Vacancies(t *testing.T) {
+	mocks, mux := setupMocks(t)
+	mockVac := mocks.vac
+
+	t.Run("Create Success", func(t *testing.T) {
+		target := "2026-12-01"
+		req := &dto.CreateVacancyRequest{PositionID: 3, TargetStartDate: &target}
+		resp := &dto.VacancyResponse{ID: 1, DepartmentID: 1, PositionID: 3, Status: domain.VacancyStatusOpen}
+
+		mockVac.On("Create", mock.Anything, 1, req).Return(resp, nil).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/departments/1/vacancies", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List By Status", func(t *testing.T) {
+		mockVac.On("List", mock.Anything, 1, &dto.ListVacanciesRequest{Status: "open"}).Return([]dto.VacancyResponse{}, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/vacancies?status=open", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Fill Success", func(t *testing.T) {
+		req := &dto.FillVacancyRequest{FullName: "Oleg Moroz"}
+		resp := &dto.FillVacancyResponse{
+			Vacancy:  dto.VacancyResponse{ID: 2, DepartmentID: 1, Status: domain.VacancyStatusFilled, EmployeeID: ptr(5)},
+			Employee: dto.EmployeeResponse{ID: 5, DepartmentID: 1, FullName: "Oleg Moroz"},
+		}
+
+		mockVac.On("Fill", mock.Anything, 1, 2, req).Return(resp, nil).Once()
+
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/departments/1/vacancies/2/fill", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Fill Closed", func(t *testing.T) {
+		mockVac.On("Fill", mock.Anything, 1, 3, mock.Anything).Return(nil, domain.ErrVacancyClosed).Once()
+
+		r := httptest.NewRequest("POST", "/departments/1/vacancies/3/fill", bytes.NewBufferString(`{"full_name":"Oleg Moroz"}`))
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Headcount", func(t *testing.T) {
+		resp := []dto.HeadcountResponse{{DepartmentID: 1, Name: "IT", ActualHeadcount: 4, OpenVacancies: 2, PlannedHeadcount: 6}}
+
+		mockVac.On("GetHeadcount", mock.Anything, 1).Return(resp, nil).Once()
+
+		r := httptest.NewRequest("GET", "/departments/1/headcount", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+func ptr(i int) *int {
+	return &i
+}
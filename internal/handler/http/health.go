@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// readinessTimeout - time limit of every readiness check
+const readinessTimeout = 2 * time.Second
+
+// HealthCheck - check of dependency the service needs to serve requests, nil error means healthy
+type HealthCheck func(ctx context.Context) error
+
+// Health - liveness and readiness probes of the process
+type Health struct {
+	log          *slog.Logger
+	mu           sync.RWMutex
+	checks       map[string]HealthCheck
+	shuttingDown atomic.Bool
+}
+
+// NewHealth - constructor for Health without readiness checks
+func NewHealth(log *slog.Logger) *Health {
+	return &Health{log: log, checks: make(map[string]HealthCheck)}
+}
+
+// AddCheck - adds readiness check reported under name
+func (h *Health) AddCheck(name string, check HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// SetShuttingDown - marks process as not ready, called on graceful shutdown before server stops accepting requests
+func (h *Health) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Live godoc
+// @Summary Liveness probe
+// @Description Return 200 while process is running
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.HealthResponse
+// @Security
+// @Router /healthz [get]
+func (h *Health) Live(w http.ResponseWriter, r *http.Request) {
+	renderJSON(w, http.StatusOK, dto.HealthResponse{Status: dto.HealthStatusOK})
+}
+
+// Ready godoc
+// @Summary Readiness probe
+// @Description Return 200 when database is reachable and migrated to the latest version, 503 with failed checks otherwise or during shutdown
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.HealthResponse
+// @Failure 503 {object} dto.HealthResponse
+// @Security
+// @Router /readyz [get]
+func (h *Health) Ready(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.Ready"
+
+	if h.shuttingDown.Load() {
+		renderJSON(w, http.StatusServiceUnavailable, dto.HealthResponse{
+			Status: dto.HealthStatusUnavailable,
+			Checks: map[string]string{"shutdown": "server is shutting down"},
+		})
+		return
+	}
+
+	h.mu.RLock()
+	checks := make(map[string]HealthCheck, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mu.RUnlock()
+
+	resp := dto.HealthResponse{Status: dto.HealthStatusOK, Checks: make(map[string]string, len(checks))}
+	for name, check := range checks {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		err := check(ctx)
+		cancel()
+
+		if err != nil {
+			h.log.WarnContext(r.Context(), "readiness check failed", slog.String("op", op), slog.String("check", name), slog.String("err", err.Error()))
+			resp.Status = dto.HealthStatusUnavailable
+			resp.Checks[name] = err.Error()
+			continue
+		}
+		resp.Checks[name] = dto.HealthStatusOK
+	}
+
+	status := http.StatusOK
+	if resp.Status != dto.HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	renderJSON(w, status, resp)
+}
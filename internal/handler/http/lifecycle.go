@@ -0,0 +1,147 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// SetEmployeeStatus godoc
+// @Summary Set employee status
+// @Description Put employee on leave or back to active
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param input body dto.SetStatusRequest true "New status"
+// @Success 200 {object} dto.EmployeeResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /employees/{id}/status [put]
+func (h *Handler) SetEmployeeStatus(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetEmployeeStatus"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting setting employee status")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	var req dto.SetStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Employee().SetStatus(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employee(resp)
+
+	log.InfoContext(r.Context(), "set employee status", "id", id, "status", req.Status)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// TerminateEmployee godoc
+// @Summary Terminate employee
+// @Description Terminate employee, their reports move to their manager and they stop heading departments
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param input body dto.TerminateEmployeeRequest false "Termination date, today by default"
+// @Success 200 {object} dto.EmployeeResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /employees/{id}/terminate [post]
+func (h *Handler) TerminateEmployee(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.TerminateEmployee"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting terminating employee")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	// Body is optional
+	var req dto.TerminateEmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Employee().Terminate(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employee(resp)
+
+	log.InfoContext(r.Context(), "terminated employee", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// RehireEmployee godoc
+// @Summary Rehire employee
+// @Description Make terminated employee active again with a new hire date
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param input body dto.RehireEmployeeRequest false "Hire date, today by default"
+// @Success 200 {object} dto.EmployeeResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /employees/{id}/rehire [post]
+func (h *Handler) RehireEmployee(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.RehireEmployee"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting rehiring employee")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	// Body is optional
+	var req dto.RehireEmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Employee().Rehire(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employee(resp)
+
+	log.InfoContext(r.Context(), "rehired employee", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/metrics"
+)
+
+// unmatchedRoute - route label of requests matching no pattern, keeps label cardinality bounded
+const unmatchedRoute = "unmatched"
+
+// Instrument - middleware recording count and latency of requests by method, route pattern and status,
+// route is resolved against mux
+func Instrument(m *metrics.Metrics, mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &responseRecorder{ResponseWriter: w}
+			_, route := mux.Handler(r)
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			next.ServeHTTP(rec, r)
+
+			m.ObserveRequest(r.Method, route, rec.Status(), time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/tmozzze/org_struct_api/pkg/logger"
+)
+
+// RequestIDHeader - header carrying request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen - longest incoming request ID kept as is
+const maxRequestIDLen = 128
+
+// RequestID - middleware taking request ID from X-Request-ID header or generating a new one,
+// puts it on request context for logging and echoes it in response header
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logger.WithRequestID(r.Context(), id)))
+	})
+}
+
+// AccessLog - middleware writing one log record per request with method, route pattern,
+// status, latency and response size, route is resolved against mux
+func AccessLog(log *slog.Logger, mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &responseRecorder{ResponseWriter: w}
+			_, route := mux.Handler(r)
+
+			next.ServeHTTP(rec, r)
+
+			level := slog.LevelInfo
+			if rec.Status() >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			log.LogAttrs(r.Context(), level, "request",
+				slog.String("method", r.Method),
+				slog.String("route", route),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", rec.Status()),
+				slog.Duration("latency", time.Since(start)),
+				slog.Int("bytes", rec.bytes),
+			)
+		})
+	}
+}
+
+// Recover - middleware turning handler panic into 500 response instead of dropped connection
+func Recover(log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "handler.Recover"
+
+			rec := &responseRecorder{ResponseWriter: w}
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				// Deliberate abort of response, net/http handles it
+				if err, ok := p.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(p)
+				}
+
+				log.ErrorContext(r.Context(), "panic recovered",
+					slog.String("op", op),
+					slog.Any("panic", p),
+					slog.String("stack", string(debug.Stack())),
+				)
+				// Part of response is already sent, status can't be changed
+				if rec.status != 0 {
+					return
+				}
+				renderJSON(rec, http.StatusInternalServerError, errorResponse{Error: "internal server error"})
+			}()
+
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// responseRecorder - response writer remembering status and written bytes
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+// WriteHeader - records status
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write - records implicit 200 status and body size
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Status - response status, 200 when handler wrote nothing
+func (r *responseRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Unwrap - gives http.ResponseController access to underlying writer
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// CreatePosition godoc
+// @Summary Create position
+// @Description Create position in the catalogue
+// @Tags positions
+// @Accept json
+// @Produce json
+// @Param input body dto.CreatePositionRequest true "Position data"
+// @Success 201 {object} dto.PositionResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /positions [post]
+func (h *Handler) CreatePosition(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CreatePosition"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting creating position")
+
+	var req dto.CreatePositionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Position().Create(r.Context(), &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "created position", "title", req.Title)
+	renderJSON(w, http.StatusCreated, resp)
+}
+
+// ListPositions godoc
+// @Summary List positions
+// @Description Return all positions of the catalogue
+// @Tags positions
+// @Produce json
+// @Success 200 {array} dto.PositionResponse
+// @Router /positions [get]
+func (h *Handler) ListPositions(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListPositions"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing positions")
+
+	resp, err := h.services.Position().List(r.Context())
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "listed positions", "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// GetPosition godoc
+// @Summary Get position
+// @Description Return position of the catalogue
+// @Tags positions
+// @Produce json
+// @Param id path int true "Position ID"
+// @Success 200 {object} dto.PositionResponse
+// @Failure 404 {object} errorResponse
+// @Router /positions/{id} [get]
+func (h *Handler) GetPosition(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetPosition"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting getting position")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrPositionNotFound)
+		return
+	}
+
+	resp, err := h.services.Position().GetByID(r.Context(), id)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "got position", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// UpdatePosition godoc
+// @Summary Update position
+// @Description Update position, renaming also renames position of its employees
+// @Tags positions
+// @Accept json
+// @Produce json
+// @Param id path int true "Position ID"
+// @Param input body dto.UpdatePositionRequest true "New data"
+// @Success 200 {object} dto.PositionResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /positions/{id} [patch]
+func (h *Handler) UpdatePosition(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.UpdatePosition"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting updating position")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrPositionNotFound)
+		return
+	}
+
+	var req dto.UpdatePositionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Position().Update(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "updated position", "id", id)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// DeletePosition godoc
+// @Summary Delete position
+// @Description Delete position which is not held by any employee or vacancy
+// @Tags positions
+// @Param id path int true "Position ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /positions/{id} [delete]
+func (h *Handler) DeletePosition(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.DeletePosition"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting deleting position")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrPositionNotFound)
+		return
+	}
+
+	if err := h.services.Position().Delete(r.Context(), id); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "deleted position", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
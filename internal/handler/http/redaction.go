@@ -0,0 +1,70 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/tmozzze/org_struct_api/internal/config"
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// ScopeAuthorizer - resolve scopes of caller, scopes unlock employee fields hidden by redaction policy
+type ScopeAuthorizer interface {
+	Scopes(r *http.Request) []string
+}
+
+// HeaderScopes - ScopeAuthorizer reading space or comma separated scopes from request header,
+// the header must be set by a trusted gateway which drops it from client requests
+type HeaderScopes struct {
+	Header string
+}
+
+// Scopes - scopes listed in the header
+func (h HeaderScopes) Scopes(r *http.Request) []string {
+	return strings.FieldsFunc(r.Header.Get(h.Header), func(c rune) bool {
+		return c == ',' || c == ' '
+	})
+}
+
+// PrincipalScopes - ScopeAuthorizer reading scopes of authenticated principal, headers sent by clients are ignored
+type PrincipalScopes struct{}
+
+// Scopes - scopes of principal on request context
+func (PrincipalScopes) Scopes(r *http.Request) []string {
+	p, ok := domain.PrincipalFromContext(r.Context())
+	if !ok {
+		return nil
+	}
+	return p.Scopes
+}
+
+// Redactor - hide employee fields of responses from callers without the scopes required by policy
+type Redactor struct {
+	policy     dto.RedactionPolicy
+	authorizer ScopeAuthorizer
+}
+
+// NewRedactor - constructor for Redactor with policy of cfg
+func NewRedactor(cfg config.RedactionCfg, authorizer ScopeAuthorizer) (*Redactor, error) {
+	rules := make(map[string]dto.RedactionRule, len(cfg.Fields))
+	for field, rule := range cfg.Fields {
+		rules[field] = dto.RedactionRule{Scope: rule.Scope, Mode: rule.Mode}
+	}
+
+	policy, err := dto.NewRedactionPolicy(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction policy: %w", err)
+	}
+
+	return &Redactor{policy: policy, authorizer: authorizer}, nil
+}
+
+// For - redaction of the request caller, nil Redactor hides nothing
+func (rd *Redactor) For(r *http.Request) *dto.Redaction {
+	if rd == nil {
+		return nil
+	}
+	return rd.policy.For(rd.authorizer.Scopes(r))
+}
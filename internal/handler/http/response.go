@@ -6,11 +6,14 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/tmozzze/org_struct_api/internal/domain"
 )
 
 type errorResponse struct {
 	Error string `json:"error"`
+	// Conflict - existing entity the request conflicts with
+	Conflict interface{} `json:"conflict,omitempty"`
 }
 
 func renderJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -21,29 +24,75 @@ func renderJSON(w http.ResponseWriter, status int, data interface{}) {
 	}
 }
 
-func handleError(w http.ResponseWriter, log *slog.Logger, op string, err error) {
-	log.Error(op, slog.String("err", err.Error()))
+func handleError(w http.ResponseWriter, r *http.Request, log *slog.Logger, op string, err error) {
+	log.ErrorContext(r.Context(), op, slog.String("err", err.Error()))
 
 	status := http.StatusInternalServerError
 	message := "internal server error"
 
+	// Request payload rejected by validator
+	var validationErrs validator.ValidationErrors
+
 	// Mapping domain errors to HTTP status codes
 	switch {
 	case errors.Is(err, domain.ErrNotFound),
 		errors.Is(err, domain.ErrDepartmentNotFound),
-		errors.Is(err, domain.ErrParentNotFound):
+		errors.Is(err, domain.ErrParentNotFound),
+		errors.Is(err, domain.ErrEmployeeNotFound),
+		errors.Is(err, domain.ErrManagerNotFound),
+		errors.Is(err, domain.ErrAssignmentNotFound),
+		errors.Is(err, domain.ErrPositionNotFound),
+		errors.Is(err, domain.ErrVacancyNotFound),
+		errors.Is(err, domain.ErrAttributeNotFound),
+		errors.Is(err, domain.ErrDepartmentTypeNotFound),
+		errors.Is(err, domain.ErrAPIKeyNotFound),
+		errors.Is(err, domain.ErrGrantNotFound):
 		status = http.StatusNotFound
 		message = err.Error()
 	case errors.Is(err, domain.ErrDuplicateName),
+		errors.Is(err, domain.ErrAlreadyExist),
+		errors.Is(err, domain.ErrDuplicateEmail),
+		errors.Is(err, domain.ErrDuplicateEmployeeNumber),
+		errors.Is(err, domain.ErrDuplicateCostCenter),
+		errors.Is(err, domain.ErrPositionInUse),
+		errors.Is(err, domain.ErrDepartmentTypeInUse),
+		errors.Is(err, domain.ErrVacancyClosed),
+		errors.Is(err, domain.ErrEmployeeTerminated),
+		errors.Is(err, domain.ErrEmployeeNotTerminated),
 		errors.Is(err, domain.ErrCycleConstraint):
 		status = http.StatusConflict
 		message = err.Error()
-	case errors.Is(err, domain.ErrInvalidReassignToID),
+	case errors.As(err, &validationErrs),
+		errors.Is(err, domain.ErrInvalidReassignToID),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrInvalidHead),
+		errors.Is(err, domain.ErrInvalidPrimary),
+		errors.Is(err, domain.ErrInvalidTerminatedAt),
+		errors.Is(err, domain.ErrInvalidRehiredAt),
+		errors.Is(err, domain.ErrInvalidAttribute),
+		errors.Is(err, domain.ErrInvalidChildOrder),
+		errors.Is(err, domain.ErrInvalidExpiresAt),
+		errors.Is(err, domain.ErrAllocationConstraint),
 		errors.Is(err, domain.ErrLengthConstraint),
 		errors.Is(err, domain.ErrEmptyConstraint):
 		status = http.StatusBadRequest
 		message = err.Error()
+	case errors.Is(err, domain.ErrHierarchyRule):
+		status = http.StatusUnprocessableEntity
+		message = err.Error()
+	case errors.Is(err, domain.ErrUnauthenticated):
+		status = http.StatusUnauthorized
+		message = err.Error()
+	case errors.Is(err, domain.ErrForbidden):
+		status = http.StatusForbidden
+		message = err.Error()
+	}
+
+	resp := errorResponse{Error: message}
+	var conflict *domain.ConflictError
+	if status == http.StatusConflict && errors.As(err, &conflict) {
+		resp.Conflict = conflict.Existing
 	}
 
-	renderJSON(w, status, errorResponse{Error: message})
+	renderJSON(w, status, resp)
 }
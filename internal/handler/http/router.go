@@ -16,12 +16,72 @@ func NewRouter(h *Handler) *http.ServeMux {
 
 	// Departments
 	mux.HandleFunc("POST /departments", h.CreateDepartment)
+	mux.HandleFunc("GET /departments/search", h.SearchDepartments)
 	mux.HandleFunc("GET /departments/{id}", h.GetDepartment)
 	mux.HandleFunc("PATCH /departments/{id}", h.UpdateDepartment)
 	mux.HandleFunc("DELETE /departments/{id}", h.DeleteDepartment)
+	mux.HandleFunc("GET /departments/{id}/children", h.ListChildren)
+	mux.HandleFunc("GET /departments/{id}/stats", h.GetDepartmentStats)
+	mux.HandleFunc("GET /departments/{id}/budget", h.GetDepartmentBudget)
+	mux.HandleFunc("PUT /departments/{id}/head", h.SetDepartmentHead)
+	mux.HandleFunc("POST /departments/{id}/reorder", h.ReorderChildren)
 
 	// Employees
 	mux.HandleFunc("POST /departments/{id}/employees", h.CreateEmployee)
+	mux.HandleFunc("GET /departments/{id}/employees", h.ListEmployees)
+	mux.HandleFunc("GET /employees/{id}/manager-chain", h.GetManagerChain)
+	mux.HandleFunc("PUT /employees/{id}/manager", h.SetManager)
+	mux.HandleFunc("GET /employees/{id}/reports", h.ListDirectReports)
+	mux.HandleFunc("GET /employees/{id}/reports/all", h.ListAllReports)
+	mux.HandleFunc("GET /employees/{id}/management-chain", h.GetManagementChain)
+	mux.HandleFunc("PUT /employees/{id}/status", h.SetEmployeeStatus)
+	mux.HandleFunc("POST /employees/{id}/terminate", h.TerminateEmployee)
+	mux.HandleFunc("POST /employees/{id}/rehire", h.RehireEmployee)
+	mux.HandleFunc("PUT /employees/{id}/attributes", h.SetEmployeeAttributes)
+
+	// Assignments
+	mux.HandleFunc("GET /employees/{id}/assignments", h.ListAssignments)
+	mux.HandleFunc("POST /employees/{id}/assignments", h.CreateAssignment)
+	mux.HandleFunc("PATCH /employees/{id}/assignments/{assignment_id}", h.UpdateAssignment)
+	mux.HandleFunc("DELETE /employees/{id}/assignments/{assignment_id}", h.DeleteAssignment)
+
+	// Positions
+	mux.HandleFunc("POST /positions", h.CreatePosition)
+	mux.HandleFunc("GET /positions", h.ListPositions)
+	mux.HandleFunc("GET /positions/{id}", h.GetPosition)
+	mux.HandleFunc("PATCH /positions/{id}", h.UpdatePosition)
+	mux.HandleFunc("DELETE /positions/{id}", h.DeletePosition)
+
+	// Vacancies
+	mux.HandleFunc("GET /departments/{id}/vacancies", h.ListVacancies)
+	mux.HandleFunc("POST /departments/{id}/vacancies", h.CreateVacancy)
+	mux.HandleFunc("PATCH /departments/{id}/vacancies/{vacancy_id}", h.UpdateVacancy)
+	mux.HandleFunc("POST /departments/{id}/vacancies/{vacancy_id}/fill", h.FillVacancy)
+	mux.HandleFunc("GET /departments/{id}/headcount", h.GetHeadcount)
+
+	// Attributes
+	mux.HandleFunc("POST /attributes", h.CreateAttribute)
+	mux.HandleFunc("GET /attributes", h.ListAttributes)
+	mux.HandleFunc("PATCH /attributes/{id}", h.UpdateAttribute)
+	mux.HandleFunc("DELETE /attributes/{id}", h.DeleteAttribute)
+
+	// Department types
+	mux.HandleFunc("GET /department-types", h.ListDepartmentTypes)
+	mux.HandleFunc("PUT /department-types/{name}", h.SaveDepartmentType)
+	mux.HandleFunc("DELETE /department-types/{name}", h.DeleteDepartmentType)
+
+	// Auth
+	mux.HandleFunc("POST /auth/keys", h.CreateAPIKey)
+	mux.HandleFunc("GET /auth/keys", h.ListAPIKeys)
+	mux.HandleFunc("DELETE /auth/keys/{id}", h.RevokeAPIKey)
+
+	// Grants
+	mux.HandleFunc("GET /departments/{id}/grants", h.ListGrants)
+	mux.HandleFunc("PUT /departments/{id}/grants", h.SaveGrant)
+	mux.HandleFunc("DELETE /departments/{id}/grants/{grant_id}", h.DeleteGrant)
+
+	// Reports
+	mux.HandleFunc("GET /reports/span-of-control", h.GetSpanOfControl)
 
 	return mux
 }
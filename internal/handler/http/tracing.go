@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Trace - middleware starting server span of request named by route pattern resolved against mux,
+// parent span is taken from W3C traceparent header, uses global tracer provider and propagator
+func Trace(mux *http.ServeMux) func(http.Handler) http.Handler {
+	route := func(r *http.Request) string {
+		if _, pattern := mux.Handler(r); pattern != "" {
+			return pattern
+		}
+		return unmatchedRoute
+	}
+
+	return func(next http.Handler) http.Handler {
+		withRoute := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			trace.SpanFromContext(r.Context()).SetAttributes(attribute.String("http.route", route(r)))
+			next.ServeHTTP(w, r)
+		})
+		return otelhttp.NewHandler(withRoute, "http.server",
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return route(r)
+			}),
+		)
+	}
+}
@@ -0,0 +1,217 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// CreateVacancy godoc
+// @Summary Create vacancy
+// @Description Open a vacancy for a catalogue position in a department
+// @Tags vacancies
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param input body dto.CreateVacancyRequest true "Vacancy data"
+// @Success 201 {object} dto.VacancyResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/vacancies [post]
+func (h *Handler) CreateVacancy(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CreateVacancy"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting creating vacancy")
+
+	deptID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrDepartmentNotFound)
+		return
+	}
+
+	var req dto.CreateVacancyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Vacancy().Create(r.Context(), deptID, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "created vacancy", "department_id", deptID, "id", resp.ID)
+	renderJSON(w, http.StatusCreated, resp)
+}
+
+// ListVacancies godoc
+// @Summary List vacancies
+// @Description Return vacancies of a department, optionally filtered by status
+// @Tags vacancies
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param status query string false "Vacancy status" Enums(open, filled, cancelled)
+// @Success 200 {array} dto.VacancyResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/vacancies [get]
+func (h *Handler) ListVacancies(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListVacancies"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting listing vacancies")
+
+	deptID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrDepartmentNotFound)
+		return
+	}
+
+	req := dto.ListVacanciesRequest{Status: r.URL.Query().Get("status")}
+
+	resp, err := h.services.Vacancy().List(r.Context(), deptID, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "listed vacancies", "department_id", deptID, "count", len(resp))
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// UpdateVacancy godoc
+// @Summary Update vacancy
+// @Description Update position or target start date of an open vacancy, or cancel it
+// @Tags vacancies
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param vacancy_id path int true "Vacancy ID"
+// @Param input body dto.UpdateVacancyRequest true "New data"
+// @Success 200 {object} dto.VacancyResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /departments/{id}/vacancies/{vacancy_id} [patch]
+func (h *Handler) UpdateVacancy(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.UpdateVacancy"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting updating vacancy")
+
+	deptID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrDepartmentNotFound)
+		return
+	}
+	vacancyID, err := strconv.Atoi(r.PathValue("vacancy_id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrVacancyNotFound)
+		return
+	}
+
+	var req dto.UpdateVacancyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Vacancy().Update(r.Context(), deptID, vacancyID, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "updated vacancy", "department_id", deptID, "vacancy_id", vacancyID)
+	renderJSON(w, http.StatusOK, resp)
+}
+
+// FillVacancy godoc
+// @Summary Fill vacancy
+// @Description Hire an employee into an open vacancy and close it
+// @Tags vacancies
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Param vacancy_id path int true "Vacancy ID"
+// @Param input body dto.FillVacancyRequest true "Employee data"
+// @Success 201 {object} dto.FillVacancyResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /departments/{id}/vacancies/{vacancy_id}/fill [post]
+func (h *Handler) FillVacancy(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.FillVacancy"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting filling vacancy")
+
+	deptID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrDepartmentNotFound)
+		return
+	}
+	vacancyID, err := strconv.Atoi(r.PathValue("vacancy_id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrVacancyNotFound)
+		return
+	}
+
+	var req dto.FillVacancyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	resp, err := h.services.Vacancy().Fill(r.Context(), deptID, vacancyID, &req)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	h.redactor.For(r).Employee(&resp.Employee)
+
+	log.InfoContext(r.Context(), "filled vacancy", "vacancy_id", vacancyID, "employee_id", resp.Employee.ID)
+	renderJSON(w, http.StatusCreated, resp)
+}
+
+// GetHeadcount godoc
+// @Summary Get headcount plan
+// @Description Return actual vs planned headcount of a department and every department of its subtree
+// @Tags vacancies
+// @Produce json
+// @Param id path int true "Department ID"
+// @Success 200 {array} dto.HeadcountResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /departments/{id}/headcount [get]
+func (h *Handler) GetHeadcount(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetHeadcount"
+
+	log := h.log.With(slog.String("op", op))
+	log.DebugContext(r.Context(), "starting getting headcount")
+
+	deptID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		handleError(w, r, h.log, op, domain.ErrDepartmentNotFound)
+		return
+	}
+
+	resp, err := h.services.Vacancy().GetHeadcount(r.Context(), deptID)
+	if err != nil {
+		handleError(w, r, h.log, op, err)
+		return
+	}
+
+	log.InfoContext(r.Context(), "got headcount", "department_id", deptID)
+	renderJSON(w, http.StatusOK, resp)
+}
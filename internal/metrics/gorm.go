@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// startedAtKey - statement instance key with query start time
+const startedAtKey = "metrics:started_at"
+
+// gormPlugin - GORM plugin timing every query with callbacks around GORM processors
+type gormPlugin struct {
+	metrics *Metrics
+}
+
+// GORMPlugin - plugin recording query durations, installed with db.Use
+func (m *Metrics) GORMPlugin() gorm.Plugin {
+	return &gormPlugin{metrics: m}
+}
+
+// Name - name of plugin
+func (p *gormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize - registers timing callbacks of every query kind
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	const op = "metrics.gormPlugin.Initialize"
+
+	cb := db.Callback()
+	err := errors.Join(
+		cb.Create().Before("gorm:create").Register("metrics:before_create", p.before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", p.after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", p.before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", p.after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", p.before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", p.before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", p.before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", p.before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", p.after("raw")),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to register callbacks: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *gormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(startedAtKey, time.Now())
+}
+
+func (p *gormPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(startedAtKey)
+		if !ok {
+			return
+		}
+		startedAt, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+		p.metrics.ObserveQuery(operation, db.Statement.Table, time.Since(startedAt))
+	}
+}
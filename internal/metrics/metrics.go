@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace - prefix of every metric of the service
+const namespace = "org_struct"
+
+// Metrics - prometheus collectors of the service on its own registry
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	queryDuration   *prometheus.HistogramVec
+
+	departments prometheus.Gauge
+	employees   prometheus.Gauge
+	treeDepth   prometheus.Gauge
+}
+
+// New - constructor for Metrics, registers runtime and process collectors too
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by method, route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of HTTP requests by method, route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duration of GORM queries by operation and table.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		departments: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "departments",
+			Help:      "Number of departments.",
+		}),
+		employees: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "employees",
+			Help:      "Number of employees which are not terminated.",
+		}),
+		treeDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "department_tree_max_depth",
+			Help:      "Depth of the deepest department, root departments have depth 0.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.queryDuration,
+		m.departments,
+		m.employees,
+		m.treeDepth,
+	)
+
+	return m
+}
+
+// Handler - serves collected metrics in Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Registry - registry of the collectors
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// RegisterDB - exposes connection pool stats of db from sql.DB.Stats()
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	const op = "metrics.RegisterDB"
+
+	if err := m.registry.Register(collectors.NewDBStatsCollector(db, name)); err != nil {
+		return fmt.Errorf("%s: failed to register pool stats of db '%s': %w", op, name, err)
+	}
+	return nil
+}
+
+// ObserveRequest - records finished HTTP request
+func (m *Metrics) ObserveRequest(method string, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	m.requests.WithLabelValues(method, route, code).Inc()
+	m.requestDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveQuery - records finished database query
+func (m *Metrics) ObserveQuery(operation string, table string, elapsed time.Duration) {
+	m.queryDuration.WithLabelValues(operation, table).Observe(elapsed.Seconds())
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// TotalsSource - source of organization size for business gauges
+type TotalsSource interface {
+	GetTotals(ctx context.Context) (*models.OrgTotals, error)
+}
+
+// RefreshTotals - updates business gauges from source
+func (m *Metrics) RefreshTotals(ctx context.Context, source TotalsSource) error {
+	const op = "metrics.RefreshTotals"
+
+	totals, err := source.GetTotals(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	m.departments.Set(float64(totals.Departments))
+	m.employees.Set(float64(totals.Employees))
+	m.treeDepth.Set(float64(totals.MaxDepth))
+
+	return nil
+}
+
+// RunTotals - refreshes business gauges every interval until ctx is done, failed refresh keeps previous values
+func (m *Metrics) RunTotals(ctx context.Context, source TotalsSource, interval time.Duration, log *slog.Logger) {
+	const op = "metrics.RunTotals"
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.RefreshTotals(ctx, source); err != nil && ctx.Err() == nil {
+			log.WarnContext(ctx, "failed to refresh business metrics", slog.String("op", op), slog.String("err", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
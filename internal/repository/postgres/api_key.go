@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type apiKeyRepo struct {
+	db *gorm.DB
+}
+
+func newAPIKeyRepo(db *gorm.DB) *apiKeyRepo {
+	return &apiKeyRepo{
+		db: db,
+	}
+}
+
+// Create - create a new API key
+func (r *apiKeyRepo) Create(ctx context.Context, key *models.APIKey) error {
+	const op = "postgres.apiKey.Create"
+
+	if err := r.db.WithContext(ctx).Create(key).Error; err != nil {
+		return fmt.Errorf("%s: failed to create api key: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetByHash - get API key by hash of the key
+func (r *apiKeyRepo) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	const op = "postgres.apiKey.GetByHash"
+
+	var key models.APIKey
+	if err := r.db.WithContext(ctx).Where("key_hash = ?", hash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: failed to get api key: %w", op, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get api key: %w", op, err)
+	}
+
+	return &key, nil
+}
+
+// List - list all API keys, newest first
+func (r *apiKeyRepo) List(ctx context.Context) ([]models.APIKey, error) {
+	const op = "postgres.apiKey.List"
+
+	var keys []models.APIKey
+	if err := r.db.WithContext(ctx).Order("created_at DESC, id DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to list api keys: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// Revoke - revoke API key, revoking twice keeps the first revocation time
+func (r *apiKeyRepo) Revoke(ctx context.Context, id int, at time.Time) error {
+	const op = "postgres.apiKey.Revoke"
+
+	result := r.db.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ?", id).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", at))
+
+	if result.Error != nil {
+		return fmt.Errorf("%s: failed to revoke api key id: %d: %w", op, id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: failed to revoke api key id: %d: %w", op, id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Touch - set last use of API key unless it was set less than a minute ago
+func (r *apiKeyRepo) Touch(ctx context.Context, id int, at time.Time) error {
+	const op = "postgres.apiKey.Touch"
+
+	err := r.db.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-time.Minute)).
+		Update("last_used_at", at).Error
+	if err != nil {
+		return fmt.Errorf("%s: failed to touch api key id: %d: %w", op, id, err)
+	}
+
+	return nil
+}
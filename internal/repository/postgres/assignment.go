@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type assignmentRepo struct {
+	db *gorm.DB
+}
+
+func newAssignmentRepo(db *gorm.DB) *assignmentRepo {
+	return &assignmentRepo{
+		db: db,
+	}
+}
+
+// Create - create a new employee assignment
+func (r *assignmentRepo) Create(ctx context.Context, a *models.EmployeeAssignment) error {
+	const op = "postgres.assignment.Create"
+
+	result := r.db.WithContext(ctx).Create(a)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("%s: failed to create assignment: %w", op, domain.ErrAlreadyExist)
+		}
+		return fmt.Errorf("%s: failed to create assignment: %w", op, result.Error)
+	}
+
+	return nil
+}
+
+// GetByID - get assignment by ID
+func (r *assignmentRepo) GetByID(ctx context.Context, id int) (*models.EmployeeAssignment, error) {
+	const op = "postgres.assignment.GetByID"
+
+	var a models.EmployeeAssignment
+	if err := r.db.WithContext(ctx).First(&a, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: failed to get assignment id: %d: %w", op, id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get assignment id: %d: %w", op, id, err)
+	}
+
+	return &a, nil
+}
+
+// ListByEmployee - list assignments of employee, primary first
+func (r *assignmentRepo) ListByEmployee(ctx context.Context, employeeID int) ([]models.EmployeeAssignment, error) {
+	const op = "postgres.assignment.ListByEmployee"
+
+	var assignments []models.EmployeeAssignment
+	err := r.db.WithContext(ctx).
+		Where("employee_id = ?", employeeID).
+		Order("is_primary DESC, allocation_percent DESC, id ASC").
+		Find(&assignments).Error
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list assignments of employee id: %d: %w", op, employeeID, err)
+	}
+
+	return assignments, nil
+}
+
+// Update - update assignment
+func (r *assignmentRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	const op = "postgres.assignment.Update"
+
+	result := r.db.WithContext(ctx).
+		Model(&models.EmployeeAssignment{}).
+		Where("id = ?", id).
+		Updates(updates)
+
+	if result.Error != nil {
+		return fmt.Errorf("%s: failed to update assignment id: %d: %w", op, id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: failed to update assignment id: %d: %w", op, id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete - delete assignment
+func (r *assignmentRepo) Delete(ctx context.Context, id int) error {
+	const op = "postgres.assignment.Delete"
+
+	result := r.db.WithContext(ctx).Delete(&models.EmployeeAssignment{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("%s: failed to delete assignment id: %d: %w", op, id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: failed to delete assignment id: %d: %w", op, id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SumAllocation - sum of employee allocations, excluding assignment excludeID
+func (r *assignmentRepo) SumAllocation(ctx context.Context, employeeID int, excludeID int) (int, error) {
+	const op = "postgres.assignment.SumAllocation"
+
+	var sum int
+	err := r.db.WithContext(ctx).
+		Model(&models.EmployeeAssignment{}).
+		Select("COALESCE(SUM(allocation_percent), 0)").
+		Where("employee_id = ? AND id <> ?", employeeID, excludeID).
+		Scan(&sum).Error
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to sum allocations of employee id: %d: %w", op, employeeID, err)
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type attributeRepo struct {
+	db *gorm.DB
+}
+
+func newAttributeRepo(db *gorm.DB) *attributeRepo {
+	return &attributeRepo{
+		db: db,
+	}
+}
+
+// Create - create a new attribute definition
+func (r *attributeRepo) Create(ctx context.Context, def *models.AttributeDefinition) error {
+	const op = "postgres.attribute.Create"
+
+	result := r.db.WithContext(ctx).Create(def)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("%s: failed to create attribute: %w", op, domain.ErrAlreadyExist)
+		}
+		return fmt.Errorf("%s: failed to create attribute: %w", op, result.Error)
+	}
+
+	return nil
+}
+
+// GetByID - get attribute definition by ID
+func (r *attributeRepo) GetByID(ctx context.Context, id int) (*models.AttributeDefinition, error) {
+	const op = "postgres.attribute.GetByID"
+
+	var def models.AttributeDefinition
+	if err := r.db.WithContext(ctx).First(&def, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: failed to get attribute id: %d: %w", op, id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get attribute id: %d: %w", op, id, err)
+	}
+
+	return &def, nil
+}
+
+// GetByName - get attribute definition of entity type by name
+func (r *attributeRepo) GetByName(ctx context.Context, entityType string, name string) (*models.AttributeDefinition, error) {
+	const op = "postgres.attribute.GetByName"
+
+	var def models.AttributeDefinition
+	err := r.db.WithContext(ctx).
+		Where("entity_type = ? AND name = ?", entityType, name).
+		First(&def).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // not err - just found nothing
+		}
+		return nil, fmt.Errorf("%s: failed to get attribute by name: %w", op, err)
+	}
+
+	return &def, nil
+}
+
+// List - list attribute definitions of entity type sorted by name, empty entity type lists all
+func (r *attributeRepo) List(ctx context.Context, entityType string) ([]models.AttributeDefinition, error) {
+	const op = "postgres.attribute.List"
+
+	query := r.db.WithContext(ctx)
+	if entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+
+	var defs []models.AttributeDefinition
+	if err := query.Order("entity_type ASC, name ASC").Find(&defs).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to list attributes: %w", op, err)
+	}
+
+	return defs, nil
+}
+
+// Update - update attribute definition
+func (r *attributeRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	const op = "postgres.attribute.Update"
+
+	result := r.db.WithContext(ctx).
+		Model(&models.AttributeDefinition{}).
+		Where("id = ?", id).
+		Updates(updates)
+
+	if result.Error != nil {
+		return fmt.Errorf("%s: failed to update attribute id: %d: %w", op, id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: failed to update attribute id: %d: %w", op, id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete - delete attribute definition and remove its values from departments or employees
+func (r *attributeRepo) Delete(ctx context.Context, id int) error {
+	const op = "postgres.attribute.Delete"
+
+	// Start transaction
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var def models.AttributeDefinition
+		if err := tx.First(&def, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%s: failed to get attribute id: %d: %w", op, id, domain.ErrNotFound)
+			}
+			return fmt.Errorf("%s: failed to get attribute id: %d: %w", op, id, err)
+		}
+
+		var entity interface{} = &models.Employee{}
+		if def.EntityType == domain.AttributeEntityDepartment {
+			entity = &models.Department{}
+		}
+
+		if err := tx.Model(entity).
+			Where("attributes -> ? IS NOT NULL", def.Name).
+			Update("attributes", gorm.Expr("attributes - ?", def.Name)).Error; err != nil {
+			return fmt.Errorf("%s: failed to remove values of attribute id: %d: %w", op, id, err)
+		}
+
+		if err := tx.Delete(&def).Error; err != nil {
+			return fmt.Errorf("%s: failed to delete attribute id: %d: %w", op, id, err)
+		}
+
+		return nil
+	})
+}
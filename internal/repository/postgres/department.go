@@ -2,12 +2,16 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/tmozzze/org_struct_api/internal/domain"
 	"github.com/tmozzze/org_struct_api/internal/domain/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type departmentRepo struct {
@@ -26,42 +30,48 @@ func (r *departmentRepo) Create(ctx context.Context, dept *models.Department) er
 
 	result := r.db.WithContext(ctx).Create(dept)
 	if result.Error != nil {
+		// Name or cost center taken concurrently
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("%s: failed to create department: %w", op, domain.ErrAlreadyExist)
+		}
 		return fmt.Errorf("%s: failed to create department: %w", op, result.Error)
 	}
 
 	return nil
 }
 
-// GetByID - get department by ID with optional depth and employees
-func (r *departmentRepo) GetByID(ctx context.Context, id int, depth int, includeEmployees bool) (*models.Department, error) {
+// GetByID - get department by ID with optional depth, employees and per-department limits
+func (r *departmentRepo) GetByID(ctx context.Context, id int, opts domain.TreeOptions) (*models.Department, error) {
 	const op = "postgres.department.GetByID"
 
 	var dept models.Department
-	query := r.db.WithContext(ctx)
+	query := r.db.WithContext(ctx).Preload("Head")
 
-	if includeEmployees {
-		// Sorting employees by full name
-		query = query.Preload("Employees", func(db *gorm.DB) *gorm.DB {
-			return db.Order("full_name ASC")
-		})
+	if opts.IncludeEmployees {
+		query = query.Preload("Employees", preloadEmployees(opts.EmployeesLimit, opts.IncludeTerminated))
+	}
+	if opts.IncludeSecondary {
+		query = query.Preload("Assignments", preloadSecondary(opts.IncludeTerminated)).Preload("Assignments.Employee")
 	}
 
 	// Children
 	currentPath := ""
-	for i := 0; i < depth; i++ {
+	for i := 0; i < opts.Depth; i++ {
 		if currentPath == "" {
 			currentPath = "Children"
 		} else {
 			currentPath += ".Children"
 		}
 
-		query = query.Preload(currentPath)
+		query = query.Preload(currentPath, preloadChildren(opts.ChildrenLimit))
+		query = query.Preload(currentPath + ".Head")
 
 		// Employees for all children
-		if includeEmployees {
-			query = query.Preload(currentPath+".Employees", func(db *gorm.DB) *gorm.DB {
-				return db.Order("full_name ASC")
-			})
+		if opts.IncludeEmployees {
+			query = query.Preload(currentPath+".Employees", preloadEmployees(opts.EmployeesLimit, opts.IncludeTerminated))
+		}
+		if opts.IncludeSecondary {
+			query = query.Preload(currentPath+".Assignments", preloadSecondary(opts.IncludeTerminated)).Preload(currentPath + ".Assignments.Employee")
 		}
 	}
 
@@ -77,6 +87,76 @@ func (r *departmentRepo) GetByID(ctx context.Context, id int, depth int, include
 	return &dept, nil
 }
 
+// childrenOrder - sibling order of departments in every tree and list
+const childrenOrder = "sort_order ASC, name ASC, id ASC"
+
+// preloadChildren - sort children by sort order and name and load at most limit+1 per parent (0 - no limit)
+func preloadChildren(limit int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Order(childrenOrder)
+		if limit <= 0 {
+			return db
+		}
+		return db.Where(`departments.id IN (
+			SELECT c.id FROM departments c
+			WHERE c.parent_id = departments.parent_id
+			ORDER BY c.sort_order ASC, c.name ASC, c.id ASC
+			LIMIT ?)`, limit+1)
+	}
+}
+
+// preloadEmployees - sort employees by full name and load at most limit+1 per department (0 - no limit),
+// terminated employees are skipped unless includeTerminated
+func preloadEmployees(limit int, includeTerminated bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Order("full_name ASC, id ASC")
+		if !includeTerminated {
+			db = db.Where("employees.status <> ?", domain.EmployeeStatusTerminated)
+		}
+		if limit <= 0 {
+			return db
+		}
+		return db.Where(`employees.id IN (
+			SELECT e.id FROM employees e
+			WHERE e.department_id = employees.department_id
+			AND (? OR e.status <> ?)
+			ORDER BY e.full_name ASC, e.id ASC
+			LIMIT ?)`, includeTerminated, domain.EmployeeStatusTerminated, limit+1)
+	}
+}
+
+// preloadSecondary - load only secondary assignments sorted by allocation,
+// assignments of terminated employees are skipped unless includeTerminated
+func preloadSecondary(includeTerminated bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("is_primary = ?", false).Order("allocation_percent DESC, id ASC")
+		if !includeTerminated {
+			db = db.Where("employee_id IN (SELECT id FROM employees WHERE status <> ?)", domain.EmployeeStatusTerminated)
+		}
+		return db
+	}
+}
+
+// ListChildren - list filtered direct children of department sorted by sort order and name, starting after cursor
+func (r *departmentRepo) ListChildren(ctx context.Context, parentID int, filter domain.DepartmentFilter, after *models.Cursor, limit int) ([]models.Department, error) {
+	const op = "postgres.department.ListChildren"
+
+	query := r.db.WithContext(ctx).Where("parent_id = ?", parentID)
+	if len(filter.Attributes) > 0 {
+		query = query.Where("attributes @> ?", filter.Attributes)
+	}
+	if after != nil {
+		query = query.Where("(sort_order, name, id) > (?, ?, ?)", after.Order, after.Key, after.ID)
+	}
+
+	var children []models.Department
+	if err := query.Order(childrenOrder).Limit(limit).Find(&children).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to list children of department id: %d: %w", op, parentID, err)
+	}
+
+	return children, nil
+}
+
 // Update - update department
 func (r *departmentRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
 	const op = "postgres.department.Update"
@@ -87,6 +167,10 @@ func (r *departmentRepo) Update(ctx context.Context, id int, updates map[string]
 		Updates(updates)
 
 	if result.Error != nil {
+		// Name or cost center taken concurrently
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("%s: failed to update department id: %d: %w", op, id, domain.ErrAlreadyExist)
+		}
 		return fmt.Errorf("%s: failed to update department id: %d: %w", op, id, result.Error)
 	}
 
@@ -119,6 +203,27 @@ func (r *departmentRepo) DeleteWithReassign(ctx context.Context, id int, reassig
 
 	// Start transaction
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Secondary assignments to new department become home department of moved employees
+		if err := tx.Where("department_id = ? AND is_primary = ? AND employee_id IN (?)", reassignToID, false,
+			tx.Model(&models.Employee{}).Select("id").Where("department_id = ?", id),
+		).Delete(&models.EmployeeAssignment{}).Error; err != nil {
+			return fmt.Errorf("%s: failed to drop secondary assignments to department id: %d: %w", op, reassignToID, err)
+		}
+
+		// Primary assignments follow employees
+		if err := tx.Model(&models.EmployeeAssignment{}).
+			Where("department_id = ? AND is_primary = ?", id, true).
+			Update("department_id", reassignToID).Error; err != nil {
+			return fmt.Errorf("%s: failed to reassign primary assignments id: %d: %w", op, id, err)
+		}
+
+		// Vacancies follow employees
+		if err := tx.Model(&models.Vacancy{}).
+			Where("department_id = ?", id).
+			Update("department_id", reassignToID).Error; err != nil {
+			return fmt.Errorf("%s: failed to reassign vacancies id: %d: %w", op, id, err)
+		}
+
 		// Update employees to new department
 		if err := tx.Model(&models.Employee{}).
 			Where("department_id = ?", id).
@@ -141,12 +246,104 @@ func (r *departmentRepo) DeleteWithReassign(ctx context.Context, id int, reassig
 
 }
 
-// GetByNameAndParent - get department by name and parent
+// UnsetInvalidHeads - unset heads of departments in subtree of department or headed by its employees,
+// when head works outside the department and its ancestors
+func (r *departmentRepo) UnsetInvalidHeads(ctx context.Context, id int) error {
+	const op = "postgres.department.UnsetInvalidHeads"
+
+	const unsetSQL = `
+WITH RECURSIVE scope AS (
+	SELECT d.id
+	FROM departments d
+	WHERE d.id = @id
+		OR d.head_employee_id IN (SELECT e.id FROM employees e WHERE e.department_id = @id)
+	UNION
+	SELECT d.id
+	FROM scope s
+	JOIN departments d ON d.parent_id = s.id
+), chain AS (
+	SELECT s.id AS department_id, d.id AS ancestor_id, d.parent_id
+	FROM scope s
+	JOIN departments d ON d.id = s.id
+	UNION ALL
+	SELECT c.department_id, p.id, p.parent_id
+	FROM chain c
+	JOIN departments p ON p.id = c.parent_id
+)
+UPDATE departments d SET head_employee_id = NULL
+FROM employees e
+WHERE e.id = d.head_employee_id
+	AND d.id IN (SELECT id FROM scope)
+	AND NOT EXISTS (SELECT 1 FROM chain c WHERE c.department_id = d.id AND c.ancestor_id = e.department_id)`
+
+	if err := r.db.WithContext(ctx).Exec(unsetSQL, sql.Named("id", id)).Error; err != nil {
+		return fmt.Errorf("%s: failed to unset invalid heads of department id: %d: %w", op, id, err)
+	}
+
+	return nil
+}
+
+// NextSortOrder - get sort order after the last child of parent, root departments when parentID is nil
+func (r *departmentRepo) NextSortOrder(ctx context.Context, parentID *int) (int, error) {
+	const op = "postgres.department.NextSortOrder"
+
+	query := r.db.WithContext(ctx).Model(&models.Department{}).Select("COALESCE(MAX(sort_order), 0) + 1")
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	var next int
+	if err := query.Scan(&next).Error; err != nil {
+		return 0, fmt.Errorf("%s: failed to get next sort order: %w", op, err)
+	}
+
+	return next, nil
+}
+
+// Reorder - set sort order of direct children of parent to their position in childIDs,
+// childIDs must contain every child of parent
+func (r *departmentRepo) Reorder(ctx context.Context, parentID int, childIDs []int) error {
+	const op = "postgres.department.Reorder"
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock children so none is added or moved away while reordering
+		var ids []int
+		if err := tx.Model(&models.Department{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("parent_id = ?", parentID).
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("%s: failed to lock children of department id: %d: %w", op, parentID, err)
+		}
+
+		children := make(map[int]struct{}, len(ids))
+		for _, id := range ids {
+			children[id] = struct{}{}
+		}
+		if len(childIDs) != len(children) {
+			return fmt.Errorf("%s: department id: %d has %d children, got %d: %w", op, parentID, len(children), len(childIDs), domain.ErrInvalidChildOrder)
+		}
+
+		for i, id := range childIDs {
+			if _, ok := children[id]; !ok {
+				return fmt.Errorf("%s: department id: %d is not a child of department id: %d: %w", op, id, parentID, domain.ErrInvalidChildOrder)
+			}
+			if err := tx.Model(&models.Department{}).Where("id = ?", id).Update("sort_order", i+1).Error; err != nil {
+				return fmt.Errorf("%s: failed to set sort order of department id: %d: %w", op, id, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetByNameAndParent - get department by case-insensitive name and parent
 func (r *departmentRepo) GetByNameAndParent(ctx context.Context, name string, parentID *int) (*models.Department, error) {
 	const op = "postgres.department.GetByNameAndParent"
 
 	var dept models.Department
-	query := r.db.WithContext(ctx).Where("name = ?", name)
+	query := r.db.WithContext(ctx).Where("lower(name) = lower(?)", name)
 
 	if parentID == nil {
 		query = query.Where("parent_id IS NULL")
@@ -165,6 +362,22 @@ func (r *departmentRepo) GetByNameAndParent(ctx context.Context, name string, pa
 	return &dept, nil
 }
 
+// GetByCostCenter - get department by cost center code
+func (r *departmentRepo) GetByCostCenter(ctx context.Context, costCenter string) (*models.Department, error) {
+	const op = "postgres.department.GetByCostCenter"
+
+	var dept models.Department
+	err := r.db.WithContext(ctx).Where("cost_center = ?", costCenter).First(&dept).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // not err - just found nothing
+		}
+		return nil, fmt.Errorf("%s: failed to get department by cost center: %w", op, err)
+	}
+
+	return &dept, nil
+}
+
 // GetByIDSimple - get department by ID without children and employees
 func (r *departmentRepo) GetByIDSimple(ctx context.Context, id int) (*models.Department, error) {
 	const op = "postgres.department.GetByIDSimple"
@@ -190,3 +403,108 @@ func (r *departmentRepo) Exists(ctx context.Context, id int) (bool, error) {
 	}
 	return count > 0, nil
 }
+
+// searchRow - raw row of department search query
+type searchRow struct {
+	models.Department
+	Path  string
+	Score float64
+}
+
+// likeEscaper - escape LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Search - find departments by name prefix or trigram similarity, ignoring case and accents
+func (r *departmentRepo) Search(ctx context.Context, query string, limit int) ([]models.DepartmentSearchResult, error) {
+	const op = "postgres.department.Search"
+
+	const searchSQL = `
+WITH RECURSIVE matches AS (
+	SELECT d.id, d.name, d.parent_id, d.created_at,
+		f_unaccent(lower(d.name)) LIKE f_unaccent(lower(@prefix)) || '%' AS is_prefix,
+		similarity(f_unaccent(lower(d.name)), f_unaccent(lower(@query))) AS score
+	FROM departments d
+	WHERE f_unaccent(lower(d.name)) LIKE f_unaccent(lower(@prefix)) || '%'
+		OR f_unaccent(lower(d.name)) % f_unaccent(lower(@query))
+	ORDER BY is_prefix DESC, score DESC, d.name ASC, d.id ASC
+	LIMIT @limit
+), ancestors AS (
+	SELECT m.id AS match_id, d.id, d.name, d.parent_id, 0 AS lvl
+	FROM matches m
+	JOIN departments d ON d.id = m.parent_id
+	UNION ALL
+	SELECT a.match_id, d.id, d.name, d.parent_id, a.lvl + 1
+	FROM ancestors a
+	JOIN departments d ON d.id = a.parent_id
+)
+SELECT m.id, m.name, m.parent_id, m.created_at, m.score,
+	COALESCE((
+		SELECT json_agg(json_build_object('id', a.id, 'name', a.name) ORDER BY a.lvl DESC)
+		FROM ancestors a
+		WHERE a.match_id = m.id
+	), '[]') AS path
+FROM matches m
+ORDER BY m.is_prefix DESC, m.score DESC, m.name ASC, m.id ASC`
+
+	var rows []searchRow
+	err := r.db.WithContext(ctx).Raw(searchSQL,
+		sql.Named("prefix", likeEscaper.Replace(query)),
+		sql.Named("query", query),
+		sql.Named("limit", limit),
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to search departments by query: %q: %w", op, query, err)
+	}
+
+	results := make([]models.DepartmentSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = models.DepartmentSearchResult{Department: row.Department, Score: row.Score}
+		if err := json.Unmarshal([]byte(row.Path), &results[i].Path); err != nil {
+			return nil, fmt.Errorf("%s: failed to decode path of department id: %d: %w", op, row.ID, err)
+		}
+	}
+
+	return results, nil
+}
+
+// GetAncestors - get department and all its ancestors with heads, ordered from department up to root
+func (r *departmentRepo) GetAncestors(ctx context.Context, id int) ([]models.Department, error) {
+	const op = "postgres.department.GetAncestors"
+
+	const ancestorsSQL = `
+WITH RECURSIVE ancestors AS (
+	SELECT d.id, d.parent_id, 0 AS lvl
+	FROM departments d
+	WHERE d.id = ?
+	UNION ALL
+	SELECT p.id, p.parent_id, a.lvl + 1
+	FROM ancestors a
+	JOIN departments p ON p.id = a.parent_id
+)
+SELECT id FROM ancestors ORDER BY lvl ASC`
+
+	var ids []int
+	if err := r.db.WithContext(ctx).Raw(ancestorsSQL, id).Scan(&ids).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to get ancestors of department id: %d: %w", op, id, err)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%s: failed to get ancestors of department id: %d: %w", op, id, domain.ErrNotFound)
+	}
+
+	var depts []models.Department
+	if err := r.db.WithContext(ctx).Preload("Head").Find(&depts, ids).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to load ancestors of department id: %d: %w", op, id, err)
+	}
+
+	// Restore order from department up to root
+	byID := make(map[int]models.Department, len(depts))
+	for _, d := range depts {
+		byID[d.ID] = d
+	}
+	ancestors := make([]models.Department, 0, len(ids))
+	for _, ancestorID := range ids {
+		ancestors = append(ancestors, byID[ancestorID])
+	}
+
+	return ancestors, nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type departmentTypeRepo struct {
+	db *gorm.DB
+}
+
+func newDepartmentTypeRepo(db *gorm.DB) *departmentTypeRepo {
+	return &departmentTypeRepo{
+		db: db,
+	}
+}
+
+// preloadRules - load allowed children sorted by type
+func preloadRules(db *gorm.DB) *gorm.DB {
+	return db.Order("child_type ASC")
+}
+
+// GetByName - get department type with its allowed children
+func (r *departmentTypeRepo) GetByName(ctx context.Context, name string) (*models.DepartmentType, error) {
+	const op = "postgres.departmentType.GetByName"
+
+	var t models.DepartmentType
+	if err := r.db.WithContext(ctx).Preload("Rules", preloadRules).Where("name = ?", name).First(&t).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: failed to get department type '%s': %w", op, name, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get department type '%s': %w", op, name, err)
+	}
+
+	return &t, nil
+}
+
+// List - list department types with their allowed children sorted by name
+func (r *departmentTypeRepo) List(ctx context.Context) ([]models.DepartmentType, error) {
+	const op = "postgres.departmentType.List"
+
+	var types []models.DepartmentType
+	if err := r.db.WithContext(ctx).Preload("Rules", preloadRules).Order("name ASC").Find(&types).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to list department types: %w", op, err)
+	}
+
+	return types, nil
+}
+
+// Save - create or replace department type and its allowed children
+func (r *departmentTypeRepo) Save(ctx context.Context, t *models.DepartmentType) error {
+	const op = "postgres.departmentType.Save"
+
+	// Start transaction
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Rules").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"root_allowed", "max_depth", "max_children"}),
+		}).Create(t).Error; err != nil {
+			return fmt.Errorf("%s: failed to save department type '%s': %w", op, t.Name, err)
+		}
+
+		if err := tx.Where("parent_type = ?", t.Name).Delete(&models.DepartmentTypeRule{}).Error; err != nil {
+			return fmt.Errorf("%s: failed to drop rules of department type '%s': %w", op, t.Name, err)
+		}
+
+		if len(t.Rules) == 0 {
+			return nil
+		}
+		if err := tx.Create(&t.Rules).Error; err != nil {
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				return fmt.Errorf("%s: failed to save rules of department type '%s': %w", op, t.Name, domain.ErrDepartmentTypeNotFound)
+			}
+			return fmt.Errorf("%s: failed to save rules of department type '%s': %w", op, t.Name, err)
+		}
+
+		return nil
+	})
+}
+
+// Delete - delete department type, rules with it are deleted too
+func (r *departmentTypeRepo) Delete(ctx context.Context, name string) error {
+	const op = "postgres.departmentType.Delete"
+
+	result := r.db.WithContext(ctx).Where("name = ?", name).Delete(&models.DepartmentType{})
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+			return fmt.Errorf("%s: failed to delete department type '%s': %w", op, name, domain.ErrDepartmentTypeInUse)
+		}
+		return fmt.Errorf("%s: failed to delete department type '%s': %w", op, name, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: failed to delete department type '%s': %w", op, name, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// IsUsed - check if any department has the type
+func (r *departmentTypeRepo) IsUsed(ctx context.Context, name string) (bool, error) {
+	const op = "postgres.departmentType.IsUsed"
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Department{}).Where("type = ?", name).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to check usage of department type '%s': %w", op, name, err)
+	}
+	return count > 0, nil
+}
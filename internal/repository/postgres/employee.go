@@ -2,10 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/tmozzze/org_struct_api/internal/domain"
 	"github.com/tmozzze/org_struct_api/internal/domain/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type employeeRepo struct {
@@ -24,12 +28,120 @@ func (r *employeeRepo) Create(ctx context.Context, emp *models.Employee) error {
 
 	result := r.db.WithContext(ctx).Create(emp)
 	if result.Error != nil {
+		// Email or employee number taken concurrently
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("%s: failed to create employee: %w", op, domain.ErrAlreadyExist)
+		}
 		return fmt.Errorf("%s: failed to create employee: %w", op, result.Error)
 	}
 
 	return nil
 }
 
+// GetByID - get employee by ID
+func (r *employeeRepo) GetByID(ctx context.Context, id int) (*models.Employee, error) {
+	const op = "postgres.employee.GetByID"
+
+	var emp models.Employee
+	if err := r.db.WithContext(ctx).First(&emp, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: failed to get employee id: %d: %w", op, id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get employee id: %d: %w", op, id, err)
+	}
+
+	return &emp, nil
+}
+
+// GetByEmail - get employee by email ignoring case, nil if not found
+func (r *employeeRepo) GetByEmail(ctx context.Context, email string) (*models.Employee, error) {
+	const op = "postgres.employee.GetByEmail"
+
+	var emp models.Employee
+	if err := r.db.WithContext(ctx).Where("lower(email) = lower(?)", email).First(&emp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // not err - just found nothing
+		}
+		return nil, fmt.Errorf("%s: failed to get employee by email: %w", op, err)
+	}
+
+	return &emp, nil
+}
+
+// GetByNumber - get employee by employee number, nil if not found
+func (r *employeeRepo) GetByNumber(ctx context.Context, number string) (*models.Employee, error) {
+	const op = "postgres.employee.GetByNumber"
+
+	var emp models.Employee
+	if err := r.db.WithContext(ctx).Where("employee_number = ?", number).First(&emp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // not err - just found nothing
+		}
+		return nil, fmt.Errorf("%s: failed to get employee by number: %w", op, err)
+	}
+
+	return &emp, nil
+}
+
+// NextNumber - next value of the employee number sequence
+func (r *employeeRepo) NextNumber(ctx context.Context) (int64, error) {
+	const op = "postgres.employee.NextNumber"
+
+	var next int64
+	if err := r.db.WithContext(ctx).Raw("SELECT nextval('employee_number_seq')").Scan(&next).Error; err != nil {
+		return 0, fmt.Errorf("%s: failed to get next employee number: %w", op, err)
+	}
+
+	return next, nil
+}
+
+// Update - update employee
+func (r *employeeRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	const op = "postgres.employee.Update"
+
+	result := r.db.WithContext(ctx).
+		Model(&models.Employee{}).
+		Where("id = ?", id).
+		Updates(updates)
+
+	if result.Error != nil {
+		return fmt.Errorf("%s: failed to update employee id: %d: %w", op, id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: failed to update employee id: %d: %w", op, id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Exists - check if employee exists by ID
+func (r *employeeRepo) Exists(ctx context.Context, id int) (bool, error) {
+	const op = "postgres.employee.Exists"
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Employee{}).Where("id = ?", id).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to check existence id: %d: %w", op, id, err)
+	}
+	return count > 0, nil
+}
+
+// Lock - lock employee row until the end of transaction, serializes changes of its assignments
+func (r *employeeRepo) Lock(ctx context.Context, id int) error {
+	const op = "postgres.employee.Lock"
+
+	var emp models.Employee
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&emp, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%s: failed to lock employee id: %d: %w", op, id, domain.ErrNotFound)
+		}
+		return fmt.Errorf("%s: failed to lock employee id: %d: %w", op, id, err)
+	}
+
+	return nil
+}
+
 // UpdateDepartmentForEmployees - update department for all employees in oldDeptID to newDeptID
 func (r *employeeRepo) UpdateDepartmentForEmployees(ctx context.Context, oldDeptID int, newDeptID int) error {
 	const op = "postgres.employee.UpdateDepartmentForEmployees"
@@ -44,3 +156,161 @@ func (r *employeeRepo) UpdateDepartmentForEmployees(ctx context.Context, oldDept
 
 	return nil
 }
+
+// ListByDepartment - list filtered employees of department sorted by full name, starting after cursor
+func (r *employeeRepo) ListByDepartment(ctx context.Context, deptID int, filter domain.EmployeeFilter, after *models.Cursor, limit int) ([]models.Employee, error) {
+	const op = "postgres.employee.ListByDepartment"
+
+	query := r.db.WithContext(ctx).Where("department_id = ?", deptID)
+	if filter.PositionID != nil {
+		query = query.Where("position_id = ?", *filter.PositionID)
+	}
+	if !filter.IncludeTerminated {
+		query = query.Where("status <> ?", domain.EmployeeStatusTerminated)
+	}
+	if len(filter.Attributes) > 0 {
+		query = query.Where("attributes @> ?", filter.Attributes)
+	}
+	if after != nil {
+		query = query.Where("(full_name, id) > (?, ?)", after.Key, after.ID)
+	}
+
+	var employees []models.Employee
+	if err := query.Order("full_name ASC, id ASC").Limit(limit).Find(&employees).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to list employees of department id: %d: %w", op, deptID, err)
+	}
+
+	return employees, nil
+}
+
+// Terminate - terminate employee, their reports move to their manager and they stop heading departments
+func (r *employeeRepo) Terminate(ctx context.Context, id int, terminatedAt time.Time) error {
+	const op = "postgres.employee.Terminate"
+
+	// Start transaction
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var emp models.Employee
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&emp, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%s: failed to get employee id: %d: %w", op, id, domain.ErrNotFound)
+			}
+			return fmt.Errorf("%s: failed to get employee id: %d: %w", op, id, err)
+		}
+
+		// Reports skip terminated manager
+		if err := tx.Model(&models.Employee{}).
+			Where("manager_id = ?", id).
+			Update("manager_id", emp.ManagerID).Error; err != nil {
+			return fmt.Errorf("%s: failed to reassign reports of employee id: %d: %w", op, id, err)
+		}
+
+		// Departments lose their head
+		if err := tx.Model(&models.Department{}).
+			Where("head_employee_id = ?", id).
+			Update("head_employee_id", nil).Error; err != nil {
+			return fmt.Errorf("%s: failed to unset head employee id: %d: %w", op, id, err)
+		}
+
+		if err := tx.Model(&models.Employee{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"status":        domain.EmployeeStatusTerminated,
+				"terminated_at": terminatedAt,
+				"manager_id":    nil,
+			}).Error; err != nil {
+			return fmt.Errorf("%s: failed to terminate employee id: %d: %w", op, id, err)
+		}
+
+		return nil
+	})
+}
+
+// ListDirectReports - list employees reporting directly to manager sorted by full name
+func (r *employeeRepo) ListDirectReports(ctx context.Context, managerID int) ([]models.Employee, error) {
+	const op = "postgres.employee.ListDirectReports"
+
+	var employees []models.Employee
+	err := r.db.WithContext(ctx).
+		Where("manager_id = ?", managerID).
+		Order("full_name ASC, id ASC").
+		Find(&employees).Error
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list direct reports of employee id: %d: %w", op, managerID, err)
+	}
+
+	return employees, nil
+}
+
+// ListAllReports - list all employees reporting to manager directly or indirectly, level by level
+func (r *employeeRepo) ListAllReports(ctx context.Context, managerID int) ([]models.Employee, error) {
+	const op = "postgres.employee.ListAllReports"
+
+	const reportsSQL = `
+WITH RECURSIVE reports AS (
+	SELECT e.id, 1 AS lvl
+	FROM employees e
+	WHERE e.manager_id = ?
+	UNION ALL
+	SELECT e.id, r.lvl + 1
+	FROM reports r
+	JOIN employees e ON e.manager_id = r.id
+)
+SELECT e.*
+FROM reports r
+JOIN employees e ON e.id = r.id
+ORDER BY r.lvl ASC, e.full_name ASC, e.id ASC`
+
+	var employees []models.Employee
+	if err := r.db.WithContext(ctx).Raw(reportsSQL, managerID).Scan(&employees).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to list all reports of employee id: %d: %w", op, managerID, err)
+	}
+
+	return employees, nil
+}
+
+// GetManagementChain - get managers of employee from direct manager up to the top
+func (r *employeeRepo) GetManagementChain(ctx context.Context, id int) ([]models.Employee, error) {
+	const op = "postgres.employee.GetManagementChain"
+
+	const chainSQL = `
+WITH RECURSIVE chain AS (
+	SELECT m.id, m.manager_id, 1 AS lvl
+	FROM employees e
+	JOIN employees m ON m.id = e.manager_id
+	WHERE e.id = ?
+	UNION ALL
+	SELECT m.id, m.manager_id, c.lvl + 1
+	FROM chain c
+	JOIN employees m ON m.id = c.manager_id
+)
+SELECT e.*
+FROM chain c
+JOIN employees e ON e.id = c.id
+ORDER BY c.lvl ASC`
+
+	var employees []models.Employee
+	if err := r.db.WithContext(ctx).Raw(chainSQL, id).Scan(&employees).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to get management chain of employee id: %d: %w", op, id, err)
+	}
+
+	return employees, nil
+}
+
+// GetSpanOfControl - get every manager with number of direct reports
+func (r *employeeRepo) GetSpanOfControl(ctx context.Context) ([]models.ManagerSpan, error) {
+	const op = "postgres.employee.GetSpanOfControl"
+
+	const spanSQL = `
+SELECT m.*, COUNT(r.id) AS direct_reports
+FROM employees m
+JOIN employees r ON r.manager_id = m.id
+GROUP BY m.id
+ORDER BY direct_reports DESC, m.full_name ASC, m.id ASC`
+
+	var spans []models.ManagerSpan
+	if err := r.db.WithContext(ctx).Raw(spanSQL).Scan(&spans).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to get span of control: %w", op, err)
+	}
+
+	return spans, nil
+}
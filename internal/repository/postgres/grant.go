@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type grantRepo struct {
+	db *gorm.DB
+}
+
+func newGrantRepo(db *gorm.DB) *grantRepo {
+	return &grantRepo{
+		db: db,
+	}
+}
+
+// Save - create grant or replace role of subject on department
+func (r *grantRepo) Save(ctx context.Context, g *models.DepartmentGrant) error {
+	const op = "postgres.grant.Save"
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subject"}, {Name: "department_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "granted_by", "created_at"}),
+	}).Create(g).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return fmt.Errorf("%s: failed to save grant on department id: %d: %w", op, g.DepartmentID, domain.ErrDepartmentNotFound)
+		}
+		return fmt.Errorf("%s: failed to save grant on department id: %d: %w", op, g.DepartmentID, err)
+	}
+
+	return nil
+}
+
+// ListByDepartment - list grants on department, without grants inherited from ancestors
+func (r *grantRepo) ListByDepartment(ctx context.Context, deptID int) ([]models.DepartmentGrant, error) {
+	const op = "postgres.grant.ListByDepartment"
+
+	var grants []models.DepartmentGrant
+	if err := r.db.WithContext(ctx).Where("department_id = ?", deptID).Order("subject ASC").Find(&grants).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to list grants of department id: %d: %w", op, deptID, err)
+	}
+
+	return grants, nil
+}
+
+// Delete - delete grant on department
+func (r *grantRepo) Delete(ctx context.Context, deptID int, id int) error {
+	const op = "postgres.grant.Delete"
+
+	result := r.db.WithContext(ctx).Where("id = ? AND department_id = ?", id, deptID).Delete(&models.DepartmentGrant{})
+	if result.Error != nil {
+		return fmt.Errorf("%s: failed to delete grant id: %d: %w", op, id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: failed to delete grant id: %d: %w", op, id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetRoles - roles of subject granted on department or any of its ancestors
+func (r *grantRepo) GetRoles(ctx context.Context, subject string, deptID int) ([]string, error) {
+	const op = "postgres.grant.GetRoles"
+
+	const rolesSQL = `
+WITH RECURSIVE ancestors AS (
+	SELECT d.id, d.parent_id
+	FROM departments d
+	WHERE d.id = ?
+	UNION ALL
+	SELECT p.id, p.parent_id
+	FROM ancestors a
+	JOIN departments p ON p.id = a.parent_id
+)
+SELECT g.role
+FROM department_grants g
+JOIN ancestors a ON a.id = g.department_id
+WHERE g.subject = ?`
+
+	var roles []string
+	if err := r.db.WithContext(ctx).Raw(rolesSQL, deptID, subject).Scan(&roles).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to get roles of '%s' on department id: %d: %w", op, subject, deptID, err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type positionRepo struct {
+	db *gorm.DB
+}
+
+func newPositionRepo(db *gorm.DB) *positionRepo {
+	return &positionRepo{
+		db: db,
+	}
+}
+
+// Create - create a new position
+func (r *positionRepo) Create(ctx context.Context, pos *models.Position) error {
+	const op = "postgres.position.Create"
+
+	result := r.db.WithContext(ctx).Create(pos)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("%s: failed to create position '%s': %w", op, pos.Title, domain.ErrDuplicateName)
+		}
+		return fmt.Errorf("%s: failed to create position: %w", op, result.Error)
+	}
+
+	return nil
+}
+
+// GetByID - get position by ID
+func (r *positionRepo) GetByID(ctx context.Context, id int) (*models.Position, error) {
+	const op = "postgres.position.GetByID"
+
+	var pos models.Position
+	if err := r.db.WithContext(ctx).First(&pos, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: failed to get position id: %d: %w", op, id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get position id: %d: %w", op, id, err)
+	}
+
+	return &pos, nil
+}
+
+// GetByTitle - get position by case-insensitive title
+func (r *positionRepo) GetByTitle(ctx context.Context, title string) (*models.Position, error) {
+	const op = "postgres.position.GetByTitle"
+
+	var pos models.Position
+	if err := r.db.WithContext(ctx).Where("lower(title) = lower(?)", title).First(&pos).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // not err - just found nothing
+		}
+		return nil, fmt.Errorf("%s: failed to get position by title: %w", op, err)
+	}
+
+	return &pos, nil
+}
+
+// List - list all positions sorted by family, level and title
+func (r *positionRepo) List(ctx context.Context) ([]models.Position, error) {
+	const op = "postgres.position.List"
+
+	var positions []models.Position
+	if err := r.db.WithContext(ctx).Order("family ASC, level ASC NULLS LAST, title ASC").Find(&positions).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to list positions: %w", op, err)
+	}
+
+	return positions, nil
+}
+
+// Update - update position, renaming also updates position title of its employees
+func (r *positionRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	const op = "postgres.position.Update"
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Position{}).Where("id = ?", id).Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("%s: failed to update position id: %d: %w", op, id, result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%s: failed to update position id: %d: %w", op, id, domain.ErrNotFound)
+		}
+
+		if title, ok := updates["title"]; ok {
+			if err := tx.Model(&models.Employee{}).
+				Where("position_id = ?", id).
+				Update("position", title).Error; err != nil {
+				return fmt.Errorf("%s: failed to rename position of employees id: %d: %w", op, id, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete - delete position
+func (r *positionRepo) Delete(ctx context.Context, id int) error {
+	const op = "postgres.position.Delete"
+
+	result := r.db.WithContext(ctx).Delete(&models.Position{}, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+			return fmt.Errorf("%s: failed to delete position id: %d: %w", op, id, domain.ErrPositionInUse)
+		}
+		return fmt.Errorf("%s: failed to delete position id: %d: %w", op, id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: failed to delete position id: %d: %w", op, id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// IsUsed - check if any employee holds the position or any vacancy is opened for it
+func (r *positionRepo) IsUsed(ctx context.Context, id int) (bool, error) {
+	const op = "postgres.position.IsUsed"
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Employee{}).Where("position_id = ?", id).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to check usage of position id by employees: %d: %w", op, id, err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	err = r.db.WithContext(ctx).Model(&models.Vacancy{}).Where("position_id = ?", id).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to check usage of position id by vacancies: %d: %w", op, id, err)
+	}
+	return count > 0, nil
+}
@@ -1,21 +1,39 @@
 package postgres
 
 import (
+	"context"
+
 	"github.com/tmozzze/org_struct_api/internal/domain"
 	"gorm.io/gorm"
 )
 
 // Repo - main repository struct
 type Repo struct {
+	db         *gorm.DB
 	department domain.DepartmentRepository
 	employee   domain.EmployeeRepository
+	assignment domain.AssignmentRepository
+	position   domain.PositionRepository
+	vacancy    domain.VacancyRepository
+	attribute  domain.AttributeRepository
+	deptType   domain.DepartmentTypeRepository
+	apiKey     domain.APIKeyRepository
+	grant      domain.GrantRepository
 }
 
 // NewRepository - constructor for Repo
 func NewRepository(db *gorm.DB) *Repo {
 	return &Repo{
+		db:         db,
 		department: newDepartmentRepo(db),
 		employee:   newEmployeeRepo(db),
+		assignment: newAssignmentRepo(db),
+		position:   newPositionRepo(db),
+		vacancy:    newVacancyRepo(db),
+		attribute:  newAttributeRepo(db),
+		deptType:   newDepartmentTypeRepo(db),
+		apiKey:     newAPIKeyRepo(db),
+		grant:      newGrantRepo(db),
 	}
 }
 
@@ -28,3 +46,45 @@ func (r *Repo) Department() domain.DepartmentRepository {
 func (r *Repo) Employee() domain.EmployeeRepository {
 	return r.employee
 }
+
+// Assignment - return AssignmentRepository
+func (r *Repo) Assignment() domain.AssignmentRepository {
+	return r.assignment
+}
+
+// Position - return PositionRepository
+func (r *Repo) Position() domain.PositionRepository {
+	return r.position
+}
+
+// Vacancy - return VacancyRepository
+func (r *Repo) Vacancy() domain.VacancyRepository {
+	return r.vacancy
+}
+
+// Attribute - return AttributeRepository
+func (r *Repo) Attribute() domain.AttributeRepository {
+	return r.attribute
+}
+
+// DepartmentType - return DepartmentTypeRepository
+func (r *Repo) DepartmentType() domain.DepartmentTypeRepository {
+	return r.deptType
+}
+
+// APIKey - return APIKeyRepository
+func (r *Repo) APIKey() domain.APIKeyRepository {
+	return r.apiKey
+}
+
+// Grant - return GrantRepository
+func (r *Repo) Grant() domain.GrantRepository {
+	return r.grant
+}
+
+// Transaction - runs fn with repositories of one transaction, rolled back if fn returns error
+func (r *Repo) Transaction(ctx context.Context, fn func(repo domain.Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepository(tx))
+	})
+}
@@ -2,11 +2,14 @@ package postgres
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/pressly/goose"
 	"github.com/stretchr/testify/suite"
+	"github.com/tmozzze/org_struct_api/internal/domain"
 	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"github.com/tmozzze/org_struct_api/pkg/database"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -20,7 +23,7 @@ type RepoTestSuite struct {
 
 // TearDownTest - cleanup after each test
 func (s *RepoTestSuite) TearDownTest() {
-	err := s.db.Exec("TRUNCATE TABLE employees, departments RESTART IDENTITY CASCADE").Error
+	err := s.db.Exec("ALTER SEQUENCE employee_number_seq RESTART; TRUNCATE TABLE vacancies, employee_assignments, employees, departments, positions, attribute_definitions, department_types, department_type_rules, api_keys, department_grants RESTART IDENTITY CASCADE").Error
 	s.NoError(err, "failed to cleanup database after test")
 }
 
@@ -29,10 +32,7 @@ func (s *RepoTestSuite) SetupSuite() {
 
 	// DSN
 	dsn := "host=localhost user=user password=password dbname=pgdb port=5432 sslmode=disable"
-	// Migrations directory
-	migrationsDir := "../../../database/migrations"
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		s.T().Fatalf("failed to connect to test database: %v", err)
 	}
@@ -40,7 +40,11 @@ func (s *RepoTestSuite) SetupSuite() {
 
 	sqlDB, _ := s.db.DB()
 
-	if err := goose.Up(sqlDB, migrationsDir); err != nil {
+	migrator, err := database.NewMigrator("postgres", sqlDB)
+	if err != nil {
+		s.T().Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
 		s.T().Fatalf("failed to run migrations: %v", err)
 	}
 
@@ -99,7 +103,7 @@ func (s *RepoTestSuite) TestGetByID_RecursiveTree() {
 	s.NoError(s.repo.Employee().Create(ctx, emp))
 
 	// Root with depth = 2
-	res, err := s.repo.Department().GetByID(ctx, root.ID, 2, true)
+	res, err := s.repo.Department().GetByID(ctx, root.ID, domain.TreeOptions{Depth: 2, IncludeEmployees: true})
 
 	s.NoError(err)
 	s.Equal("Root", res.Name)
@@ -140,6 +144,676 @@ func (s *RepoTestSuite) TestDeleteWithReassign() {
 	s.Equal(deptB.ID, empRes.DepartmentID, "Employee should be reassigned to Dept B")
 }
 
+// TestSearch_PathAndAccents - test for DepartmentRepo Search with ancestor path
+func (s *RepoTestSuite) TestSearch_PathAndAccents() {
+	ctx := context.Background()
+
+	// IT --> QA, Mobile --> Qualité
+	it := &models.Department{Name: "IT"}
+	s.NoError(s.repo.Department().Create(ctx, it))
+
+	mobile := &models.Department{Name: "Mobile"}
+	s.NoError(s.repo.Department().Create(ctx, mobile))
+
+	s.NoError(s.repo.Department().Create(ctx, &models.Department{Name: "QA", ParentID: &it.ID}))
+	s.NoError(s.repo.Department().Create(ctx, &models.Department{Name: "Qualité", ParentID: &mobile.ID}))
+
+	res, err := s.repo.Department().Search(ctx, "qualite", 10)
+	s.NoError(err)
+	s.Len(res, 1, "accents must be ignored")
+	s.Equal("Qualité", res[0].Name)
+	s.Equal([]models.DepartmentAncestor{{ID: mobile.ID, Name: "Mobile"}}, res[0].Path)
+
+	res, err = s.repo.Department().Search(ctx, "Q", 10)
+	s.NoError(err)
+	s.Len(res, 2, "prefix must match both departments")
+}
+
+// TestGetByID_LimitsPerParent - test for DepartmentRepo GetByID limits and keyset pagination
+func (s *RepoTestSuite) TestGetByID_LimitsPerParent() {
+	ctx := context.Background()
+
+	root := &models.Department{Name: "Root"}
+	s.NoError(s.repo.Department().Create(ctx, root))
+
+	for _, name := range []string{"C", "A", "B"} {
+		s.NoError(s.repo.Department().Create(ctx, &models.Department{Name: name, ParentID: &root.ID}))
+		s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: name, Position: "Developer", DepartmentID: root.ID}))
+	}
+
+	// limit+1 rows are loaded to detect truncation
+	res, err := s.repo.Department().GetByID(ctx, root.ID, domain.TreeOptions{
+		Depth: 1, IncludeEmployees: true, ChildrenLimit: 1, EmployeesLimit: 1,
+	})
+	s.NoError(err)
+	s.Len(res.Children, 2)
+	s.Equal("A", res.Children[0].Name)
+	s.Len(res.Employees, 2)
+
+	// Next page after "A"
+	children, err := s.repo.Department().ListChildren(ctx, root.ID, domain.DepartmentFilter{}, &models.Cursor{Key: res.Children[0].Name, ID: res.Children[0].ID}, 10)
+	s.NoError(err)
+	s.Len(children, 2)
+	s.Equal("B", children[0].Name)
+
+	employees, err := s.repo.Employee().ListByDepartment(ctx, root.ID, domain.EmployeeFilter{}, &models.Cursor{Key: "B", ID: res.Employees[1].ID}, 10)
+	s.NoError(err)
+	s.Len(employees, 1)
+	s.Equal("C", employees[0].FullName)
+}
+
+// TestGetStats_Subtree - test for DepartmentRepo GetCounts and GetStats over subtree
+func (s *RepoTestSuite) TestGetStats_Subtree() {
+	ctx := context.Background()
+
+	// Engineering --> Backend --> Platform
+	eng := &models.Department{Name: "Engineering"}
+	s.NoError(s.repo.Department().Create(ctx, eng))
+
+	backend := &models.Department{Name: "Backend", ParentID: &eng.ID}
+	s.NoError(s.repo.Department().Create(ctx, backend))
+
+	platform := &models.Department{Name: "Platform", ParentID: &backend.ID}
+	s.NoError(s.repo.Department().Create(ctx, platform))
+
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "A", Position: "CTO", DepartmentID: eng.ID}))
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "B", Position: "Developer", DepartmentID: backend.ID}))
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "C", Position: "Developer", DepartmentID: platform.ID}))
+
+	counts, err := s.repo.Department().GetCounts(ctx, []int{eng.ID, platform.ID})
+	s.NoError(err)
+	s.Equal(int64(1), counts[eng.ID].DirectEmployeeCount)
+	s.Equal(int64(3), counts[eng.ID].TotalEmployeeCount)
+	s.Equal(int64(1), counts[eng.ID].DirectChildrenCount)
+	s.Equal(int64(2), counts[eng.ID].TotalDescendantCount)
+	s.Equal(int64(1), counts[platform.ID].TotalEmployeeCount)
+
+	stats, err := s.repo.Department().GetStats(ctx, eng.ID)
+	s.NoError(err)
+	s.Equal(2, stats.MaxDepth)
+	s.Nil(stats.AverageTenureDays, "no employee has hired_at")
+	s.Equal([]models.PositionCount{{Position: "Developer", Count: 2}, {Position: "CTO", Count: 1}}, stats.Positions)
+}
+
+// TestGetTotals - test for DepartmentRepo GetTotals over the whole organization
+func (s *RepoTestSuite) TestGetTotals() {
+	ctx := context.Background()
+
+	empty, err := s.repo.Department().GetTotals(ctx)
+	s.NoError(err)
+	s.Equal(models.OrgTotals{}, *empty)
+
+	// Engineering --> Backend --> Platform, Sales
+	eng := &models.Department{Name: "Engineering"}
+	s.NoError(s.repo.Department().Create(ctx, eng))
+	backend := &models.Department{Name: "Backend", ParentID: &eng.ID}
+	s.NoError(s.repo.Department().Create(ctx, backend))
+	platform := &models.Department{Name: "Platform", ParentID: &backend.ID}
+	s.NoError(s.repo.Department().Create(ctx, platform))
+	sales := &models.Department{Name: "Sales"}
+	s.NoError(s.repo.Department().Create(ctx, sales))
+
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{DepartmentID: platform.ID, FullName: "Alice", Position: "Developer"}))
+	bob := &models.Employee{DepartmentID: sales.ID, FullName: "Bob", Position: "Manager"}
+	s.NoError(s.repo.Employee().Create(ctx, bob))
+	s.NoError(s.repo.Employee().Terminate(ctx, bob.ID, time.Now()))
+
+	totals, err := s.repo.Department().GetTotals(ctx)
+	s.NoError(err)
+	s.Equal(models.OrgTotals{Departments: 4, Employees: 1, MaxDepth: 2}, *totals)
+}
+
+// TestGetAncestors_WithHeads - test for DepartmentRepo GetAncestors order and heads
+func (s *RepoTestSuite) TestGetAncestors_WithHeads() {
+	ctx := context.Background()
+
+	// Root --> Child
+	root := &models.Department{Name: "Root"}
+	s.NoError(s.repo.Department().Create(ctx, root))
+
+	child := &models.Department{Name: "Child", ParentID: &root.ID}
+	s.NoError(s.repo.Department().Create(ctx, child))
+
+	head := &models.Employee{FullName: "Oleg Moroz", Position: "CEO", DepartmentID: root.ID}
+	s.NoError(s.repo.Employee().Create(ctx, head))
+	s.NoError(s.repo.Department().Update(ctx, root.ID, map[string]interface{}{"head_employee_id": head.ID}))
+
+	ancestors, err := s.repo.Department().GetAncestors(ctx, child.ID)
+	s.NoError(err)
+	s.Len(ancestors, 2)
+	s.Equal(child.ID, ancestors[0].ID)
+	s.Nil(ancestors[0].Head)
+	s.Equal(root.ID, ancestors[1].ID)
+	s.Equal("Oleg Moroz", ancestors[1].Head.FullName)
+}
+
+// TestReportingLines - test for EmployeeRepo reports, management chain and span of control
+func (s *RepoTestSuite) TestReportingLines() {
+	ctx := context.Background()
+
+	dept := &models.Department{Name: "Dept"}
+	s.NoError(s.repo.Department().Create(ctx, dept))
+
+	// ceo --> cto --> dev
+	ceo := &models.Employee{FullName: "CEO", Position: "CEO", DepartmentID: dept.ID}
+	s.NoError(s.repo.Employee().Create(ctx, ceo))
+
+	cto := &models.Employee{FullName: "CTO", Position: "CTO", DepartmentID: dept.ID, ManagerID: &ceo.ID}
+	s.NoError(s.repo.Employee().Create(ctx, cto))
+
+	dev := &models.Employee{FullName: "Dev", Position: "Developer", DepartmentID: dept.ID, ManagerID: &cto.ID}
+	s.NoError(s.repo.Employee().Create(ctx, dev))
+
+	direct, err := s.repo.Employee().ListDirectReports(ctx, ceo.ID)
+	s.NoError(err)
+	s.Len(direct, 1)
+
+	all, err := s.repo.Employee().ListAllReports(ctx, ceo.ID)
+	s.NoError(err)
+	s.Len(all, 2)
+	s.Equal(cto.ID, all[0].ID)
+	s.Equal(dev.ID, all[1].ID)
+
+	chain, err := s.repo.Employee().GetManagementChain(ctx, dev.ID)
+	s.NoError(err)
+	s.Len(chain, 2)
+	s.Equal(cto.ID, chain[0].ID)
+	s.Equal(ceo.ID, chain[1].ID)
+
+	spans, err := s.repo.Employee().GetSpanOfControl(ctx)
+	s.NoError(err)
+	s.Len(spans, 2)
+	s.Equal(int64(1), spans[0].DirectReports)
+}
+
+// TestAssignments_SecondaryMembers - test for secondary assignments in tree and on reassign
+func (s *RepoTestSuite) TestAssignments_SecondaryMembers() {
+	ctx := context.Background()
+
+	product := &models.Department{Name: "Product"}
+	s.NoError(s.repo.Department().Create(ctx, product))
+
+	guild := &models.Department{Name: "Guild"}
+	s.NoError(s.repo.Department().Create(ctx, guild))
+
+	emp := &models.Employee{FullName: "Oleg Moroz", Position: "Developer", DepartmentID: product.ID}
+	s.NoError(s.repo.Employee().Create(ctx, emp))
+
+	s.NoError(s.repo.Assignment().Create(ctx, &models.EmployeeAssignment{EmployeeID: emp.ID, DepartmentID: product.ID, AllocationPercent: 80, IsPrimary: true}))
+	s.NoError(s.repo.Assignment().Create(ctx, &models.EmployeeAssignment{EmployeeID: emp.ID, DepartmentID: guild.ID, Role: "Member", AllocationPercent: 20}))
+	s.ErrorIs(s.repo.Assignment().Create(ctx, &models.EmployeeAssignment{EmployeeID: emp.ID, DepartmentID: guild.ID}), domain.ErrAlreadyExist)
+
+	// Lock of employee row in transaction
+	s.NoError(s.repo.Transaction(ctx, func(repo domain.Repository) error {
+		return repo.Employee().Lock(ctx, emp.ID)
+	}))
+	s.ErrorIs(s.repo.Employee().Lock(ctx, emp.ID+100), domain.ErrNotFound)
+
+	sum, err := s.repo.Assignment().SumAllocation(ctx, emp.ID, 0)
+	s.NoError(err)
+	s.Equal(100, sum)
+
+	res, err := s.repo.Department().GetByID(ctx, guild.ID, domain.TreeOptions{Depth: 1, IncludeSecondary: true})
+	s.NoError(err)
+	s.Len(res.Assignments, 1)
+	s.Equal("Oleg Moroz", res.Assignments[0].Employee.FullName)
+
+	// Guild becomes home department, primary assignment follows
+	s.NoError(s.repo.Department().DeleteWithReassign(ctx, product.ID, guild.ID))
+
+	assignments, err := s.repo.Assignment().ListByEmployee(ctx, emp.ID)
+	s.NoError(err)
+	s.Len(assignments, 1)
+	s.True(assignments[0].IsPrimary)
+	s.Equal(guild.ID, assignments[0].DepartmentID)
+}
+
+// TestPositions_CanonicalTitle - test for case-insensitive lookup, rename and position filter
+func (s *RepoTestSuite) TestPositions_CanonicalTitle() {
+	ctx := context.Background()
+
+	dept := &models.Department{Name: "Engineering"}
+	s.NoError(s.repo.Department().Create(ctx, dept))
+
+	pos := &models.Position{Title: "Developer", Family: "Engineering"}
+	s.NoError(s.repo.Position().Create(ctx, pos))
+
+	found, err := s.repo.Position().GetByTitle(ctx, "developer")
+	s.NoError(err)
+	s.Equal(pos.ID, found.ID)
+
+	emp := &models.Employee{FullName: "Oleg Moroz", Position: pos.Title, PositionID: &pos.ID, DepartmentID: dept.ID}
+	s.NoError(s.repo.Employee().Create(ctx, emp))
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "Ivan Petrov", Position: "CTO", DepartmentID: dept.ID}))
+
+	// Rename is applied to employees holding the position
+	s.NoError(s.repo.Position().Update(ctx, pos.ID, map[string]interface{}{"title": "Software Engineer"}))
+
+	employees, err := s.repo.Employee().ListByDepartment(ctx, dept.ID, domain.EmployeeFilter{PositionID: &pos.ID}, nil, 10)
+	s.NoError(err)
+	s.Len(employees, 1)
+	s.Equal("Software Engineer", employees[0].Position)
+
+	used, err := s.repo.Position().IsUsed(ctx, pos.ID)
+	s.NoError(err)
+	s.True(used)
+
+	// Position of vacancy only is used too
+	planned := &models.Position{Title: "Designer", Family: "Design"}
+	s.NoError(s.repo.Position().Create(ctx, planned))
+	s.NoError(s.repo.Vacancy().Create(ctx, &models.Vacancy{DepartmentID: dept.ID, PositionID: planned.ID, Status: domain.VacancyStatusOpen}))
+
+	used, err = s.repo.Position().IsUsed(ctx, planned.ID)
+	s.NoError(err)
+	s.True(used)
+	s.ErrorIs(s.repo.Position().Delete(ctx, planned.ID), domain.ErrPositionInUse)
+}
+
+// TestVacancies_Headcount - test for planned vs actual headcount of subtree and single fill
+func (s *RepoTestSuite) TestVacancies_Headcount() {
+	ctx := context.Background()
+
+	root := &models.Department{Name: "Engineering"}
+	s.NoError(s.repo.Department().Create(ctx, root))
+
+	child := &models.Department{Name: "Backend", ParentID: &root.ID}
+	s.NoError(s.repo.Department().Create(ctx, child))
+
+	pos := &models.Position{Title: "Developer"}
+	s.NoError(s.repo.Position().Create(ctx, pos))
+
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "Oleg Moroz", Position: pos.Title, PositionID: &pos.ID, DepartmentID: root.ID}))
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "Ivan Petrov", Position: pos.Title, PositionID: &pos.ID, DepartmentID: child.ID}))
+
+	open := &models.Vacancy{DepartmentID: child.ID, PositionID: pos.ID, Status: domain.VacancyStatusOpen}
+	s.NoError(s.repo.Vacancy().Create(ctx, open))
+	s.NoError(s.repo.Vacancy().Create(ctx, &models.Vacancy{DepartmentID: child.ID, PositionID: pos.ID, Status: domain.VacancyStatusCancelled}))
+
+	rows, err := s.repo.Vacancy().GetHeadcount(ctx, root.ID)
+	s.NoError(err)
+	s.Require().Len(rows, 2)
+	s.Equal(models.DepartmentHeadcount{DepartmentID: root.ID, Name: "Engineering", Depth: 0, ActualHeadcount: 2, OpenVacancies: 1}, rows[0])
+	s.Equal(models.DepartmentHeadcount{DepartmentID: child.ID, ParentID: &root.ID, Name: "Backend", Depth: 1, ActualHeadcount: 1, OpenVacancies: 1}, rows[1])
+
+	// Only the first status change wins
+	s.NoError(s.repo.Vacancy().SetStatus(ctx, open.ID, domain.VacancyStatusOpen, domain.VacancyStatusFilled))
+	s.ErrorIs(s.repo.Vacancy().SetStatus(ctx, open.ID, domain.VacancyStatusOpen, domain.VacancyStatusFilled), domain.ErrNotFound)
+}
+
+// TestUnsetInvalidHeads - test for heads outside department ancestors unset after move and after employee leaves
+func (s *RepoTestSuite) TestUnsetInvalidHeads() {
+	ctx := context.Background()
+
+	sales := &models.Department{Name: "Sales"}
+	s.NoError(s.repo.Department().Create(ctx, sales))
+	ops := &models.Department{Name: "Ops"}
+	s.NoError(s.repo.Department().Create(ctx, ops))
+	east := &models.Department{Name: "East", ParentID: &sales.ID}
+	s.NoError(s.repo.Department().Create(ctx, east))
+	west := &models.Department{Name: "West", ParentID: &sales.ID}
+	s.NoError(s.repo.Department().Create(ctx, west))
+
+	anna := &models.Employee{FullName: "Anna Lis", Position: "Director", DepartmentID: sales.ID, Status: domain.EmployeeStatusActive}
+	s.NoError(s.repo.Employee().Create(ctx, anna))
+	ivan := &models.Employee{FullName: "Ivan Petrov", Position: "Manager", DepartmentID: west.ID, Status: domain.EmployeeStatusActive}
+	s.NoError(s.repo.Employee().Create(ctx, ivan))
+
+	s.NoError(s.repo.Department().Update(ctx, sales.ID, map[string]interface{}{"head_employee_id": anna.ID}))
+	s.NoError(s.repo.Department().Update(ctx, east.ID, map[string]interface{}{"head_employee_id": anna.ID}))
+	s.NoError(s.repo.Department().Update(ctx, west.ID, map[string]interface{}{"head_employee_id": ivan.ID}))
+
+	// East moves under Ops, Anna of Sales is no longer its ancestor
+	s.NoError(s.repo.Department().Update(ctx, east.ID, map[string]interface{}{"parent_id": ops.ID}))
+	s.NoError(s.repo.Department().UnsetInvalidHeads(ctx, east.ID))
+
+	res, err := s.repo.Department().GetByIDSimple(ctx, east.ID)
+	s.NoError(err)
+	s.Nil(res.HeadEmployeeID)
+	res, err = s.repo.Department().GetByIDSimple(ctx, sales.ID)
+	s.NoError(err)
+	s.Equal(anna.ID, *res.HeadEmployeeID, "head of department outside moved subtree is kept")
+
+	// Ivan moves to Ops and stops heading West
+	s.NoError(s.repo.Employee().UpdateDepartmentForEmployees(ctx, west.ID, ops.ID))
+	s.NoError(s.repo.Department().UnsetInvalidHeads(ctx, ops.ID))
+
+	res, err = s.repo.Department().GetByIDSimple(ctx, west.ID)
+	s.NoError(err)
+	s.Nil(res.HeadEmployeeID)
+	res, err = s.repo.Department().GetByIDSimple(ctx, sales.ID)
+	s.NoError(err)
+	s.Equal(anna.ID, *res.HeadEmployeeID)
+}
+
+// TestTerminate_ExcludedFromTree - test for terminated employee hidden from tree and counts, reports and head handed over
+func (s *RepoTestSuite) TestTerminate_ExcludedFromTree() {
+	ctx := context.Background()
+
+	dept := &models.Department{Name: "Engineering"}
+	s.NoError(s.repo.Department().Create(ctx, dept))
+
+	cto := &models.Employee{FullName: "Anna CTO", Position: "CTO", DepartmentID: dept.ID, Status: domain.EmployeeStatusActive}
+	s.NoError(s.repo.Employee().Create(ctx, cto))
+
+	lead := &models.Employee{FullName: "Ivan Lead", Position: "Lead", DepartmentID: dept.ID, ManagerID: &cto.ID, Status: domain.EmployeeStatusActive}
+	s.NoError(s.repo.Employee().Create(ctx, lead))
+
+	dev := &models.Employee{FullName: "Oleg Dev", Position: "Developer", DepartmentID: dept.ID, ManagerID: &lead.ID, Status: domain.EmployeeStatusActive}
+	s.NoError(s.repo.Employee().Create(ctx, dev))
+
+	s.NoError(s.repo.Department().Update(ctx, dept.ID, map[string]interface{}{"head_employee_id": lead.ID}))
+
+	s.NoError(s.repo.Employee().Terminate(ctx, lead.ID, time.Now()))
+
+	// Report moves to manager of terminated lead
+	moved, err := s.repo.Employee().GetByID(ctx, dev.ID)
+	s.NoError(err)
+	s.Equal(cto.ID, *moved.ManagerID)
+
+	res, err := s.repo.Department().GetByID(ctx, dept.ID, domain.TreeOptions{Depth: 1, IncludeEmployees: true, EmployeesLimit: 10})
+	s.NoError(err)
+	s.Nil(res.HeadEmployeeID)
+	s.Len(res.Employees, 2)
+
+	res, err = s.repo.Department().GetByID(ctx, dept.ID, domain.TreeOptions{Depth: 1, IncludeEmployees: true, IncludeTerminated: true})
+	s.NoError(err)
+	s.Len(res.Employees, 3)
+
+	counts, err := s.repo.Department().GetCounts(ctx, []int{dept.ID})
+	s.NoError(err)
+	s.Equal(int64(2), counts[dept.ID].DirectEmployeeCount)
+}
+
+// TestEmployeeContacts_Unique - test for case-insensitive email uniqueness and number sequence
+func (s *RepoTestSuite) TestEmployeeContacts_Unique() {
+	ctx := context.Background()
+
+	dept := &models.Department{Name: "Engineering"}
+	s.NoError(s.repo.Department().Create(ctx, dept))
+
+	email := "Oleg.Moroz@example.com"
+	number := "EMP-000001"
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "Oleg Moroz", Position: "Developer", DepartmentID: dept.ID, Email: &email, EmployeeNumber: &number}))
+
+	found, err := s.repo.Employee().GetByEmail(ctx, "oleg.moroz@EXAMPLE.com")
+	s.NoError(err)
+	s.Require().NotNil(found)
+	s.Equal(number, *found.EmployeeNumber)
+
+	// Unique index catches what the service check missed
+	sameEmail := "OLEG.MOROZ@example.com"
+	err = s.repo.Employee().Create(ctx, &models.Employee{FullName: "Oleg Moroz 2", Position: "Developer", DepartmentID: dept.ID, Email: &sameEmail})
+	s.ErrorIs(err, domain.ErrAlreadyExist)
+
+	first, err := s.repo.Employee().NextNumber(ctx)
+	s.NoError(err)
+	second, err := s.repo.Employee().NextNumber(ctx)
+	s.NoError(err)
+	s.Equal(first+1, second)
+}
+
+// TestAttributes_FilterAndDelete - test for filtering by attribute values and removing values of deleted attribute
+func (s *RepoTestSuite) TestAttributes_FilterAndDelete() {
+	ctx := context.Background()
+
+	def := &models.AttributeDefinition{EntityType: domain.AttributeEntityEmployee, Name: "level", Type: domain.AttributeTypeNumber}
+	s.NoError(s.repo.Attribute().Create(ctx, def))
+	s.ErrorIs(s.repo.Attribute().Create(ctx, &models.AttributeDefinition{EntityType: domain.AttributeEntityEmployee, Name: "level", Type: domain.AttributeTypeString}), domain.ErrAlreadyExist)
+
+	root := &models.Department{Name: "Root"}
+	s.NoError(s.repo.Department().Create(ctx, root))
+	berlin := &models.Department{Name: "Berlin Office", ParentID: &root.ID, Attributes: models.Attributes{"location": "Berlin"}}
+	s.NoError(s.repo.Department().Create(ctx, berlin))
+	s.NoError(s.repo.Department().Create(ctx, &models.Department{Name: "Remote", ParentID: &root.ID}))
+
+	children, err := s.repo.Department().ListChildren(ctx, root.ID, domain.DepartmentFilter{Attributes: models.Attributes{"location": "Berlin"}}, nil, 10)
+	s.NoError(err)
+	s.Len(children, 1)
+	s.Equal("Berlin", children[0].Attributes["location"])
+
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "A", Position: "Developer", DepartmentID: root.ID, Attributes: models.Attributes{"level": 3}}))
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "B", Position: "Developer", DepartmentID: root.ID, Attributes: models.Attributes{"level": 2}}))
+
+	// 3 in the filter matches stored 3
+	employees, err := s.repo.Employee().ListByDepartment(ctx, root.ID, domain.EmployeeFilter{Attributes: models.Attributes{"level": float64(3)}}, nil, 10)
+	s.NoError(err)
+	s.Len(employees, 1)
+	s.Equal("A", employees[0].FullName)
+
+	s.NoError(s.repo.Attribute().Delete(ctx, def.ID))
+
+	employees, err = s.repo.Employee().ListByDepartment(ctx, root.ID, domain.EmployeeFilter{}, nil, 10)
+	s.NoError(err)
+	s.Len(employees, 2)
+	for _, emp := range employees {
+		s.Empty(emp.Attributes)
+	}
+}
+
 func TestRepoSuite(t *testing.T) {
 	suite.Run(t, new(RepoTestSuite))
 }
+
+// TestDepartmentTypes_Subtree - test for department types, subtree and reassign deleting sub-departments
+func (s *RepoTestSuite) TestDepartmentTypes_Subtree() {
+	ctx := context.Background()
+
+	maxDepth := 2
+	s.NoError(s.repo.DepartmentType().Save(ctx, &models.DepartmentType{Name: "team", RootAllowed: false}))
+	s.NoError(s.repo.DepartmentType().Save(ctx, &models.DepartmentType{
+		Name:        "division",
+		RootAllowed: true,
+		MaxDepth:    &maxDepth,
+		Rules:       []models.DepartmentTypeRule{{ParentType: "division", ChildType: "team"}},
+	}))
+
+	typ, err := s.repo.DepartmentType().GetByName(ctx, "division")
+	s.NoError(err)
+	s.Equal([]string{"team"}, typ.AllowedChildren())
+
+	// Sales --> East, Support
+	division := "division"
+	team := "team"
+	sales := &models.Department{Name: "Sales", Type: &division}
+	s.NoError(s.repo.Department().Create(ctx, sales))
+	support := &models.Department{Name: "Support", Type: &division}
+	s.NoError(s.repo.Department().Create(ctx, support))
+	east := &models.Department{Name: "East", ParentID: &sales.ID, Type: &team}
+	s.NoError(s.repo.Department().Create(ctx, east))
+
+	nodes, err := s.repo.Department().GetSubtree(ctx, []int{sales.ID})
+	s.NoError(err)
+	s.Equal([]models.DepartmentNode{
+		{ID: sales.ID, Type: &division, Depth: 0},
+		{ID: east.ID, ParentID: &sales.ID, Type: &team, Depth: 1},
+	}, nodes)
+
+	used, err := s.repo.DepartmentType().IsUsed(ctx, "team")
+	s.NoError(err)
+	s.True(used)
+	s.ErrorIs(s.repo.DepartmentType().Delete(ctx, "team"), domain.ErrDepartmentTypeInUse)
+
+	s.NoError(s.repo.Department().DeleteWithReassign(ctx, sales.ID, support.ID))
+
+	_, err = s.repo.Department().GetByIDSimple(ctx, east.ID)
+	s.ErrorIs(err, domain.ErrNotFound, "sub-department should be deleted with Sales")
+}
+
+// TestReorder_SiblingOrder - test for DepartmentRepo Reorder, NextSortOrder and sibling order of trees and pages
+func (s *RepoTestSuite) TestReorder_SiblingOrder() {
+	ctx := context.Background()
+
+	// Company --> Dev, HR, QA
+	company := &models.Department{Name: "Company"}
+	s.NoError(s.repo.Department().Create(ctx, company))
+	dev := &models.Department{Name: "Dev", ParentID: &company.ID}
+	s.NoError(s.repo.Department().Create(ctx, dev))
+	hr := &models.Department{Name: "HR", ParentID: &company.ID}
+	s.NoError(s.repo.Department().Create(ctx, hr))
+	qa := &models.Department{Name: "QA", ParentID: &company.ID}
+	s.NoError(s.repo.Department().Create(ctx, qa))
+
+	err := s.repo.Department().Reorder(ctx, company.ID, []int{qa.ID, dev.ID})
+	s.ErrorIs(err, domain.ErrInvalidChildOrder, "every child must be listed")
+
+	s.NoError(s.repo.Department().Reorder(ctx, company.ID, []int{qa.ID, dev.ID, hr.ID}))
+
+	res, err := s.repo.Department().GetByID(ctx, company.ID, domain.TreeOptions{Depth: 1, ChildrenLimit: 2})
+	s.NoError(err)
+	s.Require().Len(res.Children, 3, "limit+1 children are loaded")
+	s.Equal([]string{"QA", "Dev", "HR"}, []string{res.Children[0].Name, res.Children[1].Name, res.Children[2].Name})
+
+	page, err := s.repo.Department().ListChildren(ctx, company.ID, domain.DepartmentFilter{}, &models.Cursor{Order: 1, Key: "QA", ID: qa.ID}, 10)
+	s.NoError(err)
+	s.Require().Len(page, 2)
+	s.Equal("Dev", page[0].Name)
+	s.Equal("HR", page[1].Name)
+
+	next, err := s.repo.Department().NextSortOrder(ctx, &company.ID)
+	s.NoError(err)
+	s.Equal(4, next)
+}
+
+// TestUniqueName_CaseInsensitive - test for case-insensitive department name uniqueness
+func (s *RepoTestSuite) TestUniqueName_CaseInsensitive() {
+	ctx := context.Background()
+
+	sales := &models.Department{Name: "Sales"}
+	s.NoError(s.repo.Department().Create(ctx, sales))
+
+	err := s.repo.Department().Create(ctx, &models.Department{Name: "SALES"})
+	s.ErrorIs(err, domain.ErrAlreadyExist, "root names must be unique ignoring case")
+
+	child := &models.Department{Name: "Ops", ParentID: &sales.ID}
+	s.NoError(s.repo.Department().Create(ctx, child))
+	s.NoError(s.repo.Department().Create(ctx, &models.Department{Name: "Dev", ParentID: &sales.ID}))
+
+	err = s.repo.Department().Update(ctx, child.ID, map[string]interface{}{"name": "dev"})
+	s.ErrorIs(err, domain.ErrAlreadyExist, "sibling names must be unique ignoring case")
+
+	found, err := s.repo.Department().GetByNameAndParent(ctx, "sales", nil)
+	s.NoError(err)
+	s.Require().NotNil(found)
+	s.Equal(sales.ID, found.ID)
+}
+
+// TestGetBudget_RollUp - test for DepartmentRepo GetBudget subtree totals and cost center lookup
+func (s *RepoTestSuite) TestGetBudget_RollUp() {
+	ctx := context.Background()
+
+	// Sales (100) --> East (50), West (no budget)
+	salesBudget, eastBudget := int64(100), int64(50)
+	cc := "CC-1"
+	sales := &models.Department{Name: "Sales", CostCenter: &cc, Budget: &salesBudget}
+	s.NoError(s.repo.Department().Create(ctx, sales))
+	east := &models.Department{Name: "East", ParentID: &sales.ID, Budget: &eastBudget}
+	s.NoError(s.repo.Department().Create(ctx, east))
+	west := &models.Department{Name: "West", ParentID: &sales.ID}
+	s.NoError(s.repo.Department().Create(ctx, west))
+
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "Oleg Moroz", Position: "Manager", DepartmentID: sales.ID}))
+	s.NoError(s.repo.Employee().Create(ctx, &models.Employee{FullName: "Anna Lis", Position: "Seller", DepartmentID: east.ID}))
+
+	err := s.repo.Department().Create(ctx, &models.Department{Name: "Ops", CostCenter: &cc})
+	s.ErrorIs(err, domain.ErrAlreadyExist, "cost center must be unique")
+
+	found, err := s.repo.Department().GetByCostCenter(ctx, "CC-1")
+	s.NoError(err)
+	s.Require().NotNil(found)
+	s.Equal(sales.ID, found.ID)
+
+	rows, err := s.repo.Department().GetBudget(ctx, sales.ID)
+	s.NoError(err)
+	s.Require().Len(rows, 3)
+
+	s.Equal(sales.ID, rows[0].DepartmentID)
+	s.Equal(int64(150), rows[0].TotalBudget)
+	s.Equal(int64(1), rows[0].Headcount)
+	s.Equal(int64(2), rows[0].TotalHeadcount)
+
+	s.Equal("East", rows[1].Name)
+	s.Equal(int64(50), rows[1].TotalBudget)
+	s.Equal(int64(1), rows[1].TotalHeadcount)
+
+	s.Equal("West", rows[2].Name)
+	s.Nil(rows[2].Budget)
+	s.Equal(int64(0), rows[2].TotalBudget)
+}
+
+// TestAPIKeys_RevokeAndTouch - test for APIKeyRepo lookup by hash, revoke and last use
+func (s *RepoTestSuite) TestAPIKeys_RevokeAndTouch() {
+	ctx := context.Background()
+
+	key := &models.APIKey{Name: "ci", Prefix: "osk_abcdefgh", KeyHash: strings.Repeat("a", 64)}
+	s.NoError(s.repo.APIKey().Create(ctx, key))
+
+	err := s.repo.APIKey().Create(ctx, &models.APIKey{Name: "dup", Prefix: "osk_abcdefgh", KeyHash: strings.Repeat("a", 64)})
+	s.Error(err, "key hash must be unique")
+
+	found, err := s.repo.APIKey().GetByHash(ctx, strings.Repeat("a", 64))
+	s.NoError(err)
+	s.Equal(key.ID, found.ID)
+
+	_, err = s.repo.APIKey().GetByHash(ctx, strings.Repeat("b", 64))
+	s.ErrorIs(err, domain.ErrNotFound)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	s.NoError(s.repo.APIKey().Touch(ctx, key.ID, now))
+	s.NoError(s.repo.APIKey().Touch(ctx, key.ID, now.Add(30*time.Second)))
+
+	s.NoError(s.repo.APIKey().Revoke(ctx, key.ID, now))
+	s.NoError(s.repo.APIKey().Revoke(ctx, key.ID, now.Add(time.Hour)))
+	s.ErrorIs(s.repo.APIKey().Revoke(ctx, 999, now), domain.ErrNotFound)
+
+	keys, err := s.repo.APIKey().List(ctx)
+	s.NoError(err)
+	s.Require().Len(keys, 1)
+	s.True(now.Equal(*keys[0].LastUsedAt), "use within a minute is not recorded again")
+	s.True(now.Equal(*keys[0].RevokedAt), "second revoke keeps the first revocation time")
+	s.False(keys[0].Active(now.Add(time.Minute)))
+}
+
+// TestGrants_InheritedRoles - test for GrantRepo roles granted on ancestors and replacing grants
+func (s *RepoTestSuite) TestGrants_InheritedRoles() {
+	ctx := context.Background()
+
+	// Sales --> East
+	sales := &models.Department{Name: "Sales"}
+	s.NoError(s.repo.Department().Create(ctx, sales))
+	east := &models.Department{Name: "East", ParentID: &sales.ID}
+	s.NoError(s.repo.Department().Create(ctx, east))
+
+	grant := &models.DepartmentGrant{Subject: "bob", DepartmentID: sales.ID, Role: "viewer"}
+	s.NoError(s.repo.Grant().Save(ctx, grant))
+	s.NoError(s.repo.Grant().Save(ctx, &models.DepartmentGrant{Subject: "bob", DepartmentID: east.ID, Role: "editor"}))
+
+	roles, err := s.repo.Grant().GetRoles(ctx, "bob", east.ID)
+	s.NoError(err)
+	s.ElementsMatch([]string{"viewer", "editor"}, roles, "roles of ancestors apply to the subtree")
+
+	roles, err = s.repo.Grant().GetRoles(ctx, "bob", sales.ID)
+	s.NoError(err)
+	s.Equal([]string{"viewer"}, roles, "roles of descendants do not apply up")
+
+	// Second grant replaces role of subject
+	replaced := &models.DepartmentGrant{Subject: "bob", DepartmentID: sales.ID, Role: "admin"}
+	s.NoError(s.repo.Grant().Save(ctx, replaced))
+	s.Equal(grant.ID, replaced.ID)
+
+	grants, err := s.repo.Grant().ListByDepartment(ctx, sales.ID)
+	s.NoError(err)
+	s.Require().Len(grants, 1)
+	s.Equal("admin", grants[0].Role)
+
+	err = s.repo.Grant().Save(ctx, &models.DepartmentGrant{Subject: "bob", DepartmentID: 999, Role: "viewer"})
+	s.ErrorIs(err, domain.ErrDepartmentNotFound)
+
+	s.ErrorIs(s.repo.Grant().Delete(ctx, east.ID, grant.ID), domain.ErrNotFound, "grant of another department")
+	s.NoError(s.repo.Grant().Delete(ctx, sales.ID, grant.ID))
+
+	// Grants are deleted with department
+	s.NoError(s.repo.Department().Delete(ctx, east.ID))
+	roles, err = s.repo.Grant().GetRoles(ctx, "bob", sales.ID)
+	s.NoError(err)
+	s.Empty(roles)
+}
@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// subtreeCTE - recursive CTE "subtree" with every department under @ids (root_id, id, lvl), roots have lvl 0
+const subtreeCTE = `
+WITH RECURSIVE subtree AS (
+	SELECT d.id AS root_id, d.id, 0 AS lvl
+	FROM departments d
+	WHERE d.id IN @ids
+	UNION ALL
+	SELECT s.root_id, c.id, s.lvl + 1
+	FROM subtree s
+	JOIN departments c ON c.parent_id = s.id
+)`
+
+// GetCounts - get headcount counters for every department in ids, computed over their subtrees
+func (r *departmentRepo) GetCounts(ctx context.Context, ids []int) (map[int]models.DepartmentCounts, error) {
+	const op = "postgres.department.GetCounts"
+
+	counts := make(map[int]models.DepartmentCounts, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	const countsSQL = subtreeCTE + `
+SELECT s.root_id AS department_id,
+	COALESCE(SUM(e.cnt) FILTER (WHERE s.lvl = 0), 0) AS direct_employee_count,
+	COALESCE(SUM(e.cnt), 0) AS total_employee_count,
+	COUNT(*) FILTER (WHERE s.lvl = 1) AS direct_children_count,
+	COUNT(*) FILTER (WHERE s.lvl > 0) AS total_descendant_count,
+	MAX(s.lvl) AS max_depth
+FROM subtree s
+LEFT JOIN LATERAL (
+	SELECT COUNT(*) AS cnt FROM employees WHERE department_id = s.id AND status <> @terminated
+) e ON true
+GROUP BY s.root_id`
+
+	var rows []models.DepartmentCounts
+	if err := r.db.WithContext(ctx).Raw(countsSQL, sql.Named("ids", ids), sql.Named("terminated", domain.EmployeeStatusTerminated)).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to count subtrees of departments: %v: %w", op, ids, err)
+	}
+
+	for _, row := range rows {
+		counts[row.DepartmentID] = row
+	}
+
+	return counts, nil
+}
+
+// GetStats - get aggregated statistics of department subtree
+func (r *departmentRepo) GetStats(ctx context.Context, id int) (*models.DepartmentStats, error) {
+	const op = "postgres.department.GetStats"
+
+	counts, err := r.GetCounts(ctx, []int{id})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stats := &models.DepartmentStats{DepartmentCounts: counts[id]}
+	stats.DepartmentID = id
+
+	const tenureSQL = subtreeCTE + `
+SELECT AVG(CURRENT_DATE - e.hired_at)::float8 AS average_tenure_days,
+	COUNT(e.hired_at) AS employees_with_hire
+FROM subtree s
+JOIN employees e ON e.department_id = s.id AND e.status <> @terminated`
+
+	var tenure struct {
+		AverageTenureDays *float64
+		EmployeesWithHire int64
+	}
+	if err := r.db.WithContext(ctx).Raw(tenureSQL, sql.Named("ids", []int{id}), sql.Named("terminated", domain.EmployeeStatusTerminated)).Scan(&tenure).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to compute tenure of department id: %d: %w", op, id, err)
+	}
+	stats.AverageTenureDays = tenure.AverageTenureDays
+	stats.EmployeesWithHire = tenure.EmployeesWithHire
+
+	const positionsSQL = subtreeCTE + `
+SELECT e.position_id, COALESCE(p.title, e.position) AS position, COUNT(*) AS count
+FROM subtree s
+JOIN employees e ON e.department_id = s.id AND e.status <> @terminated
+LEFT JOIN positions p ON p.id = e.position_id
+GROUP BY e.position_id, COALESCE(p.title, e.position)
+ORDER BY count DESC, position ASC`
+
+	if err := r.db.WithContext(ctx).Raw(positionsSQL, sql.Named("ids", []int{id}), sql.Named("terminated", domain.EmployeeStatusTerminated)).Scan(&stats.Positions).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to count positions of department id: %d: %w", op, id, err)
+	}
+
+	return stats, nil
+}
+
+// GetTotals - get department and employee counts and depth of the deepest department of the whole organization
+func (r *departmentRepo) GetTotals(ctx context.Context) (*models.OrgTotals, error) {
+	const op = "postgres.department.GetTotals"
+
+	const totalsSQL = `
+WITH RECURSIVE tree AS (
+	SELECT d.id, 0 AS lvl
+	FROM departments d
+	WHERE d.parent_id IS NULL
+	UNION ALL
+	SELECT c.id, t.lvl + 1
+	FROM tree t
+	JOIN departments c ON c.parent_id = t.id
+)
+SELECT (SELECT COUNT(*) FROM departments) AS departments,
+	(SELECT COUNT(*) FROM employees WHERE status <> @terminated) AS employees,
+	COALESCE((SELECT MAX(lvl) FROM tree), 0) AS max_depth`
+
+	var totals models.OrgTotals
+	if err := r.db.WithContext(ctx).Raw(totalsSQL, sql.Named("terminated", domain.EmployeeStatusTerminated)).Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to count organization: %w", op, err)
+	}
+
+	return &totals, nil
+}
+
+// GetSubtree - get every department under ids with type and depth relative to them, ids have depth 0
+func (r *departmentRepo) GetSubtree(ctx context.Context, ids []int) ([]models.DepartmentNode, error) {
+	const op = "postgres.department.GetSubtree"
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	const nodesSQL = subtreeCTE + `
+SELECT d.id, d.parent_id, d.type, s.lvl AS depth
+FROM subtree s
+JOIN departments d ON d.id = s.id
+ORDER BY s.lvl ASC, d.id ASC`
+
+	var nodes []models.DepartmentNode
+	if err := r.db.WithContext(ctx).Raw(nodesSQL, sql.Named("ids", ids)).Scan(&nodes).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to get subtrees of departments: %v: %w", op, ids, err)
+	}
+
+	return nodes, nil
+}
+
+// GetBudget - get budget and headcount of department and of every department in its subtree with subtree totals
+func (r *departmentRepo) GetBudget(ctx context.Context, id int) ([]models.DepartmentBudget, error) {
+	const op = "postgres.department.GetBudget"
+
+	const budgetSQL = `
+WITH RECURSIVE tree AS (
+	SELECT d.id, d.parent_id, d.name, d.cost_center, d.budget, d.sort_order, 0 AS depth
+	FROM departments d
+	WHERE d.id = @id
+	UNION ALL
+	SELECT c.id, c.parent_id, c.name, c.cost_center, c.budget, c.sort_order, t.depth + 1
+	FROM tree t
+	JOIN departments c ON c.parent_id = t.id
+), subtree AS (
+	SELECT t.id AS root_id, t.id
+	FROM tree t
+	UNION ALL
+	SELECT s.root_id, c.id
+	FROM subtree s
+	JOIN departments c ON c.parent_id = s.id
+)
+SELECT t.id AS department_id, t.parent_id, t.name, t.cost_center, t.depth, t.budget,
+	COALESCE(SUM(d.budget), 0)::bigint AS total_budget,
+	COALESCE(SUM(e.cnt) FILTER (WHERE s.id = t.id), 0)::bigint AS headcount,
+	COALESCE(SUM(e.cnt), 0)::bigint AS total_headcount
+FROM tree t
+JOIN subtree s ON s.root_id = t.id
+JOIN departments d ON d.id = s.id
+LEFT JOIN LATERAL (
+	SELECT COUNT(*) AS cnt FROM employees WHERE department_id = s.id AND status <> @terminated
+) e ON true
+GROUP BY t.id, t.parent_id, t.name, t.cost_center, t.budget, t.sort_order, t.depth
+ORDER BY t.depth ASC, t.sort_order ASC, t.name ASC, t.id ASC`
+
+	var rows []models.DepartmentBudget
+	err := r.db.WithContext(ctx).
+		Raw(budgetSQL, sql.Named("id", id), sql.Named("terminated", domain.EmployeeStatusTerminated)).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to compute budget of department id: %d: %w", op, id, err)
+	}
+
+	return rows, nil
+}
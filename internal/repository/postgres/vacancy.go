@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type vacancyRepo struct {
+	db *gorm.DB
+}
+
+func newVacancyRepo(db *gorm.DB) *vacancyRepo {
+	return &vacancyRepo{
+		db: db,
+	}
+}
+
+// Create - create a new vacancy
+func (r *vacancyRepo) Create(ctx context.Context, v *models.Vacancy) error {
+	const op = "postgres.vacancy.Create"
+
+	result := r.db.WithContext(ctx).Create(v)
+	if result.Error != nil {
+		return fmt.Errorf("%s: failed to create vacancy: %w", op, result.Error)
+	}
+
+	return nil
+}
+
+// GetByID - get vacancy by ID with its position
+func (r *vacancyRepo) GetByID(ctx context.Context, id int) (*models.Vacancy, error) {
+	const op = "postgres.vacancy.GetByID"
+
+	var v models.Vacancy
+	if err := r.db.WithContext(ctx).Preload("Position").First(&v, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: failed to get vacancy id: %d: %w", op, id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get vacancy id: %d: %w", op, id, err)
+	}
+
+	return &v, nil
+}
+
+// ListByDepartment - list vacancies of department, optionally only with status
+func (r *vacancyRepo) ListByDepartment(ctx context.Context, deptID int, status string) ([]models.Vacancy, error) {
+	const op = "postgres.vacancy.ListByDepartment"
+
+	query := r.db.WithContext(ctx).Preload("Position").Where("department_id = ?", deptID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var vacancies []models.Vacancy
+	if err := query.Order("target_start_date ASC NULLS LAST, id ASC").Find(&vacancies).Error; err != nil {
+		return nil, fmt.Errorf("%s: failed to list vacancies of department id: %d: %w", op, deptID, err)
+	}
+
+	return vacancies, nil
+}
+
+// Update - update vacancy
+func (r *vacancyRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	const op = "postgres.vacancy.Update"
+
+	result := r.db.WithContext(ctx).
+		Model(&models.Vacancy{}).
+		Where("id = ?", id).
+		Updates(updates)
+
+	if result.Error != nil {
+		return fmt.Errorf("%s: failed to update vacancy id: %d: %w", op, id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: failed to update vacancy id: %d: %w", op, id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetStatus - change vacancy status only if it still has status from, so concurrent changes can't both win
+func (r *vacancyRepo) SetStatus(ctx context.Context, id int, from string, to string) error {
+	const op = "postgres.vacancy.SetStatus"
+
+	result := r.db.WithContext(ctx).
+		Model(&models.Vacancy{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+
+	if result.Error != nil {
+		return fmt.Errorf("%s: failed to set status of vacancy id: %d: %w", op, id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: vacancy id: %d has no status '%s': %w", op, id, from, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetHeadcount - get actual and planned headcount of department and of every department in its subtree
+func (r *vacancyRepo) GetHeadcount(ctx context.Context, deptID int) ([]models.DepartmentHeadcount, error) {
+	const op = "postgres.vacancy.GetHeadcount"
+
+	const headcountSQL = `
+WITH RECURSIVE tree AS (
+	SELECT d.id, d.parent_id, d.name, d.sort_order, 0 AS depth
+	FROM departments d
+	WHERE d.id = @id
+	UNION ALL
+	SELECT c.id, c.parent_id, c.name, c.sort_order, t.depth + 1
+	FROM tree t
+	JOIN departments c ON c.parent_id = t.id
+), subtree AS (
+	SELECT t.id AS root_id, t.id
+	FROM tree t
+	UNION ALL
+	SELECT s.root_id, c.id
+	FROM subtree s
+	JOIN departments c ON c.parent_id = s.id
+)
+SELECT t.id AS department_id, t.parent_id, t.name, t.depth,
+	COALESCE(SUM(e.cnt), 0) AS actual_headcount,
+	COALESCE(SUM(v.cnt), 0) AS open_vacancies
+FROM tree t
+JOIN subtree s ON s.root_id = t.id
+LEFT JOIN LATERAL (
+	SELECT COUNT(*) AS cnt FROM employees WHERE department_id = s.id AND status <> @terminated
+) e ON true
+LEFT JOIN LATERAL (
+	SELECT COUNT(*) AS cnt FROM vacancies WHERE department_id = s.id AND status = @status
+) v ON true
+GROUP BY t.id, t.parent_id, t.name, t.sort_order, t.depth
+ORDER BY t.depth ASC, t.sort_order ASC, t.name ASC, t.id ASC`
+
+	var rows []models.DepartmentHeadcount
+	err := r.db.WithContext(ctx).
+		Raw(headcountSQL, sql.Named("id", deptID), sql.Named("status", domain.VacancyStatusOpen), sql.Named("terminated", domain.EmployeeStatusTerminated)).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to compute headcount of department id: %d: %w", op, deptID, err)
+	}
+
+	return rows, nil
+}
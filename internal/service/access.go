@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// authorize - check principal has role on department granted on it or any of its ancestors.
+// Admin principals and trusted calls made without authentication are allowed everything,
+// nil department is the root level which only admin principals may change
+func authorize(ctx context.Context, repo domain.Repository, role string, deptID *int) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok || p.Admin {
+		return nil
+	}
+
+	if deptID == nil {
+		return fmt.Errorf("'%s' may not change root departments: %w", p.Subject, domain.ErrForbidden)
+	}
+
+	roles, err := repo.Grant().GetRoles(ctx, p.Subject, *deptID)
+	if err != nil {
+		return fmt.Errorf("failed to get roles of '%s': %w", p.Subject, err)
+	}
+	for _, granted := range roles {
+		if domain.RoleRank(granted) >= domain.RoleRank(role) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("'%s' has no %s role on department '%d': %w", p.Subject, role, *deptID, domain.ErrForbidden)
+}
+
+// requireAdmin - allow calls of admin principals and trusted calls made without authentication
+func requireAdmin(ctx context.Context) error {
+	if p, ok := domain.PrincipalFromContext(ctx); ok && !p.Admin {
+		return fmt.Errorf("admin role required for '%s': %w", p.Subject, domain.ErrForbidden)
+	}
+	return nil
+}
+
+// viewFilter - decides which departments principal may view, roles of each department are loaded once
+type viewFilter struct {
+	repo domain.Repository
+	// all - admin principal or trusted call, every department is visible
+	all     bool
+	visible map[int]bool
+}
+
+func newViewFilter(ctx context.Context, repo domain.Repository) *viewFilter {
+	p, ok := domain.PrincipalFromContext(ctx)
+	return &viewFilter{repo: repo, all: !ok || p.Admin, visible: make(map[int]bool)}
+}
+
+// can - check principal has viewer role on department
+func (f *viewFilter) can(ctx context.Context, deptID int) (bool, error) {
+	if f.all {
+		return true, nil
+	}
+	if visible, ok := f.visible[deptID]; ok {
+		return visible, nil
+	}
+
+	err := authorize(ctx, f.repo, domain.RoleViewer, &deptID)
+	if err != nil && !errors.Is(err, domain.ErrForbidden) {
+		return false, err
+	}
+	f.visible[deptID] = err == nil
+	return err == nil, nil
+}
+
+// employees - employees of departments principal may view
+func (f *viewFilter) employees(ctx context.Context, emps []models.Employee) ([]models.Employee, error) {
+	if f.all {
+		return emps, nil
+	}
+
+	visible := make([]models.Employee, 0, len(emps))
+	for _, emp := range emps {
+		ok, err := f.can(ctx, emp.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			visible = append(visible, emp)
+		}
+	}
+	return visible, nil
+}
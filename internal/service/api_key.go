@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// apiKeyPrefixLen - length of the key start stored to identify the key in lists
+const apiKeyPrefixLen = 12
+
+type apiKeyService struct {
+	repo     domain.Repository
+	log      *slog.Logger
+	validate *validator.Validate
+	now      func() time.Time
+}
+
+func newAPIKeyService(
+	repo domain.Repository,
+	log *slog.Logger,
+	validate *validator.Validate,
+) domain.APIKeyService {
+	return &apiKeyService{repo: repo, log: log, validate: validate, now: time.Now}
+}
+
+// Create - Issue a new API key, only admins may issue keys
+func (s *apiKeyService) Create(ctx context.Context, req *dto.CreateAPIKeyRequest) (*dto.APIKeyCreatedResponse, error) {
+	const op = "service.apiKey.Create"
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Trimming space
+	req.Name = strings.TrimSpace(req.Name)
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(s.now()) {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrInvalidExpiresAt)
+	}
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to generate api key: %w", op, err)
+	}
+
+	// Mapping DTO to Model
+	key := &models.APIKey{
+		Name:      req.Name,
+		Prefix:    secret[:apiKeyPrefixLen],
+		KeyHash:   hashAPIKey(secret),
+		IsAdmin:   req.IsAdmin,
+		Scopes:    strings.Join(req.Scopes, " "),
+		ExpiresAt: req.ExpiresAt,
+	}
+	if p, ok := domain.PrincipalFromContext(ctx); ok {
+		key.CreatedBy = &p.Subject
+	}
+
+	// Go to repo
+	if err := s.repo.APIKey().Create(ctx, key); err != nil {
+		return nil, fmt.Errorf("%s: failed to create api key: %w", op, err)
+	}
+
+	// Mapping model to DTO, the key is returned only here
+	return &dto.APIKeyCreatedResponse{APIKeyResponse: dto.NewAPIKeyResponse(*key), Key: secret}, nil
+}
+
+// List - List issued API keys without the keys themselves
+func (s *apiKeyService) List(ctx context.Context) ([]dto.APIKeyResponse, error) {
+	const op = "service.apiKey.List"
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	keys, err := s.repo.APIKey().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list api keys: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		resp[i] = dto.NewAPIKeyResponse(key)
+	}
+	return resp, nil
+}
+
+// Revoke - Revoke API key, requests with it are rejected from now on
+func (s *apiKeyService) Revoke(ctx context.Context, id int) error {
+	const op = "service.apiKey.Revoke"
+
+	if err := requireAdmin(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.repo.APIKey().Revoke(ctx, id, s.now()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%s: api key with id '%d' not found: %w", op, id, domain.ErrAPIKeyNotFound)
+		}
+		return fmt.Errorf("%s: failed to revoke api key: %w", op, err)
+	}
+
+	return nil
+}
+
+// Authenticate - Resolve principal of an active API key
+func (s *apiKeyService) Authenticate(ctx context.Context, token string) (*models.Principal, error) {
+	const op = "service.apiKey.Authenticate"
+
+	if !strings.HasPrefix(token, domain.APIKeyPrefix) {
+		return nil, fmt.Errorf("%s: not an api key: %w", op, domain.ErrUnauthenticated)
+	}
+
+	key, err := s.repo.APIKey().GetByHash(ctx, hashAPIKey(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%s: unknown api key: %w", op, domain.ErrUnauthenticated)
+		}
+		return nil, fmt.Errorf("%s: failed to get api key: %w", op, err)
+	}
+
+	now := s.now()
+	if !key.Active(now) {
+		return nil, fmt.Errorf("%s: api key '%s' is revoked or expired: %w", op, key.Prefix, domain.ErrUnauthenticated)
+	}
+
+	// Last use is informational, the request goes on without it
+	if err := s.repo.APIKey().Touch(ctx, key.ID, now); err != nil {
+		s.log.WarnContext(ctx, "failed to record api key use", slog.String("op", op), slog.String("err", err.Error()))
+	}
+
+	return &models.Principal{
+		Subject: fmt.Sprintf("api-key:%d", key.ID),
+		Method:  domain.AuthMethodAPIKey,
+		Admin:   key.IsAdmin,
+		Scopes:  strings.Fields(key.Scopes),
+	}, nil
+}
+
+// generateAPIKey - random key with APIKeyPrefix and 256 bits of entropy
+func generateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return domain.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashAPIKey - hex SHA-256 of key, keys are random so no salt is needed
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// ListAssignments - List department assignments of employee, primary first
+func (s *employeeService) ListAssignments(ctx context.Context, id int) ([]dto.AssignmentResponse, error) {
+	const op = "service.employee.ListAssignments"
+
+	if _, err := s.getEmployee(ctx, id, domain.RoleViewer); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo
+	assignments, err := s.repo.Assignment().ListByEmployee(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list assignments: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.AssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		resp[i] = dto.NewAssignmentResponse(a)
+	}
+	return resp, nil
+}
+
+// CreateAssignment - Assign employee to a department, assignment in the home department is the primary one
+func (s *employeeService) CreateAssignment(ctx context.Context, id int, req *dto.CreateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	const op = "service.employee.CreateAssignment"
+
+	// Trimming space
+	req.Role = strings.TrimSpace(req.Role)
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	emp, err := s.getEmployee(ctx, id, domain.RoleEditor)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if emp.Status == domain.EmployeeStatusTerminated {
+		return nil, fmt.Errorf("%s: employee with id '%d': %w", op, id, domain.ErrEmployeeTerminated)
+	}
+
+	// Primary flag only in the home department
+	if req.IsPrimary && req.DepartmentID != emp.DepartmentID {
+		return nil, fmt.Errorf("%s: department '%d' is not home department '%d': %w", op, req.DepartmentID, emp.DepartmentID, domain.ErrInvalidPrimary)
+	}
+
+	// Check department exists
+	exists, err := s.repo.Department().Exists(ctx, req.DepartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check department existence: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: department with id '%d' not found: %w", op, req.DepartmentID, domain.ErrDepartmentNotFound)
+	}
+	if err := authorize(ctx, s.repo, domain.RoleEditor, &req.DepartmentID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Mapping DTO to model
+	assignment := &models.EmployeeAssignment{
+		EmployeeID:        id,
+		DepartmentID:      req.DepartmentID,
+		Role:              req.Role,
+		AllocationPercent: req.AllocationPercent,
+		IsPrimary:         req.DepartmentID == emp.DepartmentID,
+	}
+
+	// Check allocations and create under lock of employee, so concurrent requests can't exceed the limit together
+	err = s.repo.Transaction(ctx, func(repo domain.Repository) error {
+		if err := lockEmployee(ctx, repo, id); err != nil {
+			return err
+		}
+
+		// Check assignment unique
+		assignments, err := repo.Assignment().ListByEmployee(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to list assignments: %w", err)
+		}
+		total := req.AllocationPercent
+		for _, a := range assignments {
+			if a.DepartmentID == req.DepartmentID {
+				return fmt.Errorf("employee '%d' already assigned to department '%d': %w", id, req.DepartmentID, domain.ErrAlreadyExist)
+			}
+			total += a.AllocationPercent
+		}
+
+		// Check allocations sum
+		if total > domain.MaxAllocationPercent {
+			return fmt.Errorf("allocations of employee '%d' sum to %d%%: %w", id, total, domain.ErrAllocationConstraint)
+		}
+
+		// Go to repo
+		if err := repo.Assignment().Create(ctx, assignment); err != nil {
+			return fmt.Errorf("failed to create assignment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewAssignmentResponse(*assignment)
+	return &resp, nil
+}
+
+// UpdateAssignment - Update role or allocation of employee assignment
+func (s *employeeService) UpdateAssignment(ctx context.Context, id int, assignmentID int, req *dto.UpdateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	const op = "service.employee.UpdateAssignment"
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	assignment, err := s.getAssignment(ctx, id, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Role != nil {
+		assignment.Role = strings.TrimSpace(*req.Role)
+		updates["role"] = assignment.Role
+	}
+
+	if req.AllocationPercent != nil {
+		assignment.AllocationPercent = *req.AllocationPercent
+		updates["allocation_percent"] = assignment.AllocationPercent
+	}
+
+	// If no fields to update
+	if len(updates) == 0 {
+		resp := dto.NewAssignmentResponse(*assignment)
+		return &resp, nil
+	}
+
+	// Check allocations and update under lock of employee, so concurrent requests can't exceed the limit together
+	err = s.repo.Transaction(ctx, func(repo domain.Repository) error {
+		if req.AllocationPercent != nil {
+			if err := lockEmployee(ctx, repo, id); err != nil {
+				return err
+			}
+
+			// Check allocations sum
+			sum, err := repo.Assignment().SumAllocation(ctx, id, assignmentID)
+			if err != nil {
+				return fmt.Errorf("failed to sum allocations: %w", err)
+			}
+			if total := sum + *req.AllocationPercent; total > domain.MaxAllocationPercent {
+				return fmt.Errorf("allocations of employee '%d' sum to %d%%: %w", id, total, domain.ErrAllocationConstraint)
+			}
+		}
+
+		// Go to repo to update
+		if err := repo.Assignment().Update(ctx, assignmentID, updates); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("failed to update assignment: %w", domain.ErrAssignmentNotFound)
+			}
+			return fmt.Errorf("failed to update assignment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewAssignmentResponse(*assignment)
+	return &resp, nil
+}
+
+// DeleteAssignment - Remove employee assignment
+func (s *employeeService) DeleteAssignment(ctx context.Context, id int, assignmentID int) error {
+	const op = "service.employee.DeleteAssignment"
+
+	if _, err := s.getAssignment(ctx, id, assignmentID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo
+	if err := s.repo.Assignment().Delete(ctx, assignmentID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%s: failed to delete assignment: %w", op, domain.ErrAssignmentNotFound)
+		}
+		return fmt.Errorf("%s: failed to delete assignment: %w", op, err)
+	}
+
+	return nil
+}
+
+// lockEmployee - lock employee row in transaction of repo mapping not found error
+func lockEmployee(ctx context.Context, repo domain.Repository, id int) error {
+	if err := repo.Employee().Lock(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("employee with id '%d' not found: %w", id, domain.ErrEmployeeNotFound)
+		}
+		return fmt.Errorf("failed to lock employee: %w", err)
+	}
+	return nil
+}
+
+// getAssignment - get assignment of employee by id mapping not found error, principal must be editor of its department
+func (s *employeeService) getAssignment(ctx context.Context, employeeID int, assignmentID int) (*models.EmployeeAssignment, error) {
+	assignment, err := s.repo.Assignment().GetByID(ctx, assignmentID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("assignment with id '%d' not found: %w", assignmentID, domain.ErrAssignmentNotFound)
+		}
+		return nil, fmt.Errorf("failed to get assignment: %w", err)
+	}
+
+	// Assignment of another employee
+	if assignment.EmployeeID != employeeID {
+		return nil, fmt.Errorf("assignment with id '%d' not found for employee '%d': %w", assignmentID, employeeID, domain.ErrAssignmentNotFound)
+	}
+
+	// Check rights on department of assignment
+	if err := authorize(ctx, s.repo, domain.RoleEditor, &assignment.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// attributeNamePattern - attribute names are snake_case to be usable as attr.<name> query params
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type attributeService struct {
+	repo     domain.Repository
+	log      *slog.Logger
+	validate *validator.Validate
+}
+
+func newAttributeService(
+	repo domain.Repository,
+	log *slog.Logger,
+	validate *validator.Validate,
+) domain.AttributeService {
+	return &attributeService{repo: repo, log: log, validate: validate}
+}
+
+// Create - Define a new custom attribute of departments or employees
+func (s *attributeService) Create(ctx context.Context, req *dto.CreateAttributeRequest) (*dto.AttributeResponse, error) {
+	const op = "service.attribute.Create"
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Trimming space
+	req.Name = strings.TrimSpace(req.Name)
+	req.EnumValues = trimValues(req.EnumValues)
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+	if !attributeNamePattern.MatchString(req.Name) {
+		return nil, fmt.Errorf("%s: attribute name '%s' must be snake_case: %w", op, req.Name, domain.ErrInvalidAttribute)
+	}
+	if req.Type != domain.AttributeTypeEnum && len(req.EnumValues) > 0 {
+		return nil, fmt.Errorf("%s: enum values of %s attribute: %w", op, req.Type, domain.ErrInvalidAttribute)
+	}
+
+	// Check name unique
+	existing, err := s.repo.Attribute().GetByName(ctx, req.EntityType, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check attribute name uniqueness: %w", op, err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%s: %s attribute '%s' already exists: %w", op, req.EntityType, req.Name, domain.ErrDuplicateName)
+	}
+
+	// Mapping DTO to Model
+	def := &models.AttributeDefinition{
+		EntityType: req.EntityType,
+		Name:       req.Name,
+		Type:       req.Type,
+		Required:   req.Required,
+		EnumValues: req.EnumValues,
+	}
+
+	// Go to repo
+	if err := s.repo.Attribute().Create(ctx, def); err != nil {
+		return nil, fmt.Errorf("%s: failed to create attribute: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewAttributeResponse(*def)
+	return &resp, nil
+}
+
+// List - List custom attributes, optionally of one entity type
+func (s *attributeService) List(ctx context.Context, req *dto.ListAttributesRequest) ([]dto.AttributeResponse, error) {
+	const op = "service.attribute.List"
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	defs, err := s.repo.Attribute().List(ctx, req.EntityType)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list attributes: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.AttributeResponse, len(defs))
+	for i, def := range defs {
+		resp[i] = dto.NewAttributeResponse(def)
+	}
+	return resp, nil
+}
+
+// Update - Update required flag or enum values of custom attribute,
+// stored values are not revalidated and are checked on the next write
+func (s *attributeService) Update(ctx context.Context, id int, req *dto.UpdateAttributeRequest) (*dto.AttributeResponse, error) {
+	const op = "service.attribute.Update"
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.EnumValues = trimValues(req.EnumValues)
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	def, err := s.getAttribute(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updates := make(map[string]interface{})
+	if req.Required != nil {
+		updates["required"] = *req.Required
+	}
+	if req.EnumValues != nil {
+		if def.Type != domain.AttributeTypeEnum {
+			return nil, fmt.Errorf("%s: enum values of %s attribute: %w", op, def.Type, domain.ErrInvalidAttribute)
+		}
+		updates["enum_values"] = models.StringList(req.EnumValues)
+	}
+
+	// Go to repo to update
+	if len(updates) > 0 {
+		if err := s.repo.Attribute().Update(ctx, id, updates); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%s: failed to update attribute: %w", op, domain.ErrAttributeNotFound)
+			}
+			return nil, fmt.Errorf("%s: failed to update attribute: %w", op, err)
+		}
+
+		if def, err = s.getAttribute(ctx, id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewAttributeResponse(*def)
+	return &resp, nil
+}
+
+// Delete - Delete custom attribute with its values
+func (s *attributeService) Delete(ctx context.Context, id int) error {
+	const op = "service.attribute.Delete"
+
+	if err := requireAdmin(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.repo.Attribute().Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%s: failed to delete attribute: %w", op, domain.ErrAttributeNotFound)
+		}
+		return fmt.Errorf("%s: failed to delete attribute: %w", op, err)
+	}
+
+	return nil
+}
+
+// getAttribute - get attribute definition by id
+func (s *attributeService) getAttribute(ctx context.Context, id int) (*models.AttributeDefinition, error) {
+	def, err := s.repo.Attribute().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("attribute with id '%d' not found: %w", id, domain.ErrAttributeNotFound)
+		}
+		return nil, fmt.Errorf("failed to get attribute: %w", err)
+	}
+	return def, nil
+}
+
+// checkAttributes - check attribute values against the schema of entity type,
+// every value must be defined with matching type and every required attribute must be present
+func checkAttributes(ctx context.Context, repo domain.Repository, entityType string, attrs map[string]interface{}) error {
+	defs, err := repo.Attribute().List(ctx, entityType)
+	if err != nil {
+		return fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	byName := make(map[string]models.AttributeDefinition, len(defs))
+	for _, def := range defs {
+		byName[def.Name] = def
+	}
+
+	for name, value := range attrs {
+		def, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown %s attribute '%s': %w", entityType, name, domain.ErrInvalidAttribute)
+		}
+		if !attributeValueValid(def, value) {
+			return fmt.Errorf("value of attribute '%s' is not a valid %s: %w", name, def.Type, domain.ErrInvalidAttribute)
+		}
+	}
+
+	for _, def := range defs {
+		if _, ok := attrs[def.Name]; def.Required && !ok {
+			return fmt.Errorf("required attribute '%s' is missing: %w", def.Name, domain.ErrInvalidAttribute)
+		}
+	}
+
+	return nil
+}
+
+// attributeFilter - parse raw filter values by type of attribute of entity type
+func attributeFilter(ctx context.Context, repo domain.Repository, entityType string, raw map[string]string) (models.Attributes, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	defs, err := repo.Attribute().List(ctx, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	byName := make(map[string]models.AttributeDefinition, len(defs))
+	for _, def := range defs {
+		byName[def.Name] = def
+	}
+
+	filter := make(models.Attributes, len(raw))
+	for name, rawValue := range raw {
+		def, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown %s attribute '%s': %w", entityType, name, domain.ErrInvalidAttribute)
+		}
+
+		value, err := parseAttributeValue(def, rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("filter value of attribute '%s' is not a valid %s: %w", name, def.Type, domain.ErrInvalidAttribute)
+		}
+		filter[name] = value
+	}
+
+	return filter, nil
+}
+
+// attributeValueValid - check decoded JSON value matches attribute type
+func attributeValueValid(def models.AttributeDefinition, value interface{}) bool {
+	switch def.Type {
+	case domain.AttributeTypeString:
+		_, ok := value.(string)
+		return ok
+	case domain.AttributeTypeNumber:
+		_, ok := value.(float64)
+		return ok
+	case domain.AttributeTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case domain.AttributeTypeEnum:
+		str, ok := value.(string)
+		return ok && slices.Contains(def.EnumValues, str)
+	default:
+		return false
+	}
+}
+
+// parseAttributeValue - parse query param value by attribute type
+func parseAttributeValue(def models.AttributeDefinition, raw string) (interface{}, error) {
+	switch def.Type {
+	case domain.AttributeTypeNumber:
+		return strconv.ParseFloat(raw, 64)
+	case domain.AttributeTypeBoolean:
+		return strconv.ParseBool(raw)
+	case domain.AttributeTypeEnum:
+		if !slices.Contains(def.EnumValues, raw) {
+			return nil, fmt.Errorf("'%s' is not one of enum values", raw)
+		}
+		return raw, nil
+	default:
+		return raw, nil
+	}
+}
+
+// trimValues - trim space of every value
+func trimValues(values []string) []string {
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
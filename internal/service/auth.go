@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/tmozzze/org_struct_api/internal/config"
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// bootstrapSubject - subject of principal authenticated by the bootstrap key
+const bootstrapSubject = "bootstrap"
+
+// authenticator - resolve principal from bootstrap key, API key or JWT
+type authenticator struct {
+	bootstrapKey string
+	keys         domain.Authenticator
+	jwt          *jwtVerifier
+	log          *slog.Logger
+}
+
+// NewAuthenticator - constructor for Authenticator of API requests, loads JWT keys from cfg
+func NewAuthenticator(cfg config.AuthCfg, keys domain.Authenticator, log *slog.Logger) (domain.Authenticator, error) {
+	const op = "service.NewAuthenticator"
+
+	verifier, err := newJWTVerifier(cfg.JWT)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &authenticator{
+		bootstrapKey: cfg.BootstrapKey,
+		keys:         keys,
+		jwt:          verifier,
+		log:          log,
+	}, nil
+}
+
+// Authenticate - resolve principal of token
+func (a *authenticator) Authenticate(ctx context.Context, token string) (*models.Principal, error) {
+	const op = "service.auth.Authenticate"
+
+	if token == "" {
+		return nil, fmt.Errorf("%s: no credentials: %w", op, domain.ErrUnauthenticated)
+	}
+
+	if a.bootstrapKey != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.bootstrapKey)) == 1 {
+		return &models.Principal{Subject: bootstrapSubject, Method: domain.AuthMethodAPIKey, Admin: true}, nil
+	}
+
+	if strings.HasPrefix(token, domain.APIKeyPrefix) {
+		return a.keys.Authenticate(ctx, token)
+	}
+
+	if a.jwt == nil {
+		return nil, fmt.Errorf("%s: jwt authentication is not configured: %w", op, domain.ErrUnauthenticated)
+	}
+	return a.jwt.Authenticate(ctx, token)
+}
+
+// jwtVerifier - verify signature and claims of JWT bearer tokens
+type jwtVerifier struct {
+	hmacSecret []byte
+	// rsaKeys - RSA public keys by kid, key of PEM file has empty kid
+	rsaKeys     map[string]*rsa.PublicKey
+	parser      *jwt.Parser
+	rolesClaim  string
+	adminRole   string
+	scopesClaim string
+}
+
+// newJWTVerifier - nil verifier when no JWT key is configured
+func newJWTVerifier(cfg config.JWTCfg) (*jwtVerifier, error) {
+	v := &jwtVerifier{
+		rsaKeys:     make(map[string]*rsa.PublicKey),
+		rolesClaim:  cfg.RolesClaim,
+		adminRole:   cfg.AdminRole,
+		scopesClaim: cfg.ScopesClaim,
+	}
+
+	var methods []string
+	if cfg.HMACSecret != "" {
+		v.hmacSecret = []byte(cfg.HMACSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if cfg.RSAPublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.RSAPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rsa public key: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rsa public key: %w", err)
+		}
+		v.rsaKeys[""] = key
+	}
+
+	if cfg.JWKSFile != "" {
+		data, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwks: %w", err)
+		}
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse jwks: %w", err)
+		}
+		for kid, key := range keys {
+			v.rsaKeys[kid] = key
+		}
+	}
+
+	if len(v.rsaKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, nil
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+
+	return v, nil
+}
+
+// Authenticate - principal of valid token, admin when roles claim contains admin role, scopes of scopes claim
+func (v *jwtVerifier) Authenticate(_ context.Context, token string) (*models.Principal, error) {
+	const op = "service.jwt.Authenticate"
+
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return nil, fmt.Errorf("%s: invalid token: %v: %w", op, err, domain.ErrUnauthenticated)
+	}
+
+	sub, err := claims.GetSubject()
+	if err != nil || sub == "" {
+		return nil, fmt.Errorf("%s: token has no subject: %w", op, domain.ErrUnauthenticated)
+	}
+
+	return &models.Principal{
+		Subject: sub,
+		Method:  domain.AuthMethodJWT,
+		Admin:   slices.Contains(listClaim(claims, v.rolesClaim), v.adminRole),
+		Scopes:  listClaim(claims, v.scopesClaim),
+	}, nil
+}
+
+// key - verification key of token by its alg and kid
+func (v *jwtVerifier) key(t *jwt.Token) (interface{}, error) {
+	switch t.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return v.hmacSecret, nil
+	case *jwt.SigningMethodRSA:
+		kid, _ := t.Header["kid"].(string)
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		// Token without known kid is verified with the only key
+		if len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id '%s'", kid)
+	}
+	return nil, errors.New("unexpected signing method")
+}
+
+// listClaim - claim as list of strings or space separated string
+func listClaim(claims jwt.MapClaims, name string) []string {
+	switch raw := claims[name].(type) {
+	case string:
+		return strings.Fields(raw)
+	case []interface{}:
+		values := make([]string, 0, len(raw))
+		for _, r := range raw {
+			if s, ok := r.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// parseJWKS - RSA public keys of JSON Web Key Set by kid, other key types are skipped
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': invalid modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': invalid exponent: %w", k.Kid, err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key '%s': exponent is too large", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no RSA signing keys")
+	}
+	return keys, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/tmozzze/org_struct_api/internal/domain"
 	"github.com/tmozzze/org_struct_api/internal/domain/dto"
 	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"golang.org/x/text/unicode/norm"
 )
 
 type departmentService struct {
@@ -31,23 +32,39 @@ func newDepartmentService(
 func (s *departmentService) Create(ctx context.Context, req *dto.CreateDepartmentRequest) (*dto.DepartmentResponse, error) {
 	const op = "service.department.Create"
 
-	// Trimming space
-	req.Name = strings.TrimSpace(req.Name)
+	// Normalising name and cost center
+	req.Name = normalizeName(req.Name)
+	req.CostCenter = normalizeCostCenter(req.CostCenter)
 
 	// Validation DTO
 	if err := s.validate.Struct(req); err != nil {
 		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
 	}
 	// Check parent department exists
+	var parent *models.Department
 	if req.ParentID != nil {
-		exists, err := s.repo.Department().Exists(ctx, *req.ParentID)
+		var err error
+		parent, err = s.repo.Department().GetByIDSimple(ctx, *req.ParentID)
 		if err != nil {
-			return nil, fmt.Errorf("%s: failed to check parent department existence: %w", op, err)
-		}
-		if !exists {
-			return nil, fmt.Errorf("%s: parent department not found: %w", op, domain.ErrParentNotFound)
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%s: parent department not found: %w", op, domain.ErrParentNotFound)
+			}
+			return nil, fmt.Errorf("%s: failed to get parent department: %w", op, err)
 		}
+	}
 
+	// Check rights on parent department
+	if err := authorize(ctx, s.repo, domain.RoleEditor, req.ParentID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Check type and hierarchy rules
+	req.Type = trimOptional(req.Type)
+	if err := s.checkType(ctx, req.Type); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.checkHierarchy(ctx, parent, []models.DepartmentNode{{Type: req.Type}}, 0); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	// Check name unique
@@ -56,13 +73,39 @@ func (s *departmentService) Create(ctx context.Context, req *dto.CreateDepartmen
 		return nil, fmt.Errorf("%s: failed to check department name uniqueness: %w", op, err)
 	}
 	if existing != nil {
-		return nil, fmt.Errorf("%s: department with name '%s' already exists: %w", op, req.Name, domain.ErrDuplicateName)
+		return nil, fmt.Errorf("%s: department with name '%s' already exists: %w", op, req.Name, duplicateName(existing))
+	}
+
+	// Check cost center unique
+	if err := s.checkCostCenter(ctx, req.CostCenter, 0); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Check custom attributes
+	if err := checkAttributes(ctx, s.repo, domain.AttributeEntityDepartment, req.Attributes); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Place after siblings unless sort order is given
+	var sortOrder int
+	if req.SortOrder != nil {
+		sortOrder = *req.SortOrder
+	} else {
+		sortOrder, err = s.repo.Department().NextSortOrder(ctx, req.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to get sort order: %w", op, err)
+		}
 	}
 
 	// Mapping DTO to Model
 	dept := &models.Department{
-		Name:     req.Name,
-		ParentID: req.ParentID,
+		Name:       req.Name,
+		ParentID:   req.ParentID,
+		Type:       req.Type,
+		SortOrder:  sortOrder,
+		Attributes: req.Attributes,
+		CostCenter: req.CostCenter,
+		Budget:     req.Budget,
 	}
 
 	// Go to repo
@@ -85,13 +128,24 @@ func (s *departmentService) GetByID(ctx context.Context, id int, req *dto.GetByI
 		req.Depth = 5
 	}
 
+	// Set default and max limits
+	req.ChildrenLimit = pageLimit(req.ChildrenLimit)
+	req.EmployeesLimit = pageLimit(req.EmployeesLimit)
+
 	// Validation DTO
 	if err := s.validate.Struct(req); err != nil {
 		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
 	}
 
 	// Go to repo
-	dept, err := s.repo.Department().GetByID(ctx, id, req.Depth, req.IncludeEmployees)
+	dept, err := s.repo.Department().GetByID(ctx, id, domain.TreeOptions{
+		Depth:             req.Depth,
+		IncludeEmployees:  req.IncludeEmployees,
+		ChildrenLimit:     req.ChildrenLimit,
+		EmployeesLimit:    req.EmployeesLimit,
+		IncludeSecondary:  req.IncludeSecondary,
+		IncludeTerminated: req.IncludeTerminated,
+	})
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, fmt.Errorf("%s: failed to get department by id: %w", op, domain.ErrDepartmentNotFound)
@@ -99,8 +153,23 @@ func (s *departmentService) GetByID(ctx context.Context, id int, req *dto.GetByI
 		return nil, fmt.Errorf("%s: failed to get department by id: %w", op, err)
 	}
 
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleViewer, &id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	// Mapping model to DTO
-	resp := dto.NewDepartmentResponse(*dept)
+	resp := dto.NewDepartmentTreeResponse(*dept, req.ChildrenLimit, req.EmployeesLimit)
+
+	// Headcount counters for every department in the response
+	if req.IncludeStats {
+		counts, err := s.repo.Department().GetCounts(ctx, resp.IDs())
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to get department counts: %w", op, err)
+		}
+		resp.SetCounts(counts)
+	}
+
 	return &resp, nil
 }
 
@@ -108,6 +177,15 @@ func (s *departmentService) GetByID(ctx context.Context, id int, req *dto.GetByI
 func (s *departmentService) Update(ctx context.Context, id int, req *dto.UpdateDepartmentRequest) (*dto.DepartmentResponse, error) {
 	const op = "service.department.Update"
 
+	// Normalising cost center, empty one is kept to remove it
+	if req.CostCenter != nil {
+		costCenter := ""
+		if normalized := normalizeCostCenter(req.CostCenter); normalized != nil {
+			costCenter = *normalized
+		}
+		req.CostCenter = &costCenter
+	}
+
 	// Validation DTO
 	if err := s.validate.Struct(req); err != nil {
 		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
@@ -122,15 +200,21 @@ func (s *departmentService) Update(ctx context.Context, id int, req *dto.UpdateD
 		return nil, fmt.Errorf("%s: failed to get current department: %w", op, err)
 	}
 
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleEditor, &id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	updates := make(map[string]interface{})
 
-	// Trimming space
+	// Normalising name
 	if req.Name != nil {
-		trimmedName := strings.TrimSpace(*req.Name)
-		if trimmedName == "" {
+		name := normalizeName(*req.Name)
+		if name == "" {
 			return nil, domain.ErrEmptyConstraint
 		}
-		updates["name"] = trimmedName
+		req.Name = &name
+		updates["name"] = name
 	}
 
 	// Check parent department
@@ -153,9 +237,58 @@ func (s *departmentService) Update(ctx context.Context, id int, req *dto.UpdateD
 			return nil, fmt.Errorf("%s: failed to check cycle constraint: %w", op, err)
 		}
 
+		// Moving requires rights on both old and new parent
+		if derefID(current.ParentID) != newParentID {
+			if err := authorize(ctx, s.repo, domain.RoleEditor, current.ParentID); err != nil {
+				return nil, fmt.Errorf("%s: old parent: %w", op, err)
+			}
+			if err := authorize(ctx, s.repo, domain.RoleEditor, &newParentID); err != nil {
+				return nil, fmt.Errorf("%s: new parent: %w", op, err)
+			}
+		}
+
 		updates["parent_id"] = newParentID
 	}
 
+	// Check type and hierarchy rules of moved or retyped subtree
+	if req.Type != nil {
+		newType := trimOptional(req.Type)
+		if err := s.checkType(ctx, newType); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		updates["type"] = newType
+	}
+	if err := s.checkUpdateHierarchy(ctx, current, updates); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Check custom attributes
+	if req.Attributes != nil {
+		if err := checkAttributes(ctx, s.repo, domain.AttributeEntityDepartment, req.Attributes); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		updates["attributes"] = models.Attributes(req.Attributes)
+	}
+
+	if req.SortOrder != nil {
+		updates["sort_order"] = *req.SortOrder
+	}
+
+	// Check cost center unique, empty removes it
+	if req.CostCenter != nil {
+		costCenter := normalizeCostCenter(req.CostCenter)
+		if err := s.checkCostCenter(ctx, costCenter, id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		updates["cost_center"] = costCenter
+	}
+	if req.Budget != nil {
+		updates["budget"] = *req.Budget
+	}
+	if req.ClearBudget {
+		updates["budget"] = nil
+	}
+
 	// If no fields to update
 	if len(updates) == 0 {
 		resp := dto.NewDepartmentResponse(*current)
@@ -165,7 +298,7 @@ func (s *departmentService) Update(ctx context.Context, id int, req *dto.UpdateD
 	// Check name unique
 	nameToCheck := current.Name
 	if req.Name != nil {
-		nameToCheck = strings.TrimSpace(*req.Name)
+		nameToCheck = *req.Name
 	}
 
 	parentIDToCheck := current.ParentID
@@ -178,19 +311,40 @@ func (s *departmentService) Update(ctx context.Context, id int, req *dto.UpdateD
 		return nil, fmt.Errorf("%s: failed to check department name unique constraint: %w", op, err)
 	}
 	if existing != nil && existing.ID != id {
-		return nil, fmt.Errorf("%s: department with name '%s' and parent_id '%d' already exists: %w", op, nameToCheck, parentIDToCheck, domain.ErrDuplicateName)
+		return nil, fmt.Errorf("%s: department with name '%s' and parent_id '%d' already exists: %w", op, nameToCheck, derefID(parentIDToCheck), duplicateName(existing))
 	}
 
-	// Go to repo to update
-	if err := s.repo.Department().Update(ctx, id, updates); err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			return nil, fmt.Errorf("%s: failed to update department: %w", op, domain.ErrDepartmentNotFound)
+	// Moved department goes after its new siblings unless sort order is given
+	moved := req.ParentID != nil && derefID(current.ParentID) != *req.ParentID
+	if req.SortOrder == nil && moved {
+		next, err := s.repo.Department().NextSortOrder(ctx, req.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to get sort order: %w", op, err)
 		}
-		return nil, fmt.Errorf("%s: failed to update department: %w", op, err)
+		updates["sort_order"] = next
+	}
+
+	// Go to repo to update, heads of moved subtree working under old ancestors lose the post
+	err = s.repo.Transaction(ctx, func(repo domain.Repository) error {
+		if err := repo.Department().Update(ctx, id, updates); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("failed to update department: %w", domain.ErrDepartmentNotFound)
+			}
+			return fmt.Errorf("failed to update department: %w", err)
+		}
+		if moved {
+			if err := repo.Department().UnsetInvalidHeads(ctx, id); err != nil {
+				return fmt.Errorf("failed to unset heads of moved department: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	// Get updated department
-	updatedDept, err := s.repo.Department().GetByID(ctx, id, 1, false)
+	updatedDept, err := s.repo.Department().GetByID(ctx, id, domain.TreeOptions{Depth: 1})
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get updated department: %w", op, err)
 	}
@@ -217,6 +371,11 @@ func (s *departmentService) Delete(ctx context.Context, id int, req *dto.DeleteD
 		return fmt.Errorf("%s: department with id '%d' does not exist: %w", op, id, domain.ErrDepartmentNotFound)
 	}
 
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleAdmin, &id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	// Reassign Mode
 	if req.Mode == domain.ModeReassign {
 		// Validate reassign_to_id
@@ -232,13 +391,25 @@ func (s *departmentService) Delete(ctx context.Context, id int, req *dto.DeleteD
 		if !exists {
 			return fmt.Errorf("%s: reassign_to_id department with id '%d' does not exist: %w", op, *req.ReassignToID, domain.ErrDepartmentNotFound)
 		}
+		if err := authorize(ctx, s.repo, domain.RoleEditor, req.ReassignToID); err != nil {
+			return fmt.Errorf("%s: reassign_to_id: %w", op, err)
+		}
 
-		// Go to repo
-		if err := s.repo.Department().DeleteWithReassign(ctx, id, *req.ReassignToID); err != nil {
-			if errors.Is(err, domain.ErrNotFound) {
-				return fmt.Errorf("%s: failed to delete department with reassign, department not found: %w", op, domain.ErrDepartmentNotFound)
+		// Go to repo, moved employees stop heading departments outside reassign_to department subtree
+		err = s.repo.Transaction(ctx, func(repo domain.Repository) error {
+			if err := repo.Department().DeleteWithReassign(ctx, id, *req.ReassignToID); err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					return fmt.Errorf("failed to delete department with reassign, department not found: %w", domain.ErrDepartmentNotFound)
+				}
+				return fmt.Errorf("failed to delete department with reassign: %w", err)
 			}
-			return fmt.Errorf("%s: failed to delete department with reassign: %w", op, err)
+			if err := repo.Department().UnsetInvalidHeads(ctx, *req.ReassignToID); err != nil {
+				return fmt.Errorf("failed to unset heads of reassigned employees: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
 		}
 	} else {
 		// Cascade Mode - Go to repo just Delete
@@ -253,6 +424,45 @@ func (s *departmentService) Delete(ctx context.Context, id int, req *dto.DeleteD
 	return nil
 }
 
+// normalizeName - NFC-normalise department name and collapse any whitespace to single spaces
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+}
+
+// normalizeCostCenter - trim and upper-case cost center code, nil for empty
+func normalizeCostCenter(costCenter *string) *string {
+	costCenter = trimOptional(costCenter)
+	if costCenter == nil {
+		return nil
+	}
+
+	upper := strings.ToUpper(*costCenter)
+	return &upper
+}
+
+// checkCostCenter - check cost center code is not used by another department than id
+func (s *departmentService) checkCostCenter(ctx context.Context, costCenter *string, id int) error {
+	if costCenter == nil {
+		return nil
+	}
+
+	existing, err := s.repo.Department().GetByCostCenter(ctx, *costCenter)
+	if err != nil {
+		return fmt.Errorf("failed to check cost center uniqueness: %w", err)
+	}
+	if existing != nil && existing.ID != id {
+		conflict := &domain.ConflictError{Err: domain.ErrDuplicateCostCenter, Existing: dto.NewDepartmentResponse(*existing)}
+		return fmt.Errorf("cost center '%s' is used by department '%d': %w", *costCenter, existing.ID, conflict)
+	}
+
+	return nil
+}
+
+// duplicateName - name conflict reporting the existing department
+func duplicateName(existing *models.Department) error {
+	return &domain.ConflictError{Err: domain.ErrDuplicateName, Existing: dto.NewDepartmentResponse(*existing)}
+}
+
 func (s *departmentService) checkCycle(ctx context.Context, movingID int, newParentID int) error {
 	currParentID := &newParentID
 
@@ -269,3 +479,302 @@ func (s *departmentService) checkCycle(ctx context.Context, movingID int, newPar
 	}
 	return nil
 }
+
+// Search - Search departments by name across the whole tree, only departments principal may view
+func (s *departmentService) Search(ctx context.Context, req *dto.SearchDepartmentsRequest) ([]dto.DepartmentSearchResponse, error) {
+	const op = "service.department.Search"
+
+	// Trimming space
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		return nil, fmt.Errorf("%s: search query is empty: %w", op, domain.ErrEmptyConstraint)
+	}
+
+	// Set default and max limit
+	if req.Limit <= 0 {
+		req.Limit = domain.DefaultSearchLimit
+	}
+	if req.Limit > domain.MaxSearchLimit {
+		req.Limit = domain.MaxSearchLimit
+	}
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	// Results principal may not view are dropped, so more are loaded for principals with limited rights
+	filter := newViewFilter(ctx, s.repo)
+	limit := req.Limit
+	if !filter.all {
+		limit = domain.MaxSearchLimit
+	}
+
+	// Go to repo
+	results, err := s.repo.Department().Search(ctx, req.Query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to search departments: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.DepartmentSearchResponse, 0, len(results))
+	for _, result := range results {
+		if len(resp) == req.Limit {
+			break
+		}
+
+		visible, err := filter.can(ctx, result.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to check rights on departments: %w", op, err)
+		}
+		if !visible {
+			continue
+		}
+
+		// Path starts at the highest ancestor principal may view, rights are inherited down the tree
+		for i, ancestor := range result.Path {
+			visible, err := filter.can(ctx, ancestor.ID)
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to check rights on departments: %w", op, err)
+			}
+			if visible {
+				result.Path = result.Path[i:]
+				break
+			}
+			if i == len(result.Path)-1 {
+				result.Path = nil
+			}
+		}
+
+		resp = append(resp, dto.NewDepartmentSearchResponse(result))
+	}
+	return resp, nil
+}
+
+// ListChildren - List direct children of department filtered by attribute values page by page
+func (s *departmentService) ListChildren(ctx context.Context, id int, req *dto.ListChildrenRequest) (*dto.DepartmentsPage, error) {
+	const op = "service.department.ListChildren"
+
+	// Set default and max limit
+	req.Limit = pageLimit(req.Limit)
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	after, err := dto.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, domain.ErrInvalidCursor, err)
+	}
+
+	// Check department exists
+	exists, err := s.repo.Department().Exists(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check department existence: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: department with id '%d' does not exist: %w", op, id, domain.ErrDepartmentNotFound)
+	}
+
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleViewer, &id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	attributes, err := attributeFilter(ctx, s.repo, domain.AttributeEntityDepartment, req.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo, one extra row to detect next page
+	filter := domain.DepartmentFilter{Attributes: attributes}
+	children, err := s.repo.Department().ListChildren(ctx, id, filter, after, req.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list children: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	page := &dto.DepartmentsPage{Items: make([]dto.DepartmentResponse, 0, len(children))}
+	if len(children) > req.Limit {
+		children = children[:req.Limit]
+		last := children[len(children)-1]
+		cursor := dto.EncodeCursor(models.Cursor{Order: last.SortOrder, Key: last.Name, ID: last.ID})
+		page.NextCursor = &cursor
+	}
+	for _, child := range children {
+		page.Items = append(page.Items, dto.NewDepartmentResponse(child))
+	}
+
+	return page, nil
+}
+
+// GetStats - Get aggregated statistics of department subtree
+func (s *departmentService) GetStats(ctx context.Context, id int) (*dto.DepartmentStatsResponse, error) {
+	const op = "service.department.GetStats"
+
+	// Check department exists
+	exists, err := s.repo.Department().Exists(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check department existence: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: department with id '%d' does not exist: %w", op, id, domain.ErrDepartmentNotFound)
+	}
+
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleViewer, &id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo
+	stats, err := s.repo.Department().GetStats(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get department stats: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewDepartmentStatsResponse(*stats)
+	return &resp, nil
+}
+
+// GetBudget - Get budget of department and every department of its subtree compared with headcount
+func (s *departmentService) GetBudget(ctx context.Context, id int) ([]dto.DepartmentBudgetResponse, error) {
+	const op = "service.department.GetBudget"
+
+	// Go to repo
+	rows, err := s.repo.Department().GetBudget(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get department budget: %w", op, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: department with id '%d' not found: %w", op, id, domain.ErrDepartmentNotFound)
+	}
+
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleViewer, &id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.DepartmentBudgetResponse, len(rows))
+	for i, row := range rows {
+		resp[i] = dto.NewDepartmentBudgetResponse(row)
+	}
+	return resp, nil
+}
+
+// SetHead - Set or remove department head, head must belong to the department or one of its ancestors
+func (s *departmentService) SetHead(ctx context.Context, id int, req *dto.SetHeadRequest) (*dto.DepartmentResponse, error) {
+	const op = "service.department.SetHead"
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	// Department and its ancestors
+	ancestors, err := s.repo.Department().GetAncestors(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%s: failed to get department ancestors: %w", op, domain.ErrDepartmentNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get department ancestors: %w", op, err)
+	}
+
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleEditor, &id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.EmployeeID != nil {
+		emp, err := s.repo.Employee().GetByID(ctx, *req.EmployeeID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%s: employee with id '%d' not found: %w", op, *req.EmployeeID, domain.ErrEmployeeNotFound)
+			}
+			return nil, fmt.Errorf("%s: failed to get employee: %w", op, err)
+		}
+		if emp.Status == domain.EmployeeStatusTerminated {
+			return nil, fmt.Errorf("%s: employee with id '%d': %w", op, emp.ID, domain.ErrEmployeeTerminated)
+		}
+
+		inHierarchy := false
+		for _, ancestor := range ancestors {
+			if ancestor.ID == emp.DepartmentID {
+				inHierarchy = true
+				break
+			}
+		}
+		if !inHierarchy {
+			return nil, fmt.Errorf("%s: employee '%d' from department '%d': %w", op, emp.ID, emp.DepartmentID, domain.ErrInvalidHead)
+		}
+	}
+
+	// Go to repo to update
+	if err := s.repo.Department().Update(ctx, id, map[string]interface{}{"head_employee_id": req.EmployeeID}); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%s: failed to set department head: %w", op, domain.ErrDepartmentNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to set department head: %w", op, err)
+	}
+
+	// Get updated department
+	updatedDept, err := s.repo.Department().GetByID(ctx, id, domain.TreeOptions{Depth: 1})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get updated department: %w", op, err)
+	}
+	// Mapping model to DTO
+	resp := dto.NewDepartmentResponse(*updatedDept)
+	return &resp, nil
+}
+
+// Reorder - Set order of direct children of department, child_ids must list every child exactly once
+func (s *departmentService) Reorder(ctx context.Context, id int, req *dto.ReorderChildrenRequest) ([]dto.DepartmentResponse, error) {
+	const op = "service.department.Reorder"
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	seen := make(map[int]struct{}, len(req.ChildIDs))
+	for _, childID := range req.ChildIDs {
+		if _, ok := seen[childID]; ok {
+			return nil, fmt.Errorf("%s: department id '%d' is listed twice: %w", op, childID, domain.ErrInvalidChildOrder)
+		}
+		seen[childID] = struct{}{}
+	}
+
+	// Check department exists
+	exists, err := s.repo.Department().Exists(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check department existence: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: department with id '%d' does not exist: %w", op, id, domain.ErrDepartmentNotFound)
+	}
+
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleEditor, &id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo
+	if err := s.repo.Department().Reorder(ctx, id, req.ChildIDs); err != nil {
+		return nil, fmt.Errorf("%s: failed to reorder children: %w", op, err)
+	}
+
+	children, err := s.repo.Department().ListChildren(ctx, id, domain.DepartmentFilter{}, nil, len(req.ChildIDs))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list children: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.DepartmentResponse, 0, len(children))
+	for _, child := range children {
+		resp = append(resp, dto.NewDepartmentResponse(child))
+	}
+
+	return resp, nil
+}
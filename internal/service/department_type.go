@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+type departmentTypeService struct {
+	repo     domain.Repository
+	log      *slog.Logger
+	validate *validator.Validate
+}
+
+func newDepartmentTypeService(
+	repo domain.Repository,
+	log *slog.Logger,
+	validate *validator.Validate,
+) domain.DepartmentTypeService {
+	return &departmentTypeService{repo: repo, log: log, validate: validate}
+}
+
+// List - List department types with their hierarchy rules
+func (s *departmentTypeService) List(ctx context.Context) ([]dto.DepartmentTypeResponse, error) {
+	const op = "service.departmentType.List"
+
+	types, err := s.repo.DepartmentType().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list department types: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.DepartmentTypeResponse, len(types))
+	for i, t := range types {
+		resp[i] = dto.NewDepartmentTypeResponse(t)
+	}
+	return resp, nil
+}
+
+// Save - Create or replace department type with its hierarchy rules,
+// existing departments are not revalidated and are checked on the next move
+func (s *departmentTypeService) Save(ctx context.Context, name string, req *dto.SaveDepartmentTypeRequest) (*dto.DepartmentTypeResponse, error) {
+	const op = "service.departmentType.Save"
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Trimming space
+	name = strings.TrimSpace(name)
+	req.AllowedChildren = trimValues(req.AllowedChildren)
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrEmptyConstraint)
+	}
+	if len(name) > 50 {
+		return nil, fmt.Errorf("%s: department type name is longer than 50: %w", op, domain.ErrLengthConstraint)
+	}
+
+	// Check allowed children exist, the type may contain itself
+	children := slices.Compact(slices.Sorted(slices.Values(req.AllowedChildren)))
+	types, err := s.repo.DepartmentType().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list department types: %w", op, err)
+	}
+	for _, child := range children {
+		known := child == name || slices.ContainsFunc(types, func(t models.DepartmentType) bool { return t.Name == child })
+		if !known {
+			return nil, fmt.Errorf("%s: allowed child type '%s' not found: %w", op, child, domain.ErrDepartmentTypeNotFound)
+		}
+	}
+
+	// Mapping DTO to Model
+	t := &models.DepartmentType{
+		Name:        name,
+		RootAllowed: req.RootAllowed == nil || *req.RootAllowed,
+		MaxDepth:    req.MaxDepth,
+		MaxChildren: req.MaxChildren,
+		Rules:       make([]models.DepartmentTypeRule, len(children)),
+	}
+	for i, child := range children {
+		t.Rules[i] = models.DepartmentTypeRule{ParentType: name, ChildType: child}
+	}
+
+	// Go to repo
+	if err := s.repo.DepartmentType().Save(ctx, t); err != nil {
+		return nil, fmt.Errorf("%s: failed to save department type: %w", op, err)
+	}
+
+	saved, err := s.repo.DepartmentType().GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get saved department type: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewDepartmentTypeResponse(*saved)
+	return &resp, nil
+}
+
+// Delete - Delete department type, only if no department has it
+func (s *departmentTypeService) Delete(ctx context.Context, name string) error {
+	const op = "service.departmentType.Delete"
+
+	if err := requireAdmin(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	used, err := s.repo.DepartmentType().IsUsed(ctx, name)
+	if err != nil {
+		return fmt.Errorf("%s: failed to check department type usage: %w", op, err)
+	}
+	if used {
+		return fmt.Errorf("%s: department type '%s' is used by departments: %w", op, name, domain.ErrDepartmentTypeInUse)
+	}
+
+	// Go to repo
+	if err := s.repo.DepartmentType().Delete(ctx, name); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%s: failed to delete department type: %w", op, domain.ErrDepartmentTypeNotFound)
+		}
+		return fmt.Errorf("%s: failed to delete department type: %w", op, err)
+	}
+
+	return nil
+}
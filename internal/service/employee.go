@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -16,20 +18,29 @@ type employeeService struct {
 	repo     domain.Repository
 	log      *slog.Logger
 	validate *validator.Validate
+	// numberPattern - fmt pattern of generated employee numbers, empty disables generation
+	numberPattern string
 }
 
 func newEmployeeService(
 	repo domain.Repository,
 	log *slog.Logger,
 	validate *validator.Validate,
+	numberPattern string,
 ) domain.EmployeeService {
-	return &employeeService{repo: repo, log: log, validate: validate}
+	return &employeeService{repo: repo, log: log, validate: validate, numberPattern: numberPattern}
 }
 
 // Create - Create a new employee in a department
 func (s *employeeService) Create(ctx context.Context, deptID int, req *dto.CreateEmployeeRequest) (*dto.EmployeeResponse, error) {
 	const op = "service.employee.Create"
 
+	// Trimming space
+	req.Position = strings.TrimSpace(req.Position)
+	req.Email = trimOptional(req.Email)
+	req.Phone = trimOptional(req.Phone)
+	req.EmployeeNumber = trimOptional(req.EmployeeNumber)
+
 	// Validation
 	if err := s.validate.Struct(req); err != nil {
 		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
@@ -44,6 +55,59 @@ func (s *employeeService) Create(ctx context.Context, deptID int, req *dto.Creat
 		return nil, fmt.Errorf("%s: parent department not found: %w", op, domain.ErrDepartmentNotFound)
 	}
 
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleEditor, &deptID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Check manager
+	if req.ManagerID != nil {
+		if err := s.checkManager(ctx, *req.ManagerID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// Check email and employee number unique
+	if req.Email != nil {
+		existing, err := s.repo.Employee().GetByEmail(ctx, *req.Email)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to check email uniqueness: %w", op, err)
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("%s: email '%s' is used by employee '%d': %w", op, *req.Email, existing.ID, domain.ErrDuplicateEmail)
+		}
+	}
+	if req.EmployeeNumber != nil {
+		existing, err := s.repo.Employee().GetByNumber(ctx, *req.EmployeeNumber)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to check employee number uniqueness: %w", op, err)
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("%s: employee number '%s' is used by employee '%d': %w", op, *req.EmployeeNumber, existing.ID, domain.ErrDuplicateEmployeeNumber)
+		}
+	}
+
+	// Canonical position
+	position, err := s.resolvePosition(ctx, req.PositionID, req.Position)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to resolve position: %w", op, err)
+	}
+
+	// Check custom attributes
+	if err := checkAttributes(ctx, s.repo, domain.AttributeEntityEmployee, req.Attributes); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Generate employee number
+	employeeNumber := req.EmployeeNumber
+	if employeeNumber == nil && s.numberPattern != "" {
+		number, err := s.generateNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to generate employee number: %w", op, err)
+		}
+		employeeNumber = &number
+	}
+
 	// Parsing date
 	var hiredAt *time.Time
 	if req.HiredAt != nil {
@@ -56,10 +120,17 @@ func (s *employeeService) Create(ctx context.Context, deptID int, req *dto.Creat
 
 	// Mapping DTO to model
 	emp := &models.Employee{
-		DepartmentID: deptID,
-		FullName:     req.FullName,
-		Position:     req.Position,
-		HiredAt:      hiredAt,
+		DepartmentID:   deptID,
+		ManagerID:      req.ManagerID,
+		FullName:       req.FullName,
+		PositionID:     &position.ID,
+		Position:       position.Title,
+		HiredAt:        hiredAt,
+		Email:          req.Email,
+		Phone:          req.Phone,
+		EmployeeNumber: employeeNumber,
+		Status:         domain.EmployeeStatusActive,
+		Attributes:     req.Attributes,
 	}
 
 	// Go to repo
@@ -71,3 +142,416 @@ func (s *employeeService) Create(ctx context.Context, deptID int, req *dto.Creat
 	resp := dto.NewEmployeeResponse(*emp)
 	return &resp, nil
 }
+
+// ListByDepartment - List employees of department filtered by position and attribute values page by page
+func (s *employeeService) ListByDepartment(ctx context.Context, deptID int, req *dto.ListEmployeesRequest) (*dto.EmployeesPage, error) {
+	const op = "service.employee.ListByDepartment"
+
+	// Set default and max limit
+	req.Limit = pageLimit(req.Limit)
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	after, err := dto.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, domain.ErrInvalidCursor, err)
+	}
+
+	// Check department exists
+	exists, err := s.repo.Department().Exists(ctx, deptID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check department existence: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: department not found: %w", op, domain.ErrDepartmentNotFound)
+	}
+
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleViewer, &deptID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	attributes, err := attributeFilter(ctx, s.repo, domain.AttributeEntityEmployee, req.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo, one extra row to detect next page
+	filter := domain.EmployeeFilter{
+		PositionID:        req.PositionID,
+		IncludeTerminated: req.IncludeTerminated,
+		Attributes:        attributes,
+	}
+	employees, err := s.repo.Employee().ListByDepartment(ctx, deptID, filter, after, req.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list employees: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	page := &dto.EmployeesPage{Items: make([]dto.EmployeeResponse, 0, len(employees))}
+	if len(employees) > req.Limit {
+		employees = employees[:req.Limit]
+		last := employees[len(employees)-1]
+		cursor := dto.EncodeCursor(models.Cursor{Key: last.FullName, ID: last.ID})
+		page.NextCursor = &cursor
+	}
+	for _, emp := range employees {
+		page.Items = append(page.Items, dto.NewEmployeeResponse(emp))
+	}
+
+	return page, nil
+}
+
+// GetManagerChain - Get heads of employee department and its ancestors, from the closest up to root
+func (s *employeeService) GetManagerChain(ctx context.Context, id int) ([]dto.ManagerChainItem, error) {
+	const op = "service.employee.GetManagerChain"
+
+	emp, err := s.getEmployee(ctx, id, domain.RoleViewer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Walk up the tree
+	ancestors, err := s.repo.Department().GetAncestors(ctx, emp.DepartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get department ancestors: %w", op, err)
+	}
+
+	chain := make([]dto.ManagerChainItem, 0, len(ancestors))
+	for _, dept := range ancestors {
+		// Employee is not his own manager
+		if dept.Head == nil || dept.Head.ID == emp.ID {
+			continue
+		}
+		chain = append(chain, dto.ManagerChainItem{
+			DepartmentID:   dept.ID,
+			DepartmentName: dept.Name,
+			Head:           dto.NewEmployeeResponse(*dept.Head),
+		})
+	}
+
+	return chain, nil
+}
+
+// SetManager - Set or remove manager of employee
+func (s *employeeService) SetManager(ctx context.Context, id int, req *dto.SetManagerRequest) (*dto.EmployeeResponse, error) {
+	const op = "service.employee.SetManager"
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	emp, err := s.getEmployee(ctx, id, domain.RoleEditor)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Check manager
+	if req.ManagerID != nil {
+		if err := s.checkManager(ctx, *req.ManagerID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := s.checkManagerCycle(ctx, id, *req.ManagerID); err != nil {
+			return nil, fmt.Errorf("%s: failed to check cycle constraint: %w", op, err)
+		}
+	}
+
+	// Go to repo to update
+	if err := s.repo.Employee().Update(ctx, id, map[string]interface{}{"manager_id": req.ManagerID}); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%s: failed to set manager: %w", op, domain.ErrEmployeeNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to set manager: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	emp.ManagerID = req.ManagerID
+	resp := dto.NewEmployeeResponse(*emp)
+	return &resp, nil
+}
+
+// ListDirectReports - List employees reporting directly to employee, only employees of departments principal may view
+func (s *employeeService) ListDirectReports(ctx context.Context, id int) ([]dto.EmployeeResponse, error) {
+	const op = "service.employee.ListDirectReports"
+
+	if _, err := s.getEmployee(ctx, id, domain.RoleViewer); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo
+	reports, err := s.repo.Employee().ListDirectReports(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list direct reports: %w", op, err)
+	}
+
+	// Employees of other subtrees are hidden from principal without rights on them
+	reports, err = newViewFilter(ctx, s.repo).employees(ctx, reports)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check rights on departments: %w", op, err)
+	}
+
+	return newEmployeeResponses(reports), nil
+}
+
+// ListAllReports - List employees reporting to employee directly or indirectly, only employees of departments principal may view
+func (s *employeeService) ListAllReports(ctx context.Context, id int) ([]dto.EmployeeResponse, error) {
+	const op = "service.employee.ListAllReports"
+
+	if _, err := s.getEmployee(ctx, id, domain.RoleViewer); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo
+	reports, err := s.repo.Employee().ListAllReports(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list all reports: %w", op, err)
+	}
+
+	// Employees of other subtrees are hidden from principal without rights on them
+	reports, err = newViewFilter(ctx, s.repo).employees(ctx, reports)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check rights on departments: %w", op, err)
+	}
+
+	return newEmployeeResponses(reports), nil
+}
+
+// GetManagementChain - Get managers of employee from direct manager up to the top, only employees of departments principal may view
+func (s *employeeService) GetManagementChain(ctx context.Context, id int) ([]dto.EmployeeResponse, error) {
+	const op = "service.employee.GetManagementChain"
+
+	if _, err := s.getEmployee(ctx, id, domain.RoleViewer); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo
+	chain, err := s.repo.Employee().GetManagementChain(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get management chain: %w", op, err)
+	}
+
+	// Employees of other subtrees are hidden from principal without rights on them
+	chain, err = newViewFilter(ctx, s.repo).employees(ctx, chain)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check rights on departments: %w", op, err)
+	}
+
+	return newEmployeeResponses(chain), nil
+}
+
+// GetSpanOfControl - Get managers with number of direct reports, flagging too many or too few
+func (s *employeeService) GetSpanOfControl(ctx context.Context, req *dto.SpanOfControlRequest) ([]dto.ManagerSpanResponse, error) {
+	const op = "service.employee.GetSpanOfControl"
+
+	// Set default limits
+	if req.Min <= 0 {
+		req.Min = domain.DefaultMinSpan
+	}
+	if req.Max <= 0 {
+		req.Max = domain.DefaultMaxSpan
+	}
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	// Go to repo
+	spans, err := s.repo.Employee().GetSpanOfControl(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get span of control: %w", op, err)
+	}
+
+	// Mapping models to DTO, managers of other subtrees are hidden from principal without rights on them
+	filter := newViewFilter(ctx, s.repo)
+	resp := make([]dto.ManagerSpanResponse, 0, len(spans))
+	for _, span := range spans {
+		visible, err := filter.can(ctx, span.DepartmentID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to check rights on departments: %w", op, err)
+		}
+		if !visible {
+			continue
+		}
+
+		status := domain.SpanStatusOK
+		switch {
+		case span.DirectReports < int64(req.Min):
+			status = domain.SpanStatusTooFew
+		case span.DirectReports > int64(req.Max):
+			status = domain.SpanStatusTooMany
+		}
+
+		resp = append(resp, dto.ManagerSpanResponse{
+			Manager:       dto.NewEmployeeResponse(span.Employee),
+			DirectReports: span.DirectReports,
+			Status:        status,
+		})
+	}
+
+	return resp, nil
+}
+
+// resolvePosition - get catalogue position by id, or by title creating it when unknown and principal is admin
+func (s *employeeService) resolvePosition(ctx context.Context, positionID *int, title string) (*models.Position, error) {
+	if positionID != nil {
+		pos, err := s.repo.Position().GetByID(ctx, *positionID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("position with id '%d' not found: %w", *positionID, domain.ErrPositionNotFound)
+			}
+			return nil, fmt.Errorf("failed to get position: %w", err)
+		}
+		return pos, nil
+	}
+
+	pos, err := s.repo.Position().GetByTitle(ctx, title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position by title: %w", err)
+	}
+	if pos != nil {
+		return pos, nil
+	}
+
+	// Only admins extend the catalogue, others pick existing positions
+	if err := requireAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("position '%s' not found in catalogue: %w", title, domain.ErrPositionNotFound)
+	}
+
+	pos = &models.Position{Title: title}
+	if err := s.repo.Position().Create(ctx, pos); err != nil {
+		// Created concurrently
+		if existing, getErr := s.repo.Position().GetByTitle(ctx, title); getErr == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("failed to create position '%s': %w", title, err)
+	}
+
+	return pos, nil
+}
+
+// getEmployee - get employee by id mapping not found error, principal must have role on employee department
+func (s *employeeService) getEmployee(ctx context.Context, id int, role string) (*models.Employee, error) {
+	emp, err := s.repo.Employee().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("employee with id '%d' not found: %w", id, domain.ErrEmployeeNotFound)
+		}
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+	if err := authorize(ctx, s.repo, role, &emp.DepartmentID); err != nil {
+		return nil, err
+	}
+	return emp, nil
+}
+
+// generateNumber - format next sequence value with the number pattern, skipping numbers taken manually
+func (s *employeeService) generateNumber(ctx context.Context) (string, error) {
+	const maxAttempts = 5
+
+	for i := 0; i < maxAttempts; i++ {
+		next, err := s.repo.Employee().NextNumber(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		number := fmt.Sprintf(s.numberPattern, next)
+		existing, err := s.repo.Employee().GetByNumber(ctx, number)
+		if err != nil {
+			return "", fmt.Errorf("failed to check employee number uniqueness: %w", err)
+		}
+		if existing == nil {
+			return number, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free employee number after %d attempts: %w", maxAttempts, domain.ErrDuplicateEmployeeNumber)
+}
+
+// SetAttributes - Replace custom attributes of employee
+func (s *employeeService) SetAttributes(ctx context.Context, id int, req *dto.SetAttributesRequest) (*dto.EmployeeResponse, error) {
+	const op = "service.employee.SetAttributes"
+
+	emp, err := s.getEmployee(ctx, id, domain.RoleEditor)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Check custom attributes
+	if err := checkAttributes(ctx, s.repo, domain.AttributeEntityEmployee, req.Attributes); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo
+	attributes := models.Attributes(req.Attributes)
+	if err := s.repo.Employee().Update(ctx, id, map[string]interface{}{"attributes": attributes}); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%s: failed to update employee: %w", op, domain.ErrEmployeeNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to update employee: %w", op, err)
+	}
+	emp.Attributes = attributes
+
+	// Mapping model to DTO
+	resp := dto.NewEmployeeResponse(*emp)
+	return &resp, nil
+}
+
+// trimOptional - trim space of optional value, blank becomes nil
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// checkManager - check manager exists and is not terminated
+func (s *employeeService) checkManager(ctx context.Context, managerID int) error {
+	manager, err := s.repo.Employee().GetByID(ctx, managerID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("manager with id '%d' not found: %w", managerID, domain.ErrManagerNotFound)
+		}
+		return fmt.Errorf("failed to get manager: %w", err)
+	}
+	if manager.Status == domain.EmployeeStatusTerminated {
+		return fmt.Errorf("manager with id '%d': %w", managerID, domain.ErrEmployeeTerminated)
+	}
+	return nil
+}
+
+func (s *employeeService) checkManagerCycle(ctx context.Context, employeeID int, newManagerID int) error {
+	currManagerID := &newManagerID
+
+	for currManagerID != nil {
+		if *currManagerID == employeeID {
+			return domain.ErrCycleConstraint
+		}
+
+		manager, err := s.repo.Employee().GetByID(ctx, *currManagerID)
+		if err != nil {
+			return err
+		}
+		currManagerID = manager.ManagerID
+	}
+	return nil
+}
+
+// newEmployeeResponses - convert Employee models to EmployeeResponse DTOs
+func newEmployeeResponses(employees []models.Employee) []dto.EmployeeResponse {
+	resp := make([]dto.EmployeeResponse, len(employees))
+	for i, emp := range employees {
+		resp[i] = dto.NewEmployeeResponse(emp)
+	}
+	return resp
+}
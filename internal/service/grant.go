@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+type grantService struct {
+	repo     domain.Repository
+	log      *slog.Logger
+	validate *validator.Validate
+}
+
+func newGrantService(
+	repo domain.Repository,
+	log *slog.Logger,
+	validate *validator.Validate,
+) domain.GrantService {
+	return &grantService{repo: repo, log: log, validate: validate}
+}
+
+// List - List grants on department, requires admin role on it
+func (s *grantService) List(ctx context.Context, deptID int) ([]dto.GrantResponse, error) {
+	const op = "service.grant.List"
+
+	if err := s.checkDepartment(ctx, deptID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	grants, err := s.repo.Grant().ListByDepartment(ctx, deptID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list grants: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.GrantResponse, len(grants))
+	for i, g := range grants {
+		resp[i] = dto.NewGrantResponse(g)
+	}
+	return resp, nil
+}
+
+// Save - Grant role on department subtree to subject, requires admin role on department
+func (s *grantService) Save(ctx context.Context, deptID int, req *dto.SaveGrantRequest) (*dto.GrantResponse, error) {
+	const op = "service.grant.Save"
+
+	// Trimming space
+	req.Subject = strings.TrimSpace(req.Subject)
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	if err := s.checkDepartment(ctx, deptID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Mapping DTO to Model
+	grant := &models.DepartmentGrant{
+		Subject:      req.Subject,
+		DepartmentID: deptID,
+		Role:         req.Role,
+	}
+	if p, ok := domain.PrincipalFromContext(ctx); ok {
+		grant.GrantedBy = &p.Subject
+	}
+
+	// Go to repo
+	if err := s.repo.Grant().Save(ctx, grant); err != nil {
+		return nil, fmt.Errorf("%s: failed to save grant: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewGrantResponse(*grant)
+	return &resp, nil
+}
+
+// Delete - Delete grant on department, requires admin role on department
+func (s *grantService) Delete(ctx context.Context, deptID int, id int) error {
+	const op = "service.grant.Delete"
+
+	if err := s.checkDepartment(ctx, deptID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.repo.Grant().Delete(ctx, deptID, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%s: grant with id '%d' not found: %w", op, id, domain.ErrGrantNotFound)
+		}
+		return fmt.Errorf("%s: failed to delete grant: %w", op, err)
+	}
+
+	return nil
+}
+
+// checkDepartment - check department exists and principal may manage its grants
+func (s *grantService) checkDepartment(ctx context.Context, deptID int) error {
+	exists, err := s.repo.Department().Exists(ctx, deptID)
+	if err != nil {
+		return fmt.Errorf("failed to check department existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("department with id '%d' not found: %w", deptID, domain.ErrDepartmentNotFound)
+	}
+
+	return authorize(ctx, s.repo, domain.RoleAdmin, &deptID)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// checkType - check department type exists, nil and empty type mean untyped department
+func (s *departmentService) checkType(ctx context.Context, deptType *string) error {
+	if deptType == nil || *deptType == "" {
+		return nil
+	}
+
+	if _, err := s.repo.DepartmentType().GetByName(ctx, *deptType); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("department type '%s' not found: %w", *deptType, domain.ErrDepartmentTypeNotFound)
+		}
+		return fmt.Errorf("failed to get department type: %w", err)
+	}
+	return nil
+}
+
+// checkHierarchy - check hierarchy rules for subtrees placed under parent (nil - root level).
+// Nodes with depth 0 become children of parent, deeper nodes keep their parents from nodes,
+// removedChildren - current children of parent which are deleted by the same operation.
+// Rules bind typed departments only: allowed parent/child type pairs, root_allowed, max_depth and max_children
+func (s *departmentService) checkHierarchy(ctx context.Context, parent *models.Department, nodes []models.DepartmentNode, removedChildren int) error {
+	if !hasTypes(parent, nodes) {
+		return nil
+	}
+
+	list, err := s.repo.DepartmentType().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list department types: %w", err)
+	}
+	types := make(map[string]models.DepartmentType, len(list))
+	for _, t := range list {
+		types[t.Name] = t
+	}
+
+	// Level of parent, root is level 1
+	parentLevel := 0
+	if parent != nil {
+		ancestors, err := s.repo.Department().GetAncestors(ctx, parent.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get ancestors of department '%d': %w", parent.ID, err)
+		}
+		parentLevel = len(ancestors)
+	}
+
+	typeOf := make(map[int]*string, len(nodes))
+	for _, node := range nodes {
+		typeOf[node.ID] = node.Type
+	}
+
+	children := make(map[int]int, len(nodes))
+	top := 0
+	for _, node := range nodes {
+		parentType := typeOf[derefID(node.ParentID)]
+		if node.Depth == 0 {
+			top++
+			parentType = nil
+			if parent != nil {
+				parentType = parent.Type
+			}
+		} else {
+			children[*node.ParentID]++
+		}
+
+		if parent == nil && node.Depth == 0 && node.Type != nil && !types[*node.Type].RootAllowed {
+			return fmt.Errorf("department of type '%s' must have a parent: %w", *node.Type, domain.ErrHierarchyRule)
+		}
+		if parentType != nil && (node.Type == nil || !types[*parentType].AllowsChild(*node.Type)) {
+			return fmt.Errorf("department of type '%s' cannot contain %s: %w", *parentType, describeType(node.Type), domain.ErrHierarchyRule)
+		}
+		if node.Type != nil {
+			level := parentLevel + 1 + node.Depth
+			if maxDepth := types[*node.Type].MaxDepth; maxDepth != nil && level > *maxDepth {
+				return fmt.Errorf("department of type '%s' cannot be deeper than level %d, got %d: %w", *node.Type, *maxDepth, level, domain.ErrHierarchyRule)
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		if node.Type == nil {
+			continue
+		}
+		if maxChildren := types[*node.Type].MaxChildren; maxChildren != nil && children[node.ID] > *maxChildren {
+			return fmt.Errorf("department of type '%s' cannot have more than %d children: %w", *node.Type, *maxChildren, domain.ErrHierarchyRule)
+		}
+	}
+
+	// Children of parent after the operation
+	if parent != nil && parent.Type != nil {
+		if maxChildren := types[*parent.Type].MaxChildren; maxChildren != nil {
+			counts, err := s.repo.Department().GetCounts(ctx, []int{parent.ID})
+			if err != nil {
+				return fmt.Errorf("failed to count children of department '%d': %w", parent.ID, err)
+			}
+
+			total := int(counts[parent.ID].DirectChildrenCount) - removedChildren + top
+			for _, node := range nodes {
+				if node.Depth == 0 && node.ParentID != nil && *node.ParentID == parent.ID {
+					total-- // already a child
+				}
+			}
+			if total > *maxChildren {
+				return fmt.Errorf("department of type '%s' cannot have more than %d children: %w", *parent.Type, *maxChildren, domain.ErrHierarchyRule)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkUpdateHierarchy - check hierarchy rules when update moves department to another parent or changes its type
+func (s *departmentService) checkUpdateHierarchy(ctx context.Context, current *models.Department, updates map[string]interface{}) error {
+	parentID := current.ParentID
+	newParentID, moved := updates["parent_id"].(int)
+	if moved {
+		moved = parentID == nil || *parentID != newParentID
+		parentID = &newParentID
+	}
+	newType, retyped := updates["type"].(*string)
+	retyped = retyped && derefType(newType) != derefType(current.Type)
+
+	if !moved && !retyped {
+		return nil
+	}
+
+	nodes, err := s.repo.Department().GetSubtree(ctx, []int{current.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get subtree of department '%d': %w", current.ID, err)
+	}
+	if retyped {
+		for i := range nodes {
+			if nodes[i].ID == current.ID {
+				nodes[i].Type = newType
+			}
+		}
+	}
+
+	var parent *models.Department
+	if parentID != nil {
+		parent, err = s.repo.Department().GetByIDSimple(ctx, *parentID)
+		if err != nil {
+			return fmt.Errorf("failed to get parent department '%d': %w", *parentID, err)
+		}
+	}
+
+	return s.checkHierarchy(ctx, parent, nodes, 0)
+}
+
+// hasTypes - check parent or any of nodes is typed
+func hasTypes(parent *models.Department, nodes []models.DepartmentNode) bool {
+	if parent != nil && parent.Type != nil {
+		return true
+	}
+	for _, node := range nodes {
+		if node.Type != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// describeType - name of department type for messages
+func describeType(deptType *string) string {
+	if deptType == nil {
+		return "untyped department"
+	}
+	return fmt.Sprintf("department of type '%s'", *deptType)
+}
+
+// derefID - value of optional id, 0 for nil
+func derefID(id *int) int {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+// derefType - value of optional type, empty for untyped
+func derefType(deptType *string) string {
+	if deptType == nil {
+		return ""
+	}
+	return *deptType
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+)
+
+// SetStatus - Put employee on leave or back to active, terminated employees must be rehired
+func (s *employeeService) SetStatus(ctx context.Context, id int, req *dto.SetStatusRequest) (*dto.EmployeeResponse, error) {
+	const op = "service.employee.SetStatus"
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	emp, err := s.getEmployee(ctx, id, domain.RoleEditor)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if emp.Status == domain.EmployeeStatusTerminated {
+		return nil, fmt.Errorf("%s: employee with id '%d': %w", op, id, domain.ErrEmployeeTerminated)
+	}
+
+	// Go to repo to update
+	if err := s.repo.Employee().Update(ctx, id, map[string]interface{}{"status": req.Status}); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%s: failed to set status: %w", op, domain.ErrEmployeeNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to set status: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	emp.Status = req.Status
+	resp := dto.NewEmployeeResponse(*emp)
+	return &resp, nil
+}
+
+// Terminate - Terminate employee, their reports move to their manager and they stop heading departments
+func (s *employeeService) Terminate(ctx context.Context, id int, req *dto.TerminateEmployeeRequest) (*dto.EmployeeResponse, error) {
+	const op = "service.employee.Terminate"
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	emp, err := s.getEmployee(ctx, id, domain.RoleEditor)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if emp.Status == domain.EmployeeStatusTerminated {
+		return nil, fmt.Errorf("%s: employee with id '%d': %w", op, id, domain.ErrEmployeeTerminated)
+	}
+
+	// Parsing date, today by default
+	terminatedAt, err := dateOrToday(req.TerminatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid date format for terminated_at: %w", op, err)
+	}
+	if emp.HiredAt != nil && terminatedAt.Before(*emp.HiredAt) {
+		return nil, fmt.Errorf("%s: terminated_at %s, hired_at %s: %w", op,
+			terminatedAt.Format(domain.DateFormat), emp.HiredAt.Format(domain.DateFormat), domain.ErrInvalidTerminatedAt)
+	}
+
+	// Go to repo
+	if err := s.repo.Employee().Terminate(ctx, id, terminatedAt); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%s: failed to terminate employee: %w", op, domain.ErrEmployeeNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to terminate employee: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	emp.Status = domain.EmployeeStatusTerminated
+	emp.TerminatedAt = &terminatedAt
+	emp.ManagerID = nil
+	resp := dto.NewEmployeeResponse(*emp)
+	return &resp, nil
+}
+
+// Rehire - Make terminated employee active again with a new hire date
+func (s *employeeService) Rehire(ctx context.Context, id int, req *dto.RehireEmployeeRequest) (*dto.EmployeeResponse, error) {
+	const op = "service.employee.Rehire"
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	emp, err := s.getEmployee(ctx, id, domain.RoleEditor)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if emp.Status != domain.EmployeeStatusTerminated {
+		return nil, fmt.Errorf("%s: employee with id '%d': %w", op, id, domain.ErrEmployeeNotTerminated)
+	}
+
+	// Parsing date, today by default
+	hiredAt, err := dateOrToday(req.HiredAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid date format for hired_at: %w", op, err)
+	}
+	if emp.TerminatedAt != nil && hiredAt.Before(*emp.TerminatedAt) {
+		return nil, fmt.Errorf("%s: hired_at %s, terminated_at %s: %w", op,
+			hiredAt.Format(domain.DateFormat), emp.TerminatedAt.Format(domain.DateFormat), domain.ErrInvalidRehiredAt)
+	}
+
+	// Go to repo to update
+	updates := map[string]interface{}{
+		"status":        domain.EmployeeStatusActive,
+		"terminated_at": nil,
+		"hired_at":      hiredAt,
+	}
+	if err := s.repo.Employee().Update(ctx, id, updates); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%s: failed to rehire employee: %w", op, domain.ErrEmployeeNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to rehire employee: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	emp.Status = domain.EmployeeStatusActive
+	emp.TerminatedAt = nil
+	emp.HiredAt = &hiredAt
+	resp := dto.NewEmployeeResponse(*emp)
+	return &resp, nil
+}
+
+// dateOrToday - parse optional date in DateFormat, today when not set
+func dateOrToday(value *string) (time.Time, error) {
+	if value == nil {
+		now := time.Now().UTC()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+
+	return time.Parse(domain.DateFormat, *value)
+}
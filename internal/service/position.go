@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+type positionService struct {
+	repo     domain.Repository
+	log      *slog.Logger
+	validate *validator.Validate
+}
+
+func newPositionService(
+	repo domain.Repository,
+	log *slog.Logger,
+	validate *validator.Validate,
+) domain.PositionService {
+	return &positionService{repo: repo, log: log, validate: validate}
+}
+
+// Create - Create a new position in the catalogue
+func (s *positionService) Create(ctx context.Context, req *dto.CreatePositionRequest) (*dto.PositionResponse, error) {
+	const op = "service.position.Create"
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Trimming space
+	req.Title = strings.TrimSpace(req.Title)
+	req.Family = strings.TrimSpace(req.Family)
+	req.GradeBand = strings.TrimSpace(req.GradeBand)
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	// Check title unique
+	existing, err := s.repo.Position().GetByTitle(ctx, req.Title)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check position title uniqueness: %w", op, err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%s: position with title '%s' already exists: %w", op, req.Title, domain.ErrDuplicateName)
+	}
+
+	// Mapping DTO to Model
+	pos := &models.Position{
+		Title:     req.Title,
+		Family:    req.Family,
+		Level:     req.Level,
+		GradeBand: req.GradeBand,
+	}
+
+	// Go to repo
+	if err := s.repo.Position().Create(ctx, pos); err != nil {
+		return nil, fmt.Errorf("%s: failed to create position: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewPositionResponse(*pos)
+	return &resp, nil
+}
+
+// GetByID - Get position by id
+func (s *positionService) GetByID(ctx context.Context, id int) (*dto.PositionResponse, error) {
+	const op = "service.position.GetByID"
+
+	pos, err := s.repo.Position().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%s: failed to get position by id: %w", op, domain.ErrPositionNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get position by id: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewPositionResponse(*pos)
+	return &resp, nil
+}
+
+// List - List all positions of the catalogue
+func (s *positionService) List(ctx context.Context) ([]dto.PositionResponse, error) {
+	const op = "service.position.List"
+
+	positions, err := s.repo.Position().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list positions: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.PositionResponse, len(positions))
+	for i, pos := range positions {
+		resp[i] = dto.NewPositionResponse(pos)
+	}
+	return resp, nil
+}
+
+// Update - Update position by id
+func (s *positionService) Update(ctx context.Context, id int, req *dto.UpdatePositionRequest) (*dto.PositionResponse, error) {
+	const op = "service.position.Update"
+
+	if err := requireAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Validation DTO
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, domain.ErrEmptyConstraint
+		}
+
+		// Check title unique
+		existing, err := s.repo.Position().GetByTitle(ctx, title)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to check position title uniqueness: %w", op, err)
+		}
+		if existing != nil && existing.ID != id {
+			return nil, fmt.Errorf("%s: position with title '%s' already exists: %w", op, title, domain.ErrDuplicateName)
+		}
+		updates["title"] = title
+	}
+	if req.Family != nil {
+		updates["family"] = strings.TrimSpace(*req.Family)
+	}
+	if req.Level != nil {
+		updates["level"] = *req.Level
+	}
+	if req.GradeBand != nil {
+		updates["grade_band"] = strings.TrimSpace(*req.GradeBand)
+	}
+
+	// Go to repo to update
+	if len(updates) > 0 {
+		if err := s.repo.Position().Update(ctx, id, updates); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%s: failed to update position: %w", op, domain.ErrPositionNotFound)
+			}
+			return nil, fmt.Errorf("%s: failed to update position: %w", op, err)
+		}
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// Delete - Delete position by id, only if no employee holds it and no vacancy is opened for it
+func (s *positionService) Delete(ctx context.Context, id int) error {
+	const op = "service.position.Delete"
+
+	if err := requireAdmin(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	used, err := s.repo.Position().IsUsed(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: failed to check position usage: %w", op, err)
+	}
+	if used {
+		return fmt.Errorf("%s: position with id '%d' is held by employees or vacancies: %w", op, id, domain.ErrPositionInUse)
+	}
+
+	// Go to repo
+	if err := s.repo.Position().Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%s: failed to delete position: %w", op, domain.ErrPositionNotFound)
+		}
+		return fmt.Errorf("%s: failed to delete position: %w", op, err)
+	}
+
+	return nil
+}
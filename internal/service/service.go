@@ -4,13 +4,21 @@ import (
 	"log/slog"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/tmozzze/org_struct_api/internal/config"
 	"github.com/tmozzze/org_struct_api/internal/domain"
+	"go.opentelemetry.io/otel"
 )
 
 // Service - main service struct
 type Service struct {
 	department domain.DepartmentService
 	employee   domain.EmployeeService
+	position   domain.PositionService
+	vacancy    domain.VacancyService
+	attribute  domain.AttributeService
+	deptType   domain.DepartmentTypeService
+	apiKey     domain.APIKeyService
+	grant      domain.GrantService
 	log        *slog.Logger
 	validate   *validator.Validate
 }
@@ -20,10 +28,23 @@ func NewService(
 	repo domain.Repository,
 	log *slog.Logger,
 	validate *validator.Validate,
+	employees config.EmployeesCfg,
 ) domain.Service {
+	// Spans of global tracer provider, no-op until one is installed
+	tracer := otel.Tracer(tracerName)
+	employee := func(repo domain.Repository) domain.EmployeeService {
+		return traceEmployeeService(newEmployeeService(repo, log, validate, employees.NumberPattern), tracer)
+	}
+
 	return &Service{
-		department: newDepartmentService(repo, log, validate),
-		employee:   newEmployeeService(repo, log, validate),
+		department: traceDepartmentService(newDepartmentService(repo, log, validate), tracer),
+		employee:   employee(repo),
+		position:   newPositionService(repo, log, validate),
+		vacancy:    newVacancyService(repo, employee, log, validate),
+		attribute:  newAttributeService(repo, log, validate),
+		deptType:   newDepartmentTypeService(repo, log, validate),
+		apiKey:     newAPIKeyService(repo, log, validate),
+		grant:      newGrantService(repo, log, validate),
 		log:        log,
 		validate:   validate,
 	}
@@ -38,3 +59,44 @@ func (s *Service) Department() domain.DepartmentService {
 func (s *Service) Employee() domain.EmployeeService {
 	return s.employee
 }
+
+// Position - return PositionService
+func (s *Service) Position() domain.PositionService {
+	return s.position
+}
+
+// Vacancy - return VacancyService
+func (s *Service) Vacancy() domain.VacancyService {
+	return s.vacancy
+}
+
+// Attribute - return AttributeService
+func (s *Service) Attribute() domain.AttributeService {
+	return s.attribute
+}
+
+// DepartmentType - return DepartmentTypeService
+func (s *Service) DepartmentType() domain.DepartmentTypeService {
+	return s.deptType
+}
+
+// APIKey - return APIKeyService
+func (s *Service) APIKey() domain.APIKeyService {
+	return s.apiKey
+}
+
+// Grant - return GrantService
+func (s *Service) Grant() domain.GrantService {
+	return s.grant
+}
+
+// pageLimit - apply default and max limit of one page
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return domain.DefaultPageLimit
+	}
+	if limit > domain.MaxPageLimit {
+		return domain.MaxPageLimit
+	}
+	return limit
+}
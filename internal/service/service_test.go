@@ -2,19 +2,34 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/tmozzze/org_struct_api/internal/config"
 	"github.com/tmozzze/org_struct_api/internal/domain"
 	"github.com/tmozzze/org_struct_api/internal/domain/dto"
 	"github.com/tmozzze/org_struct_api/internal/domain/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // MOCKS
@@ -28,8 +43,8 @@ func (m *MockDepartmentRepo) Create(ctx context.Context, dept *models.Department
 	return args.Error(0)
 }
 
-func (m *MockDepartmentRepo) GetByID(ctx context.Context, id int, depth int, includeEmployees bool) (*models.Department, error) {
-	args := m.Called(ctx, id, depth, includeEmployees)
+func (m *MockDepartmentRepo) GetByID(ctx context.Context, id int, opts domain.TreeOptions) (*models.Department, error) {
+	args := m.Called(ctx, id, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -52,6 +67,14 @@ func (m *MockDepartmentRepo) GetByNameAndParent(ctx context.Context, name string
 	return args.Get(0).(*models.Department), args.Error(1)
 }
 
+func (m *MockDepartmentRepo) GetByCostCenter(ctx context.Context, costCenter string) (*models.Department, error) {
+	args := m.Called(ctx, costCenter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Department), args.Error(1)
+}
+
 func (m *MockDepartmentRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
 	args := m.Called(ctx, id, updates)
 	return args.Error(0)
@@ -72,136 +95,2169 @@ func (m *MockDepartmentRepo) Exists(ctx context.Context, id int) (bool, error) {
 	return args.Bool(0), args.Error(1)
 }
 
-type MockRepoWrapper struct {
+func (m *MockDepartmentRepo) Search(ctx context.Context, query string, limit int) ([]models.DepartmentSearchResult, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DepartmentSearchResult), args.Error(1)
+}
+
+func (m *MockDepartmentRepo) ListChildren(ctx context.Context, parentID int, filter domain.DepartmentFilter, after *models.Cursor, limit int) ([]models.Department, error) {
+	args := m.Called(ctx, parentID, filter, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Department), args.Error(1)
+}
+
+func (m *MockDepartmentRepo) GetCounts(ctx context.Context, ids []int) (map[int]models.DepartmentCounts, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]models.DepartmentCounts), args.Error(1)
+}
+
+func (m *MockDepartmentRepo) GetStats(ctx context.Context, id int) (*models.DepartmentStats, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DepartmentStats), args.Error(1)
+}
+
+func (m *MockDepartmentRepo) GetTotals(ctx context.Context) (*models.OrgTotals, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrgTotals), args.Error(1)
+}
+
+func (m *MockDepartmentRepo) GetBudget(ctx context.Context, id int) ([]models.DepartmentBudget, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DepartmentBudget), args.Error(1)
+}
+
+func (m *MockDepartmentRepo) NextSortOrder(ctx context.Context, parentID *int) (int, error) {
+	args := m.Called(ctx, parentID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockDepartmentRepo) Reorder(ctx context.Context, parentID int, childIDs []int) error {
+	args := m.Called(ctx, parentID, childIDs)
+	return args.Error(0)
+}
+
+func (m *MockDepartmentRepo) UnsetInvalidHeads(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockDepartmentRepo) GetSubtree(ctx context.Context, ids []int) ([]models.DepartmentNode, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DepartmentNode), args.Error(1)
+}
+
+func (m *MockDepartmentRepo) GetAncestors(ctx context.Context, id int) ([]models.Department, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Department), args.Error(1)
+}
+
+type MockEmployeeRepo struct {
 	mock.Mock
-	deptRepo *MockDepartmentRepo
 }
 
-func (m *MockRepoWrapper) Department() domain.DepartmentRepository {
-	return m.deptRepo
+func (m *MockEmployeeRepo) GetByID(ctx context.Context, id int) (*models.Employee, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Employee), args.Error(1)
 }
-func (m *MockRepoWrapper) Employee() domain.EmployeeRepository {
-	return nil
+
+func (m *MockEmployeeRepo) Create(ctx context.Context, emp *models.Employee) error {
+	args := m.Called(ctx, emp)
+	return args.Error(0)
 }
 
-// SUITE
+func (m *MockEmployeeRepo) UpdateDepartmentForEmployees(ctx context.Context, oldDeptID int, newDeptID int) error {
+	args := m.Called(ctx, oldDeptID, newDeptID)
+	return args.Error(0)
+}
 
-type DepartmentServiceTestSuite struct {
-	suite.Suite
-	repo     *MockDepartmentRepo
-	wrapper  *MockRepoWrapper
-	service  domain.DepartmentService
-	validate *validator.Validate
+func (m *MockEmployeeRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
 }
 
-func (suite *DepartmentServiceTestSuite) SetupTest() {
-	suite.repo = new(MockDepartmentRepo)
-	suite.wrapper = &MockRepoWrapper{deptRepo: suite.repo}
-	suite.validate = validator.New()
-	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+func (m *MockEmployeeRepo) Exists(ctx context.Context, id int) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
 
-	suite.service = newDepartmentService(suite.wrapper, logger, suite.validate)
+func (m *MockEmployeeRepo) Lock(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
 }
 
-func TestDepartmentServiceSuite(t *testing.T) {
-	suite.Run(t, new(DepartmentServiceTestSuite))
+func (m *MockEmployeeRepo) ListDirectReports(ctx context.Context, managerID int) ([]models.Employee, error) {
+	args := m.Called(ctx, managerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Employee), args.Error(1)
 }
 
-// TESTS
+func (m *MockEmployeeRepo) ListAllReports(ctx context.Context, managerID int) ([]models.Employee, error) {
+	args := m.Called(ctx, managerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Employee), args.Error(1)
+}
 
-func (suite *DepartmentServiceTestSuite) TestCreate_Success() {
-	req := &dto.CreateDepartmentRequest{
-		Name:     "Backend",
-		ParentID: nil,
+func (m *MockEmployeeRepo) GetManagementChain(ctx context.Context, id int) ([]models.Employee, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).([]models.Employee), args.Error(1)
+}
 
-	suite.repo.On("GetByNameAndParent", mock.Anything, "Backend", mock.Anything).Return(nil, nil)
-	suite.repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Department")).Return(nil)
+func (m *MockEmployeeRepo) GetSpanOfControl(ctx context.Context) ([]models.ManagerSpan, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ManagerSpan), args.Error(1)
+}
 
-	resp, err := suite.service.Create(context.Background(), req)
+func (m *MockEmployeeRepo) ListByDepartment(ctx context.Context, deptID int, filter domain.EmployeeFilter, after *models.Cursor, limit int) ([]models.Employee, error) {
+	args := m.Called(ctx, deptID, filter, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Employee), args.Error(1)
+}
 
-	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), "Backend", resp.Name)
-	suite.repo.AssertExpectations(suite.T())
+func (m *MockEmployeeRepo) Terminate(ctx context.Context, id int, terminatedAt time.Time) error {
+	args := m.Called(ctx, id, terminatedAt)
+	return args.Error(0)
 }
 
-func (suite *DepartmentServiceTestSuite) TestCreate_DuplicateName() {
-	req := &dto.CreateDepartmentRequest{Name: "HR"}
+func (m *MockEmployeeRepo) GetByEmail(ctx context.Context, email string) (*models.Employee, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Employee), args.Error(1)
+}
 
-	suite.repo.On("GetByNameAndParent", mock.Anything, "HR", mock.Anything).
-		Return(&models.Department{ID: 1, Name: "HR"}, nil)
+func (m *MockEmployeeRepo) GetByNumber(ctx context.Context, number string) (*models.Employee, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Employee), args.Error(1)
+}
 
-	resp, err := suite.service.Create(context.Background(), req)
+func (m *MockEmployeeRepo) NextNumber(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
 
-	assert.Error(suite.T(), err)
-	assert.ErrorIs(suite.T(), err, domain.ErrDuplicateName)
-	assert.Nil(suite.T(), resp)
+type MockAssignmentRepo struct {
+	mock.Mock
 }
 
-func (suite *DepartmentServiceTestSuite) TestUpdate_CycleError() {
-	// Try relocation 1 in his grandchild 3
-	// Tree: 1 -> 2 -> 3
-	idToMove := 1
-	newParentID := 3
+func (m *MockAssignmentRepo) Create(ctx context.Context, a *models.EmployeeAssignment) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
 
-	req := &dto.UpdateDepartmentRequest{
-		ParentID: &newParentID,
+func (m *MockAssignmentRepo) GetByID(ctx context.Context, id int) (*models.EmployeeAssignment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).(*models.EmployeeAssignment), args.Error(1)
+}
 
-	suite.repo.On("GetByIDSimple", mock.Anything, idToMove).Return(&models.Department{ID: 1, ParentID: nil}, nil)
-	suite.repo.On("Exists", mock.Anything, newParentID).Return(true, nil)
+func (m *MockAssignmentRepo) ListByEmployee(ctx context.Context, employeeID int) ([]models.EmployeeAssignment, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EmployeeAssignment), args.Error(1)
+}
 
-	// checkCycle: 3 -> 2 -> 1
-	suite.repo.On("GetByIDSimple", mock.Anything, 3).Return(&models.Department{ID: 3, ParentID: ptr(2)}, nil)
-	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2, ParentID: ptr(1)}, nil)
+func (m *MockAssignmentRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
 
-	resp, err := suite.service.Update(context.Background(), idToMove, req)
+func (m *MockAssignmentRepo) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
 
-	assert.Error(suite.T(), err)
-	assert.ErrorIs(suite.T(), err, domain.ErrCycleConstraint)
-	assert.Nil(suite.T(), resp)
+func (m *MockAssignmentRepo) SumAllocation(ctx context.Context, employeeID int, excludeID int) (int, error) {
+	args := m.Called(ctx, employeeID, excludeID)
+	return args.Int(0), args.Error(1)
 }
 
-func (suite *DepartmentServiceTestSuite) TestDelete_ReassignSuccess() {
-	idToDelete := 10
-	reassignID := 20
-	mode := domain.ModeReassign
+type MockPositionRepo struct {
+	mock.Mock
+}
 
-	req := &dto.DeleteDepartmentRequest{
-		Mode:         mode,
-		ReassignToID: &reassignID,
+func (m *MockPositionRepo) Create(ctx context.Context, pos *models.Position) error {
+	args := m.Called(ctx, pos)
+	return args.Error(0)
+}
+
+func (m *MockPositionRepo) GetByID(ctx context.Context, id int) (*models.Position, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).(*models.Position), args.Error(1)
+}
 
-	suite.repo.On("Exists", mock.Anything, idToDelete).Return(true, nil)
-	suite.repo.On("Exists", mock.Anything, reassignID).Return(true, nil)
-	suite.repo.On("DeleteWithReassign", mock.Anything, idToDelete, reassignID).Return(nil)
+func (m *MockPositionRepo) GetByTitle(ctx context.Context, title string) (*models.Position, error) {
+	args := m.Called(ctx, title)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Position), args.Error(1)
+}
 
-	err := suite.service.Delete(context.Background(), idToDelete, req)
+func (m *MockPositionRepo) List(ctx context.Context) ([]models.Position, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Position), args.Error(1)
+}
 
-	assert.NoError(suite.T(), err)
-	suite.repo.AssertExpectations(suite.T())
+func (m *MockPositionRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
 }
 
-func (suite *DepartmentServiceTestSuite) TestDelete_ReassignToSameDepartment() {
-	idToDelete := 20
-	reassignID := 20
-	mode := domain.ModeReassign
+func (m *MockPositionRepo) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
 
-	req := &dto.DeleteDepartmentRequest{
-		Mode:         mode,
-		ReassignToID: &reassignID,
+func (m *MockPositionRepo) IsUsed(ctx context.Context, id int) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockVacancyRepo struct {
+	mock.Mock
+}
+
+func (m *MockVacancyRepo) Create(ctx context.Context, v *models.Vacancy) error {
+	args := m.Called(ctx, v)
+	return args.Error(0)
+}
+
+func (m *MockVacancyRepo) GetByID(ctx context.Context, id int) (*models.Vacancy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).(*models.Vacancy), args.Error(1)
+}
 
-	suite.repo.On("Exists", mock.Anything, idToDelete).Return(true, nil)
-	suite.repo.On("Exists", mock.Anything, reassignID).Return(true, nil)
-	suite.repo.On("DeleteWithReassign", mock.Anything, idToDelete, reassignID).Return(nil)
+func (m *MockVacancyRepo) ListByDepartment(ctx context.Context, deptID int, status string) ([]models.Vacancy, error) {
+	args := m.Called(ctx, deptID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Vacancy), args.Error(1)
+}
 
-	err := suite.service.Delete(context.Background(), idToDelete, req)
+func (m *MockVacancyRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
 
-	assert.Error(suite.T(), err)
-	assert.ErrorIs(suite.T(), err, domain.ErrInvalidReassignToID)
+func (m *MockVacancyRepo) SetStatus(ctx context.Context, id int, from string, to string) error {
+	args := m.Called(ctx, id, from, to)
+	return args.Error(0)
 }
 
-func ptr(i int) *int {
-	return &i
+func (m *MockVacancyRepo) GetHeadcount(ctx context.Context, deptID int) ([]models.DepartmentHeadcount, error) {
+	args := m.Called(ctx, deptID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DepartmentHeadcount), args.Error(1)
+}
+
+type MockAttributeRepo struct {
+	mock.Mock
+}
+
+func (m *MockAttributeRepo) Create(ctx context.Context, def *models.AttributeDefinition) error {
+	args := m.Called(ctx, def)
+	return args.Error(0)
+}
+
+func (m *MockAttributeRepo) GetByID(ctx context.Context, id int) (*models.AttributeDefinition, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockAttributeRepo) GetByName(ctx context.Context, entityType string, name string) (*models.AttributeDefinition, error) {
+	args := m.Called(ctx, entityType, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockAttributeRepo) List(ctx context.Context, entityType string) ([]models.AttributeDefinition, error) {
+	args := m.Called(ctx, entityType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockAttributeRepo) Update(ctx context.Context, id int, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockAttributeRepo) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockDepartmentTypeRepo struct {
+	mock.Mock
+}
+
+func (m *MockDepartmentTypeRepo) GetByName(ctx context.Context, name string) (*models.DepartmentType, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DepartmentType), args.Error(1)
+}
+
+func (m *MockDepartmentTypeRepo) List(ctx context.Context) ([]models.DepartmentType, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DepartmentType), args.Error(1)
+}
+
+func (m *MockDepartmentTypeRepo) Save(ctx context.Context, t *models.DepartmentType) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockDepartmentTypeRepo) Delete(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockDepartmentTypeRepo) IsUsed(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockAPIKeyRepo struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepo) Create(ctx context.Context, key *models.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepo) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) List(ctx context.Context) ([]models.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) Revoke(ctx context.Context, id int, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepo) Touch(ctx context.Context, id int, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+type MockGrantRepo struct {
+	mock.Mock
+}
+
+func (m *MockGrantRepo) Save(ctx context.Context, g *models.DepartmentGrant) error {
+	args := m.Called(ctx, g)
+	return args.Error(0)
+}
+
+func (m *MockGrantRepo) ListByDepartment(ctx context.Context, deptID int) ([]models.DepartmentGrant, error) {
+	args := m.Called(ctx, deptID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DepartmentGrant), args.Error(1)
+}
+
+func (m *MockGrantRepo) Delete(ctx context.Context, deptID int, id int) error {
+	args := m.Called(ctx, deptID, id)
+	return args.Error(0)
+}
+
+func (m *MockGrantRepo) GetRoles(ctx context.Context, subject string, deptID int) ([]string, error) {
+	args := m.Called(ctx, subject, deptID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type MockRepoWrapper struct {
+	mock.Mock
+	deptRepo       *MockDepartmentRepo
+	empRepo        *MockEmployeeRepo
+	assignmentRepo *MockAssignmentRepo
+	posRepo        *MockPositionRepo
+	vacancyRepo    *MockVacancyRepo
+	attrRepo       *MockAttributeRepo
+	deptTypeRepo   *MockDepartmentTypeRepo
+	apiKeyRepo     *MockAPIKeyRepo
+	grantRepo      *MockGrantRepo
+}
+
+func (m *MockRepoWrapper) Department() domain.DepartmentRepository {
+	return m.deptRepo
+}
+func (m *MockRepoWrapper) Employee() domain.EmployeeRepository {
+	return m.empRepo
+}
+func (m *MockRepoWrapper) Assignment() domain.AssignmentRepository {
+	return m.assignmentRepo
+}
+func (m *MockRepoWrapper) Position() domain.PositionRepository {
+	return m.posRepo
+}
+func (m *MockRepoWrapper) Vacancy() domain.VacancyRepository {
+	return m.vacancyRepo
+}
+func (m *MockRepoWrapper) Attribute() domain.AttributeRepository {
+	return m.attrRepo
+}
+func (m *MockRepoWrapper) DepartmentType() domain.DepartmentTypeRepository {
+	return m.deptTypeRepo
+}
+func (m *MockRepoWrapper) APIKey() domain.APIKeyRepository {
+	return m.apiKeyRepo
+}
+func (m *MockRepoWrapper) Grant() domain.GrantRepository {
+	return m.grantRepo
+}
+func (m *MockRepoWrapper) Transaction(ctx context.Context, fn func(repo domain.Repository) error) error {
+	return fn(m)
+}
+
+// SUITE
+
+type DepartmentServiceTestSuite struct {
+	suite.Suite
+	repo           *MockDepartmentRepo
+	empRepo        *MockEmployeeRepo
+	assignmentRepo *MockAssignmentRepo
+	posRepo        *MockPositionRepo
+	vacancyRepo    *MockVacancyRepo
+	attrRepo       *MockAttributeRepo
+	deptTypeRepo   *MockDepartmentTypeRepo
+	apiKeyRepo     *MockAPIKeyRepo
+	grantRepo      *MockGrantRepo
+	wrapper        *MockRepoWrapper
+	service        domain.DepartmentService
+	empService     domain.EmployeeService
+	posService     domain.PositionService
+	vacService     domain.VacancyService
+	attrService    domain.AttributeService
+	typeService    domain.DepartmentTypeService
+	keyService     domain.APIKeyService
+	grantService   domain.GrantService
+	validate       *validator.Validate
+}
+
+func (suite *DepartmentServiceTestSuite) SetupTest() {
+	suite.repo = new(MockDepartmentRepo)
+	suite.empRepo = new(MockEmployeeRepo)
+	suite.assignmentRepo = new(MockAssignmentRepo)
+	suite.posRepo = new(MockPositionRepo)
+	suite.vacancyRepo = new(MockVacancyRepo)
+	suite.attrRepo = new(MockAttributeRepo)
+	suite.deptTypeRepo = new(MockDepartmentTypeRepo)
+	suite.apiKeyRepo = new(MockAPIKeyRepo)
+	suite.grantRepo = new(MockGrantRepo)
+	suite.wrapper = &MockRepoWrapper{
+		deptRepo:       suite.repo,
+		empRepo:        suite.empRepo,
+		assignmentRepo: suite.assignmentRepo,
+		posRepo:        suite.posRepo,
+		vacancyRepo:    suite.vacancyRepo,
+		attrRepo:       suite.attrRepo,
+		deptTypeRepo:   suite.deptTypeRepo,
+		apiKeyRepo:     suite.apiKeyRepo,
+		grantRepo:      suite.grantRepo,
+	}
+	suite.validate = validator.New()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.service = newDepartmentService(suite.wrapper, logger, suite.validate)
+	suite.empService = newEmployeeService(suite.wrapper, logger, suite.validate, "EMP-%06d")
+	suite.posService = newPositionService(suite.wrapper, logger, suite.validate)
+	suite.vacService = newVacancyService(suite.wrapper, func(repo domain.Repository) domain.EmployeeService {
+		return newEmployeeService(repo, logger, suite.validate, "EMP-%06d")
+	}, logger, suite.validate)
+	suite.attrService = newAttributeService(suite.wrapper, logger, suite.validate)
+	suite.typeService = newDepartmentTypeService(suite.wrapper, logger, suite.validate)
+	suite.keyService = newAPIKeyService(suite.wrapper, logger, suite.validate)
+	suite.grantService = newGrantService(suite.wrapper, logger, suite.validate)
+}
+
+func TestDepartmentServiceSuite(t *testing.T) {
+	suite.Run(t, new(DepartmentServiceTestSuite))
+}
+
+// TESTS
+
+func (suite *DepartmentServiceTestSuite) TestCreate_Success() {
+	req := &dto.CreateDepartmentRequest{
+		Name:     "Backend",
+		ParentID: nil,
+	}
+
+	suite.repo.On("GetByNameAndParent", mock.Anything, "Backend", mock.Anything).Return(nil, nil)
+	suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityDepartment).Return(nil, nil)
+	suite.repo.On("NextSortOrder", mock.Anything, (*int)(nil)).Return(4, nil)
+	suite.repo.On("Create", mock.Anything, mock.MatchedBy(func(d *models.Department) bool {
+		return d.SortOrder == 4
+	})).Return(nil)
+
+	resp, err := suite.service.Create(context.Background(), req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Backend", resp.Name)
+	assert.Equal(suite.T(), 4, resp.SortOrder)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreate_DuplicateName() {
+	req := &dto.CreateDepartmentRequest{Name: "HR"}
+
+	suite.repo.On("GetByNameAndParent", mock.Anything, "HR", mock.Anything).
+		Return(&models.Department{ID: 1, Name: "HR"}, nil)
+
+	resp, err := suite.service.Create(context.Background(), req)
+
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, domain.ErrDuplicateName)
+	assert.Nil(suite.T(), resp)
+
+	var conflict *domain.ConflictError
+	assert.ErrorAs(suite.T(), err, &conflict)
+	assert.Equal(suite.T(), dto.DepartmentResponse{ID: 1, Name: "HR"}, conflict.Existing)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreate_NormalizesName() {
+	// Decomposed "é", non-breaking and repeated spaces
+	req := &dto.CreateDepartmentRequest{Name: "  Cafe\u0301\u00a0  Ops "}
+
+	suite.repo.On("GetByNameAndParent", mock.Anything, "Caf\u00e9 Ops", (*int)(nil)).Return(nil, nil)
+	suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityDepartment).Return(nil, nil)
+	suite.repo.On("NextSortOrder", mock.Anything, (*int)(nil)).Return(1, nil)
+	suite.repo.On("Create", mock.Anything, mock.MatchedBy(func(d *models.Department) bool {
+		return d.Name == "Caf\u00e9 Ops"
+	})).Return(nil)
+
+	resp, err := suite.service.Create(context.Background(), req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Caf\u00e9 Ops", resp.Name)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_RenameCaseOnly() {
+	// Renaming "sales" to "Sales" must not conflict with itself
+	name := "Sales"
+	req := &dto.UpdateDepartmentRequest{Name: &name}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 3).Return(&models.Department{ID: 3, Name: "sales"}, nil)
+	suite.repo.On("GetByNameAndParent", mock.Anything, "Sales", (*int)(nil)).Return(&models.Department{ID: 3, Name: "sales"}, nil)
+	suite.repo.On("Update", mock.Anything, 3, map[string]interface{}{"name": "Sales"}).Return(nil)
+	suite.repo.On("GetByID", mock.Anything, 3, domain.TreeOptions{Depth: 1}).Return(&models.Department{ID: 3, Name: "Sales"}, nil)
+
+	resp, err := suite.service.Update(context.Background(), 3, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Sales", resp.Name)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_CycleError() {
+	// Try relocation 1 in his grandchild 3
+	// Tree: 1 -> 2 -> 3
+	idToMove := 1
+	newParentID := 3
+
+	req := &dto.UpdateDepartmentRequest{
+		ParentID: &newParentID,
+	}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, idToMove).Return(&models.Department{ID: 1, ParentID: nil}, nil)
+	suite.repo.On("Exists", mock.Anything, newParentID).Return(true, nil)
+
+	// checkCycle: 3 -> 2 -> 1
+	suite.repo.On("GetByIDSimple", mock.Anything, 3).Return(&models.Department{ID: 3, ParentID: ptr(2)}, nil)
+	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2, ParentID: ptr(1)}, nil)
+
+	resp, err := suite.service.Update(context.Background(), idToMove, req)
+
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, domain.ErrCycleConstraint)
+	assert.Nil(suite.T(), resp)
+}
+
+func (suite *DepartmentServiceTestSuite) TestDelete_ReassignSuccess() {
+	idToDelete := 10
+	reassignID := 20
+	mode := domain.ModeReassign
+
+	req := &dto.DeleteDepartmentRequest{
+		Mode:         mode,
+		ReassignToID: &reassignID,
+	}
+
+	suite.repo.On("Exists", mock.Anything, idToDelete).Return(true, nil)
+	suite.repo.On("Exists", mock.Anything, reassignID).Return(true, nil)
+	suite.repo.On("DeleteWithReassign", mock.Anything, idToDelete, reassignID).Return(nil)
+	suite.repo.On("UnsetInvalidHeads", mock.Anything, reassignID).Return(nil)
+
+	err := suite.service.Delete(context.Background(), idToDelete, req)
+
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *DepartmentServiceTestSuite) TestDelete_ReassignUnsetHeadsFails() {
+	reassignID := 20
+	req := &dto.DeleteDepartmentRequest{Mode: domain.ModeReassign, ReassignToID: &reassignID}
+
+	suite.repo.On("Exists", mock.Anything, 10).Return(true, nil)
+	suite.repo.On("Exists", mock.Anything, reassignID).Return(true, nil)
+	suite.repo.On("DeleteWithReassign", mock.Anything, 10, reassignID).Return(nil)
+	suite.repo.On("UnsetInvalidHeads", mock.Anything, reassignID).Return(assert.AnError)
+
+	err := suite.service.Delete(context.Background(), 10, req)
+
+	assert.ErrorContains(suite.T(), err, "failed to unset heads of reassigned employees")
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *DepartmentServiceTestSuite) TestDelete_ReassignToSameDepartment() {
+	idToDelete := 20
+	reassignID := 20
+	mode := domain.ModeReassign
+
+	req := &dto.DeleteDepartmentRequest{
+		Mode:         mode,
+		ReassignToID: &reassignID,
+	}
+
+	suite.repo.On("Exists", mock.Anything, idToDelete).Return(true, nil)
+	suite.repo.On("Exists", mock.Anything, reassignID).Return(true, nil)
+	suite.repo.On("DeleteWithReassign", mock.Anything, idToDelete, reassignID).Return(nil)
+
+	err := suite.service.Delete(context.Background(), idToDelete, req)
+
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidReassignToID)
+}
+
+func (suite *DepartmentServiceTestSuite) TestSearch_Success() {
+	req := &dto.SearchDepartmentsRequest{Query: "  Équipe "}
+
+	suite.repo.On("Search", mock.Anything, "Équipe", domain.DefaultSearchLimit).Return([]models.DepartmentSearchResult{
+		{
+			Department: models.Department{ID: 5, Name: "Equipe", ParentID: ptr(2)},
+			Path:       []models.DepartmentAncestor{{ID: 1, Name: "Root"}, {ID: 2, Name: "Sales"}},
+		},
+	}, nil)
+
+	resp, err := suite.service.Search(context.Background(), req)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), resp, 1)
+	assert.Equal(suite.T(), []dto.DepartmentPathItem{{ID: 1, Name: "Root"}, {ID: 2, Name: "Sales"}}, resp[0].Path)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *DepartmentServiceTestSuite) TestSearch_EmptyQuery() {
+	req := &dto.SearchDepartmentsRequest{Query: "   "}
+
+	resp, err := suite.service.Search(context.Background(), req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrEmptyConstraint)
+	assert.Nil(suite.T(), resp)
+	suite.repo.AssertNotCalled(suite.T(), "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestGetByID_TruncatesWithCursor() {
+	req := &dto.GetByIDRequest{Depth: 1, IncludeEmployees: true, EmployeesLimit: 2}
+	opts := domain.TreeOptions{Depth: 1, IncludeEmployees: true, ChildrenLimit: domain.DefaultPageLimit, EmployeesLimit: 2}
+
+	// Repo returns limit+1 employees
+	suite.repo.On("GetByID", mock.Anything, 1, opts).Return(&models.Department{
+		ID:   1,
+		Name: "IT",
+		Employees: []models.Employee{
+			{ID: 7, FullName: "Anna"},
+			{ID: 3, FullName: "Boris"},
+			{ID: 5, FullName: "Clara"},
+		},
+	}, nil)
+
+	resp, err := suite.service.GetByID(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), resp.Employees, 2)
+	assert.Nil(suite.T(), resp.ChildrenNextCursor)
+
+	if assert.NotNil(suite.T(), resp.EmployeesNextCursor) {
+		cursor, err := dto.DecodeCursor(*resp.EmployeesNextCursor)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), &models.Cursor{Key: "Boris", ID: 3}, cursor)
+	}
+}
+
+func (suite *DepartmentServiceTestSuite) TestListChildren_NextPage() {
+	after := &models.Cursor{Key: "Backend", ID: 4}
+	req := &dto.ListChildrenRequest{PageRequest: dto.PageRequest{Cursor: dto.EncodeCursor(*after), Limit: 1}}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.repo.On("ListChildren", mock.Anything, 1, domain.DepartmentFilter{}, after, 2).Return([]models.Department{
+		{ID: 6, Name: "Frontend"},
+		{ID: 2, Name: "QA"},
+	}, nil)
+
+	page, err := suite.service.ListChildren(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), page.Items, 1)
+	assert.Equal(suite.T(), "Frontend", page.Items[0].Name)
+	if assert.NotNil(suite.T(), page.NextCursor) {
+		cursor, _ := dto.DecodeCursor(*page.NextCursor)
+		assert.Equal(suite.T(), &models.Cursor{Key: "Frontend", ID: 6}, cursor)
+	}
+}
+
+func (suite *DepartmentServiceTestSuite) TestListChildren_InvalidCursor() {
+	req := &dto.ListChildrenRequest{PageRequest: dto.PageRequest{Cursor: "not a cursor"}}
+
+	page, err := suite.service.ListChildren(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidCursor)
+	assert.Nil(suite.T(), page)
+}
+
+func (suite *DepartmentServiceTestSuite) TestListEmployees_LastPage() {
+	req := &dto.ListEmployeesRequest{}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.empRepo.On("ListByDepartment", mock.Anything, 1, domain.EmployeeFilter{}, (*models.Cursor)(nil), domain.DefaultPageLimit+1).
+		Return([]models.Employee{{ID: 1, DepartmentID: 1, FullName: "Oleg Moroz"}}, nil)
+
+	page, err := suite.empService.ListByDepartment(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), page.Items, 1)
+	assert.Nil(suite.T(), page.NextCursor)
+}
+
+func (suite *DepartmentServiceTestSuite) TestGetByID_IncludeStats() {
+	req := &dto.GetByIDRequest{Depth: 1, IncludeStats: true}
+
+	suite.repo.On("GetByID", mock.Anything, 1, mock.Anything).Return(&models.Department{
+		ID:       1,
+		Name:     "Engineering",
+		Children: []models.Department{{ID: 2, Name: "Backend", ParentID: ptr(1)}},
+	}, nil)
+	suite.repo.On("GetCounts", mock.Anything, []int{1, 2}).Return(map[int]models.DepartmentCounts{
+		1: {DepartmentID: 1, DirectEmployeeCount: 1, TotalEmployeeCount: 4, DirectChildrenCount: 1, TotalDescendantCount: 1},
+		2: {DepartmentID: 2, DirectEmployeeCount: 3, TotalEmployeeCount: 3},
+	}, nil)
+
+	resp, err := suite.service.GetByID(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(4), *resp.TotalEmployeeCount)
+	assert.Equal(suite.T(), int64(1), *resp.TotalDescendantCount)
+	assert.Equal(suite.T(), int64(3), *resp.Children[0].DirectEmployeeCount)
+	assert.Equal(suite.T(), int64(0), *resp.Children[0].DirectChildrenCount)
+}
+
+func (suite *DepartmentServiceTestSuite) TestGetStats_NotFound() {
+	suite.repo.On("Exists", mock.Anything, 42).Return(false, nil)
+
+	resp, err := suite.service.GetStats(context.Background(), 42)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrDepartmentNotFound)
+	assert.Nil(suite.T(), resp)
+	suite.repo.AssertNotCalled(suite.T(), "GetStats", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestSetHead_FromAncestor() {
+	// Tree: 1 -> 2, head of 2 works in 1
+	req := &dto.SetHeadRequest{EmployeeID: ptr(10)}
+
+	suite.repo.On("GetAncestors", mock.Anything, 2).Return([]models.Department{
+		{ID: 2, ParentID: ptr(1)},
+		{ID: 1},
+	}, nil)
+	suite.empRepo.On("GetByID", mock.Anything, 10).Return(&models.Employee{ID: 10, DepartmentID: 1}, nil)
+	suite.repo.On("Update", mock.Anything, 2, map[string]interface{}{"head_employee_id": req.EmployeeID}).Return(nil)
+	suite.repo.On("GetByID", mock.Anything, 2, domain.TreeOptions{Depth: 1}).
+		Return(&models.Department{ID: 2, HeadEmployeeID: ptr(10), Head: &models.Employee{ID: 10, DepartmentID: 1}}, nil)
+
+	resp, err := suite.service.SetHead(context.Background(), 2, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 10, resp.Head.ID)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *DepartmentServiceTestSuite) TestSetHead_FromDescendant() {
+	// Tree: 1 -> 2, employee of 2 can not lead 1
+	req := &dto.SetHeadRequest{EmployeeID: ptr(20)}
+
+	suite.repo.On("GetAncestors", mock.Anything, 1).Return([]models.Department{{ID: 1}}, nil)
+	suite.empRepo.On("GetByID", mock.Anything, 20).Return(&models.Employee{ID: 20, DepartmentID: 2}, nil)
+
+	resp, err := suite.service.SetHead(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidHead)
+	assert.Nil(suite.T(), resp)
+	suite.repo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestGetManagerChain_SkipsSelf() {
+	// Tree: 1 -> 2 -> 3, employee 30 leads 3, 2 has no head
+	suite.empRepo.On("GetByID", mock.Anything, 30).Return(&models.Employee{ID: 30, DepartmentID: 3}, nil)
+	suite.repo.On("GetAncestors", mock.Anything, 3).Return([]models.Department{
+		{ID: 3, Name: "Platform", Head: &models.Employee{ID: 30}},
+		{ID: 2, Name: "Backend"},
+		{ID: 1, Name: "Engineering", Head: &models.Employee{ID: 10, FullName: "CTO"}},
+	}, nil)
+
+	chain, err := suite.empService.GetManagerChain(context.Background(), 30)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), chain, 1)
+	assert.Equal(suite.T(), "Engineering", chain[0].DepartmentName)
+	assert.Equal(suite.T(), 10, chain[0].Head.ID)
+}
+
+func (suite *DepartmentServiceTestSuite) TestSetManager_CycleError() {
+	// Reporting: 3 -> 2 -> 1, try to make 3 the manager of 1
+	req := &dto.SetManagerRequest{ManagerID: ptr(3)}
+
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1}, nil)
+	suite.empRepo.On("GetByID", mock.Anything, 3).Return(&models.Employee{ID: 3, ManagerID: ptr(2)}, nil)
+	suite.empRepo.On("GetByID", mock.Anything, 2).Return(&models.Employee{ID: 2, ManagerID: ptr(1)}, nil)
+
+	resp, err := suite.empService.SetManager(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrCycleConstraint)
+	assert.Nil(suite.T(), resp)
+	suite.empRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestSetManager_Self() {
+	req := &dto.SetManagerRequest{ManagerID: ptr(1)}
+
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1}, nil)
+
+	_, err := suite.empService.SetManager(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrCycleConstraint)
+}
+
+func (suite *DepartmentServiceTestSuite) TestGetSpanOfControl_Flags() {
+	req := &dto.SpanOfControlRequest{Min: 2, Max: 5}
+
+	suite.empRepo.On("GetSpanOfControl", mock.Anything).Return([]models.ManagerSpan{
+		{Employee: models.Employee{ID: 1}, DirectReports: 12},
+		{Employee: models.Employee{ID: 2}, DirectReports: 3},
+		{Employee: models.Employee{ID: 3}, DirectReports: 1},
+	}, nil)
+
+	resp, err := suite.empService.GetSpanOfControl(context.Background(), req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), domain.SpanStatusTooMany, resp[0].Status)
+	assert.Equal(suite.T(), domain.SpanStatusOK, resp[1].Status)
+	assert.Equal(suite.T(), domain.SpanStatusTooFew, resp[2].Status)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateAssignment_Secondary() {
+	req := &dto.CreateAssignmentRequest{DepartmentID: 7, Role: " Guild member ", AllocationPercent: 30}
+
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1, DepartmentID: 2}, nil)
+	suite.repo.On("Exists", mock.Anything, 7).Return(true, nil)
+	suite.empRepo.On("Lock", mock.Anything, 1).Return(nil)
+	suite.assignmentRepo.On("ListByEmployee", mock.Anything, 1).Return([]models.EmployeeAssignment{
+		{ID: 1, EmployeeID: 1, DepartmentID: 2, AllocationPercent: 70, IsPrimary: true},
+	}, nil)
+	suite.assignmentRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *models.EmployeeAssignment) bool {
+		return a.DepartmentID == 7 && !a.IsPrimary && a.Role == "Guild member"
+	})).Return(nil)
+
+	resp, err := suite.empService.CreateAssignment(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), resp.IsPrimary)
+	suite.assignmentRepo.AssertExpectations(suite.T())
+	suite.empRepo.AssertCalled(suite.T(), "Lock", mock.Anything, 1)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateAssignment_AllocationExceeded() {
+	req := &dto.CreateAssignmentRequest{DepartmentID: 7, AllocationPercent: 40}
+
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1, DepartmentID: 2}, nil)
+	suite.repo.On("Exists", mock.Anything, 7).Return(true, nil)
+	suite.empRepo.On("Lock", mock.Anything, 1).Return(nil)
+	suite.assignmentRepo.On("ListByEmployee", mock.Anything, 1).Return([]models.EmployeeAssignment{
+		{ID: 1, DepartmentID: 2, AllocationPercent: 70, IsPrimary: true},
+	}, nil)
+
+	resp, err := suite.empService.CreateAssignment(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrAllocationConstraint)
+	assert.Nil(suite.T(), resp)
+	suite.assignmentRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateAssignment_PrimaryOutsideHome() {
+	req := &dto.CreateAssignmentRequest{DepartmentID: 7, AllocationPercent: 50, IsPrimary: true}
+
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1, DepartmentID: 2}, nil)
+
+	_, err := suite.empService.CreateAssignment(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidPrimary)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdateAssignment_ExcludesItselfFromSum() {
+	req := &dto.UpdateAssignmentRequest{AllocationPercent: ptr(60)}
+
+	suite.assignmentRepo.On("GetByID", mock.Anything, 5).Return(&models.EmployeeAssignment{ID: 5, EmployeeID: 1, AllocationPercent: 30}, nil)
+	suite.empRepo.On("Lock", mock.Anything, 1).Return(nil)
+	suite.assignmentRepo.On("SumAllocation", mock.Anything, 1, 5).Return(40, nil)
+	suite.assignmentRepo.On("Update", mock.Anything, 5, map[string]interface{}{"allocation_percent": 60}).Return(nil)
+
+	resp, err := suite.empService.UpdateAssignment(context.Background(), 1, 5, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 60, resp.AllocationPercent)
+	suite.empRepo.AssertCalled(suite.T(), "Lock", mock.Anything, 1)
+}
+
+func (suite *DepartmentServiceTestSuite) TestDeleteAssignment_OtherEmployee() {
+	suite.assignmentRepo.On("GetByID", mock.Anything, 5).Return(&models.EmployeeAssignment{ID: 5, EmployeeID: 2}, nil)
+
+	err := suite.empService.DeleteAssignment(context.Background(), 1, 5)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrAssignmentNotFound)
+	suite.assignmentRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func ptr(i int) *int {
+	return &i
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateEmployee_ExistingPositionTitle() {
+	req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: " developer "}
+	pos := &models.Position{ID: 3, Title: "Developer"}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.posRepo.On("GetByTitle", mock.Anything, "developer").Return(pos, nil)
+	suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityEmployee).Return(nil, nil)
+	suite.empRepo.On("NextNumber", mock.Anything).Return(int64(1), nil)
+	suite.empRepo.On("GetByNumber", mock.Anything, "EMP-000001").Return(nil, nil)
+	suite.empRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.Employee) bool {
+		return e.PositionID != nil && *e.PositionID == 3 && e.Position == "Developer"
+	})).Return(nil)
+
+	resp, err := suite.empService.Create(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Developer", resp.Position)
+	suite.posRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateEmployee_UnknownPositionTitle() {
+	req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: "Designer"}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.posRepo.On("GetByTitle", mock.Anything, "Designer").Return(nil, nil)
+	suite.posRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *models.Position) bool {
+		return p.Title == "Designer"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Position).ID = 7
+	}).Return(nil)
+	suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityEmployee).Return(nil, nil)
+	suite.empRepo.On("NextNumber", mock.Anything).Return(int64(1), nil)
+	suite.empRepo.On("GetByNumber", mock.Anything, "EMP-000001").Return(nil, nil)
+	suite.empRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.Employee) bool {
+		return e.PositionID != nil && *e.PositionID == 7
+	})).Return(nil)
+
+	resp, err := suite.empService.Create(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 7, *resp.PositionID)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateEmployee_UnknownPositionTitleByEditor() {
+	req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: "Dev"}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 1).Return([]string{domain.RoleEditor}, nil)
+	suite.posRepo.On("GetByTitle", mock.Anything, "Dev").Return(nil, nil)
+
+	resp, err := suite.empService.Create(bob(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrPositionNotFound)
+	assert.Nil(suite.T(), resp)
+	suite.posRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	suite.empRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateEmployee_PositionNotFound() {
+	posID := 42
+	req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", PositionID: &posID}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.posRepo.On("GetByID", mock.Anything, 42).Return(nil, domain.ErrNotFound)
+
+	resp, err := suite.empService.Create(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrPositionNotFound)
+	assert.Nil(suite.T(), resp)
+	suite.empRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreatePosition_DuplicateTitle() {
+	req := &dto.CreatePositionRequest{Title: "Developer"}
+
+	suite.posRepo.On("GetByTitle", mock.Anything, "Developer").Return(&models.Position{ID: 1, Title: "Developer"}, nil)
+
+	resp, err := suite.posService.Create(context.Background(), req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrDuplicateName)
+	assert.Nil(suite.T(), resp)
+}
+
+func (suite *DepartmentServiceTestSuite) TestDeletePosition_InUse() {
+	suite.posRepo.On("IsUsed", mock.Anything, 1).Return(true, nil)
+
+	err := suite.posService.Delete(context.Background(), 1)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrPositionInUse)
+	suite.posRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestFillVacancy_Success() {
+	target := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	vacancy := &models.Vacancy{ID: 2, DepartmentID: 1, PositionID: 3, TargetStartDate: &target, Status: domain.VacancyStatusOpen}
+
+	suite.vacancyRepo.On("GetByID", mock.Anything, 2).Return(vacancy, nil)
+	suite.vacancyRepo.On("SetStatus", mock.Anything, 2, domain.VacancyStatusOpen, domain.VacancyStatusFilled).Return(nil)
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.posRepo.On("GetByID", mock.Anything, 3).Return(&models.Position{ID: 3, Title: "Developer"}, nil)
+	suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityEmployee).Return(nil, nil)
+	suite.empRepo.On("NextNumber", mock.Anything).Return(int64(1), nil)
+	suite.empRepo.On("GetByNumber", mock.Anything, "EMP-000001").Return(nil, nil)
+	suite.empRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.Employee) bool {
+		return e.HiredAt != nil && e.HiredAt.Equal(target) && *e.PositionID == 3
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Employee).ID = 5
+	}).Return(nil)
+	suite.vacancyRepo.On("Update", mock.Anything, 2, map[string]interface{}{"employee_id": 5}).Return(nil)
+
+	resp, err := suite.vacService.Fill(context.Background(), 1, 2, &dto.FillVacancyRequest{FullName: "Oleg Moroz"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), domain.VacancyStatusFilled, resp.Vacancy.Status)
+	assert.Equal(suite.T(), 5, *resp.Vacancy.EmployeeID)
+	assert.Equal(suite.T(), "2026-12-01", *resp.Employee.HiredAt)
+}
+
+func (suite *DepartmentServiceTestSuite) TestFillVacancy_Closed() {
+	vacancy := &models.Vacancy{ID: 2, DepartmentID: 1, PositionID: 3, Status: domain.VacancyStatusCancelled}
+
+	suite.vacancyRepo.On("GetByID", mock.Anything, 2).Return(vacancy, nil)
+
+	resp, err := suite.vacService.Fill(context.Background(), 1, 2, &dto.FillVacancyRequest{FullName: "Oleg Moroz"})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrVacancyClosed)
+	assert.Nil(suite.T(), resp)
+	suite.empRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestFillVacancy_FailedHireNotLinked() {
+	vacancy := &models.Vacancy{ID: 2, DepartmentID: 1, PositionID: 3, Status: domain.VacancyStatusOpen}
+
+	suite.vacancyRepo.On("GetByID", mock.Anything, 2).Return(vacancy, nil)
+	suite.vacancyRepo.On("SetStatus", mock.Anything, 2, domain.VacancyStatusOpen, domain.VacancyStatusFilled).Return(nil)
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.empRepo.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrNotFound)
+
+	resp, err := suite.vacService.Fill(context.Background(), 1, 2, &dto.FillVacancyRequest{FullName: "Oleg Moroz", ManagerID: ptr(9)})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrManagerNotFound)
+	assert.Nil(suite.T(), resp)
+	// Claim is rolled back with transaction
+	suite.vacancyRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestFillVacancy_OtherDepartment() {
+	suite.vacancyRepo.On("GetByID", mock.Anything, 2).Return(&models.Vacancy{ID: 2, DepartmentID: 7, Status: domain.VacancyStatusOpen}, nil)
+
+	resp, err := suite.vacService.Fill(context.Background(), 1, 2, &dto.FillVacancyRequest{FullName: "Oleg Moroz"})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrVacancyNotFound)
+	assert.Nil(suite.T(), resp)
+}
+
+func (suite *DepartmentServiceTestSuite) TestTerminate_BeforeHire() {
+	hiredAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1, HiredAt: &hiredAt, Status: domain.EmployeeStatusActive}, nil)
+
+	date := "2024-02-29"
+	resp, err := suite.empService.Terminate(context.Background(), 1, &dto.TerminateEmployeeRequest{TerminatedAt: &date})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidTerminatedAt)
+	assert.Nil(suite.T(), resp)
+	suite.empRepo.AssertNotCalled(suite.T(), "Terminate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestTerminate_Success() {
+	hiredAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	terminatedAt := time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1, ManagerID: ptr(2), HiredAt: &hiredAt, Status: domain.EmployeeStatusOnLeave}, nil)
+	suite.empRepo.On("Terminate", mock.Anything, 1, terminatedAt).Return(nil)
+
+	date := "2026-09-30"
+	resp, err := suite.empService.Terminate(context.Background(), 1, &dto.TerminateEmployeeRequest{TerminatedAt: &date})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), domain.EmployeeStatusTerminated, resp.Status)
+	assert.Equal(suite.T(), "2026-09-30", *resp.TerminatedAt)
+	assert.Nil(suite.T(), resp.ManagerID)
+}
+
+func (suite *DepartmentServiceTestSuite) TestTerminate_AlreadyTerminated() {
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1, Status: domain.EmployeeStatusTerminated}, nil)
+
+	_, err := suite.empService.Terminate(context.Background(), 1, &dto.TerminateEmployeeRequest{})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrEmployeeTerminated)
+}
+
+func (suite *DepartmentServiceTestSuite) TestRehire_Success() {
+	terminatedAt := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	hiredAt := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1, Status: domain.EmployeeStatusTerminated, TerminatedAt: &terminatedAt}, nil)
+	suite.empRepo.On("Update", mock.Anything, 1, map[string]interface{}{
+		"status":        domain.EmployeeStatusActive,
+		"terminated_at": nil,
+		"hired_at":      hiredAt,
+	}).Return(nil)
+
+	date := "2026-06-01"
+	resp, err := suite.empService.Rehire(context.Background(), 1, &dto.RehireEmployeeRequest{HiredAt: &date})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), domain.EmployeeStatusActive, resp.Status)
+	assert.Equal(suite.T(), "2026-06-01", *resp.HiredAt)
+	assert.Nil(suite.T(), resp.TerminatedAt)
+}
+
+func (suite *DepartmentServiceTestSuite) TestRehire_BeforeTermination() {
+	terminatedAt := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1, Status: domain.EmployeeStatusTerminated, TerminatedAt: &terminatedAt}, nil)
+
+	date := "2026-01-01"
+	_, err := suite.empService.Rehire(context.Background(), 1, &dto.RehireEmployeeRequest{HiredAt: &date})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidRehiredAt)
+}
+
+func (suite *DepartmentServiceTestSuite) TestSetManager_Terminated() {
+	suite.empRepo.On("GetByID", mock.Anything, 1).Return(&models.Employee{ID: 1}, nil)
+	suite.empRepo.On("GetByID", mock.Anything, 2).Return(&models.Employee{ID: 2, Status: domain.EmployeeStatusTerminated}, nil)
+
+	_, err := suite.empService.SetManager(context.Background(), 1, &dto.SetManagerRequest{ManagerID: ptr(2)})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrEmployeeTerminated)
+	suite.empRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateEmployee_DuplicateEmail() {
+	email := " Oleg.Moroz@Example.com "
+	req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: "Developer", Email: &email}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.empRepo.On("GetByEmail", mock.Anything, "Oleg.Moroz@Example.com").Return(&models.Employee{ID: 4}, nil)
+
+	resp, err := suite.empService.Create(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrDuplicateEmail)
+	assert.Nil(suite.T(), resp)
+	suite.empRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateEmployee_DuplicateNumber() {
+	number := "EMP-000042"
+	req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: "Developer", EmployeeNumber: &number}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.empRepo.On("GetByNumber", mock.Anything, number).Return(&models.Employee{ID: 4}, nil)
+
+	_, err := suite.empService.Create(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrDuplicateEmployeeNumber)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateEmployee_InvalidPhone() {
+	phone := "8 (915) 123-45-67"
+	req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: "Developer", Phone: &phone}
+
+	_, err := suite.empService.Create(context.Background(), 1, req)
+
+	assert.Error(suite.T(), err)
+	suite.empRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateEmployee_GeneratedNumberSkipsTaken() {
+	phone := "+79151234567"
+	req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: "Developer", Phone: &phone}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.posRepo.On("GetByTitle", mock.Anything, "Developer").Return(&models.Position{ID: 3, Title: "Developer"}, nil)
+	suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityEmployee).Return(nil, nil)
+	suite.empRepo.On("NextNumber", mock.Anything).Return(int64(7), nil).Once()
+	suite.empRepo.On("NextNumber", mock.Anything).Return(int64(8), nil).Once()
+	suite.empRepo.On("GetByNumber", mock.Anything, "EMP-000007").Return(&models.Employee{ID: 2}, nil)
+	suite.empRepo.On("GetByNumber", mock.Anything, "EMP-000008").Return(nil, nil)
+	suite.empRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.Employee) bool {
+		return e.EmployeeNumber != nil && *e.EmployeeNumber == "EMP-000008"
+	})).Return(nil)
+
+	resp, err := suite.empService.Create(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "EMP-000008", *resp.EmployeeNumber)
+	assert.Equal(suite.T(), "+79151234567", *resp.Phone)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreate_UnknownAttribute() {
+	req := &dto.CreateDepartmentRequest{Name: "Backend", Attributes: map[string]interface{}{"floor": float64(3)}}
+
+	suite.repo.On("GetByNameAndParent", mock.Anything, "Backend", mock.Anything).Return(nil, nil)
+	suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityDepartment).Return([]models.AttributeDefinition{
+		{Name: "location", Type: domain.AttributeTypeString},
+	}, nil)
+
+	resp, err := suite.service.Create(context.Background(), req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidAttribute)
+	assert.Nil(suite.T(), resp)
+	suite.repo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateEmployee_AttributeSchema() {
+	defs := []models.AttributeDefinition{
+		{Name: "grade", Type: domain.AttributeTypeEnum, Required: true, EnumValues: models.StringList{"junior", "senior"}},
+		{Name: "remote", Type: domain.AttributeTypeBoolean},
+	}
+
+	cases := []struct {
+		name  string
+		attrs map[string]interface{}
+	}{
+		{"missing required", map[string]interface{}{"remote": true}},
+		{"not an enum value", map[string]interface{}{"grade": "lead"}},
+		{"wrong type", map[string]interface{}{"grade": "junior", "remote": "yes"}},
+	}
+
+	for _, tc := range cases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			req := &dto.CreateEmployeeRequest{FullName: "Oleg Moroz", Position: "Developer", Attributes: tc.attrs}
+
+			suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+			suite.posRepo.On("GetByTitle", mock.Anything, "Developer").Return(&models.Position{ID: 3, Title: "Developer"}, nil)
+			suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityEmployee).Return(defs, nil)
+
+			resp, err := suite.empService.Create(context.Background(), 1, req)
+
+			assert.ErrorIs(suite.T(), err, domain.ErrInvalidAttribute)
+			assert.Nil(suite.T(), resp)
+			suite.empRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (suite *DepartmentServiceTestSuite) TestListEmployees_AttributeFilterParsedByType() {
+	req := &dto.ListEmployeesRequest{Attributes: map[string]string{"level": "3", "remote": "true"}}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityEmployee).Return([]models.AttributeDefinition{
+		{Name: "level", Type: domain.AttributeTypeNumber},
+		{Name: "remote", Type: domain.AttributeTypeBoolean},
+	}, nil)
+	filter := domain.EmployeeFilter{Attributes: models.Attributes{"level": float64(3), "remote": true}}
+	suite.empRepo.On("ListByDepartment", mock.Anything, 1, filter, (*models.Cursor)(nil), domain.DefaultPageLimit+1).
+		Return([]models.Employee{{ID: 1, DepartmentID: 1, FullName: "Oleg Moroz"}}, nil)
+
+	page, err := suite.empService.ListByDepartment(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), page.Items, 1)
+}
+
+func (suite *DepartmentServiceTestSuite) TestListChildren_InvalidAttributeFilter() {
+	req := &dto.ListChildrenRequest{Attributes: map[string]string{"floor": "third"}}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.attrRepo.On("List", mock.Anything, domain.AttributeEntityDepartment).Return([]models.AttributeDefinition{
+		{Name: "floor", Type: domain.AttributeTypeNumber},
+	}, nil)
+
+	page, err := suite.service.ListChildren(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidAttribute)
+	assert.Nil(suite.T(), page)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreateAttribute_Validation() {
+	cases := []struct {
+		name string
+		req  *dto.CreateAttributeRequest
+	}{
+		{"name not snake_case", &dto.CreateAttributeRequest{EntityType: "employee", Name: "Cost Center", Type: "string"}},
+		{"enum values of string", &dto.CreateAttributeRequest{EntityType: "employee", Name: "grade", Type: "string", EnumValues: []string{"junior"}}},
+		{"enum without values", &dto.CreateAttributeRequest{EntityType: "employee", Name: "grade", Type: "enum"}},
+	}
+
+	for _, tc := range cases {
+		suite.Run(tc.name, func() {
+			resp, err := suite.attrService.Create(context.Background(), tc.req)
+
+			assert.Error(suite.T(), err)
+			assert.Nil(suite.T(), resp)
+		})
+	}
+	suite.attrRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdateAttribute_EnumValuesOfNumber() {
+	suite.attrRepo.On("GetByID", mock.Anything, 1).Return(&models.AttributeDefinition{ID: 1, Name: "level", Type: domain.AttributeTypeNumber}, nil)
+
+	resp, err := suite.attrService.Update(context.Background(), 1, &dto.UpdateAttributeRequest{EnumValues: []string{"1"}})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidAttribute)
+	assert.Nil(suite.T(), resp)
+	suite.attrRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func str(s string) *string {
+	return &s
+}
+
+// orgTypes - division contains departments, department contains at most 2 teams, team contains nothing
+func orgTypes() []models.DepartmentType {
+	return []models.DepartmentType{
+		{Name: "division", RootAllowed: true, Rules: []models.DepartmentTypeRule{{ParentType: "division", ChildType: "department"}}},
+		{Name: "department", MaxDepth: ptr(2), MaxChildren: ptr(2), Rules: []models.DepartmentTypeRule{{ParentType: "department", ChildType: "team"}}},
+		{Name: "team"},
+	}
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreate_HierarchyRules() {
+	cases := []struct {
+		name     string
+		parent   *models.Department
+		deptType *string
+		children int64
+		depth    int
+	}{
+		{"team cannot contain anything", &models.Department{ID: 1, Type: str("team")}, str("team"), 0, 3},
+		{"typed parent rejects untyped child", &models.Department{ID: 1, Type: str("division")}, nil, 0, 1},
+		{"department cannot be root", nil, str("department"), 0, 0},
+		{"max children", &models.Department{ID: 1, Type: str("department")}, str("team"), 2, 2},
+		{"max depth", &models.Department{ID: 1, Type: str("division")}, str("department"), 0, 2},
+	}
+
+	for _, tc := range cases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			req := &dto.CreateDepartmentRequest{Name: "New", Type: tc.deptType}
+			if tc.parent != nil {
+				req.ParentID = &tc.parent.ID
+				suite.repo.On("GetByIDSimple", mock.Anything, tc.parent.ID).Return(tc.parent, nil)
+				suite.repo.On("GetAncestors", mock.Anything, tc.parent.ID).Return(make([]models.Department, tc.depth), nil)
+				suite.repo.On("GetCounts", mock.Anything, []int{tc.parent.ID}).
+					Return(map[int]models.DepartmentCounts{tc.parent.ID: {DirectChildrenCount: tc.children}}, nil)
+			}
+			if tc.deptType != nil {
+				suite.deptTypeRepo.On("GetByName", mock.Anything, *tc.deptType).Return(&models.DepartmentType{Name: *tc.deptType}, nil)
+			}
+			suite.deptTypeRepo.On("List", mock.Anything).Return(orgTypes(), nil)
+
+			resp, err := suite.service.Create(context.Background(), req)
+
+			assert.ErrorIs(suite.T(), err, domain.ErrHierarchyRule)
+			assert.Nil(suite.T(), resp)
+			suite.repo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreate_UnknownType() {
+	req := &dto.CreateDepartmentRequest{Name: "New", Type: str("squad")}
+
+	suite.deptTypeRepo.On("GetByName", mock.Anything, "squad").Return(nil, domain.ErrNotFound)
+
+	resp, err := suite.service.Create(context.Background(), req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrDepartmentTypeNotFound)
+	assert.Nil(suite.T(), resp)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_ReparentUnderTeam() {
+	// Department 5 with team 6 moves under team 3, which cannot contain anything
+	newParentID := 3
+	req := &dto.UpdateDepartmentRequest{ParentID: &newParentID}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 5).Return(&models.Department{ID: 5, ParentID: ptr(1), Type: str("department")}, nil)
+	suite.repo.On("Exists", mock.Anything, newParentID).Return(true, nil)
+	suite.repo.On("GetByIDSimple", mock.Anything, 3).Return(&models.Department{ID: 3, ParentID: ptr(2), Type: str("team")}, nil)
+	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2, ParentID: ptr(1), Type: str("department")}, nil)
+	suite.repo.On("GetByIDSimple", mock.Anything, 1).Return(&models.Department{ID: 1, Type: str("division")}, nil)
+	suite.repo.On("GetSubtree", mock.Anything, []int{5}).Return([]models.DepartmentNode{
+		{ID: 5, ParentID: ptr(1), Type: str("department"), Depth: 0},
+		{ID: 6, ParentID: ptr(5), Type: str("team"), Depth: 1},
+	}, nil)
+	suite.deptTypeRepo.On("List", mock.Anything).Return(orgTypes(), nil)
+	suite.repo.On("GetAncestors", mock.Anything, 3).Return(make([]models.Department, 3), nil)
+
+	resp, err := suite.service.Update(context.Background(), 5, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrHierarchyRule)
+	assert.Nil(suite.T(), resp)
+	suite.repo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_RetypeWithTooManyChildren() {
+	// Division 2 with 3 teams cannot become a department with max 2 children
+	req := &dto.UpdateDepartmentRequest{Type: str("department")}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2, ParentID: ptr(1), Type: str("division")}, nil)
+	suite.repo.On("GetByIDSimple", mock.Anything, 1).Return(&models.Department{ID: 1, Type: str("division")}, nil)
+	suite.deptTypeRepo.On("GetByName", mock.Anything, "department").Return(&models.DepartmentType{Name: "department"}, nil)
+	suite.repo.On("GetSubtree", mock.Anything, []int{2}).Return([]models.DepartmentNode{
+		{ID: 2, ParentID: ptr(1), Type: str("division")},
+		{ID: 3, ParentID: ptr(2), Type: str("team"), Depth: 1},
+		{ID: 4, ParentID: ptr(2), Type: str("team"), Depth: 1},
+		{ID: 5, ParentID: ptr(2), Type: str("team"), Depth: 1},
+	}, nil)
+	suite.deptTypeRepo.On("List", mock.Anything).Return(orgTypes(), nil)
+	suite.repo.On("GetAncestors", mock.Anything, 1).Return([]models.Department{}, nil)
+
+	resp, err := suite.service.Update(context.Background(), 2, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrHierarchyRule)
+	assert.Contains(suite.T(), err.Error(), "more than 2 children")
+	assert.Nil(suite.T(), resp)
+}
+
+func (suite *DepartmentServiceTestSuite) TestSaveDepartmentType_UnknownChild() {
+	req := &dto.SaveDepartmentTypeRequest{AllowedChildren: []string{"team", "squad"}}
+
+	suite.deptTypeRepo.On("List", mock.Anything).Return(orgTypes(), nil)
+
+	resp, err := suite.typeService.Save(context.Background(), "department", req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrDepartmentTypeNotFound)
+	assert.Nil(suite.T(), resp)
+	suite.deptTypeRepo.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestSaveDepartmentType_DefaultsRootAllowed() {
+	req := &dto.SaveDepartmentTypeRequest{AllowedChildren: []string{"team", "team", "division"}}
+	saved := &models.DepartmentType{Name: "division", RootAllowed: true, Rules: []models.DepartmentTypeRule{
+		{ParentType: "division", ChildType: "division"},
+		{ParentType: "division", ChildType: "team"},
+	}}
+
+	suite.deptTypeRepo.On("List", mock.Anything).Return(orgTypes(), nil)
+	suite.deptTypeRepo.On("Save", mock.Anything, mock.MatchedBy(func(t *models.DepartmentType) bool {
+		return t.RootAllowed && len(t.Rules) == 2
+	})).Return(nil)
+	suite.deptTypeRepo.On("GetByName", mock.Anything, "division").Return(saved, nil)
+
+	resp, err := suite.typeService.Save(context.Background(), "division", req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"division", "team"}, resp.AllowedChildren)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_MoveAppendsToNewSiblings() {
+	newParentID := 2
+	req := &dto.UpdateDepartmentRequest{ParentID: &newParentID}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 5).Return(&models.Department{ID: 5, Name: "QA", ParentID: ptr(1), SortOrder: 1}, nil)
+	suite.repo.On("Exists", mock.Anything, newParentID).Return(true, nil)
+	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2}, nil)
+	suite.repo.On("GetSubtree", mock.Anything, []int{5}).Return([]models.DepartmentNode{{ID: 5, ParentID: ptr(1)}}, nil)
+	suite.repo.On("GetByNameAndParent", mock.Anything, "QA", &newParentID).Return(nil, nil)
+	suite.repo.On("NextSortOrder", mock.Anything, &newParentID).Return(3, nil)
+	suite.repo.On("Update", mock.Anything, 5, map[string]interface{}{"parent_id": newParentID, "sort_order": 3}).Return(nil)
+	suite.repo.On("UnsetInvalidHeads", mock.Anything, 5).Return(nil)
+	suite.repo.On("GetByID", mock.Anything, 5, domain.TreeOptions{Depth: 1}).
+		Return(&models.Department{ID: 5, Name: "QA", ParentID: &newParentID, SortOrder: 3}, nil)
+
+	resp, err := suite.service.Update(context.Background(), 5, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, resp.SortOrder)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_MoveUnsetHeadsFails() {
+	newParentID := 2
+	req := &dto.UpdateDepartmentRequest{ParentID: &newParentID, SortOrder: ptr(1)}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 5).Return(&models.Department{ID: 5, Name: "QA", ParentID: ptr(1)}, nil)
+	suite.repo.On("Exists", mock.Anything, newParentID).Return(true, nil)
+	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2}, nil)
+	suite.repo.On("GetSubtree", mock.Anything, []int{5}).Return([]models.DepartmentNode{{ID: 5, ParentID: ptr(1)}}, nil)
+	suite.repo.On("GetByNameAndParent", mock.Anything, "QA", &newParentID).Return(nil, nil)
+	suite.repo.On("Update", mock.Anything, 5, map[string]interface{}{"parent_id": newParentID, "sort_order": 1}).Return(nil)
+	suite.repo.On("UnsetInvalidHeads", mock.Anything, 5).Return(assert.AnError)
+
+	_, err := suite.service.Update(context.Background(), 5, req)
+
+	assert.ErrorContains(suite.T(), err, "failed to unset heads of moved department")
+	suite.repo.AssertNotCalled(suite.T(), "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestReorder_Success() {
+	req := &dto.ReorderChildrenRequest{ChildIDs: []int{3, 2}}
+
+	suite.repo.On("Exists", mock.Anything, 1).Return(true, nil)
+	suite.repo.On("Reorder", mock.Anything, 1, []int{3, 2}).Return(nil)
+	suite.repo.On("ListChildren", mock.Anything, 1, domain.DepartmentFilter{}, (*models.Cursor)(nil), 2).Return([]models.Department{
+		{ID: 3, Name: "QA", SortOrder: 1},
+		{ID: 2, Name: "Dev", SortOrder: 2},
+	}, nil)
+
+	resp, err := suite.service.Reorder(context.Background(), 1, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), resp, 2)
+	assert.Equal(suite.T(), 3, resp[0].ID)
+}
+
+func (suite *DepartmentServiceTestSuite) TestReorder_DuplicateChild() {
+	req := &dto.ReorderChildrenRequest{ChildIDs: []int{3, 2, 3}}
+
+	resp, err := suite.service.Reorder(context.Background(), 1, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidChildOrder)
+	assert.Nil(suite.T(), resp)
+	suite.repo.AssertNotCalled(suite.T(), "Reorder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreate_DuplicateCostCenter() {
+	req := &dto.CreateDepartmentRequest{Name: "Sales", CostCenter: str(" cc-100 ")}
+
+	suite.repo.On("GetByNameAndParent", mock.Anything, "Sales", (*int)(nil)).Return(nil, nil)
+	suite.repo.On("GetByCostCenter", mock.Anything, "CC-100").Return(&models.Department{ID: 4, Name: "Marketing", CostCenter: str("CC-100")}, nil)
+
+	resp, err := suite.service.Create(context.Background(), req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrDuplicateCostCenter)
+	assert.Nil(suite.T(), resp)
+
+	var conflict *domain.ConflictError
+	assert.ErrorAs(suite.T(), err, &conflict)
+	assert.Equal(suite.T(), 4, conflict.Existing.(dto.DepartmentResponse).ID)
+	suite.repo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_CostCenterAndBudget() {
+	budget := int64(120000)
+	req := &dto.UpdateDepartmentRequest{CostCenter: str("cc-200"), Budget: &budget}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2, Name: "Ops", CostCenter: str("CC-200")}, nil)
+	suite.repo.On("GetByCostCenter", mock.Anything, "CC-200").Return(&models.Department{ID: 2, Name: "Ops", CostCenter: str("CC-200")}, nil)
+	suite.repo.On("GetByNameAndParent", mock.Anything, "Ops", (*int)(nil)).Return(nil, nil)
+	suite.repo.On("Update", mock.Anything, 2, map[string]interface{}{"cost_center": str("CC-200"), "budget": budget}).Return(nil)
+	suite.repo.On("GetByID", mock.Anything, 2, domain.TreeOptions{Depth: 1}).
+		Return(&models.Department{ID: 2, Name: "Ops", CostCenter: str("CC-200"), Budget: &budget}, nil)
+
+	resp, err := suite.service.Update(context.Background(), 2, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), budget, *resp.Budget)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_CostCenterLengthAfterTrim() {
+	// 26 characters with spaces, 6 after trimming
+	req := &dto.UpdateDepartmentRequest{CostCenter: str("          cc-200          ")}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2, Name: "Ops"}, nil)
+	suite.repo.On("GetByCostCenter", mock.Anything, "CC-200").Return(nil, nil)
+	suite.repo.On("GetByNameAndParent", mock.Anything, "Ops", (*int)(nil)).Return(nil, nil)
+	suite.repo.On("Update", mock.Anything, 2, map[string]interface{}{"cost_center": str("CC-200")}).Return(nil)
+	suite.repo.On("GetByID", mock.Anything, 2, domain.TreeOptions{Depth: 1}).
+		Return(&models.Department{ID: 2, Name: "Ops", CostCenter: str("CC-200")}, nil)
+
+	resp, err := suite.service.Update(context.Background(), 2, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "CC-200", *resp.CostCenter)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_EmptyCostCenterRemovesIt() {
+	req := &dto.UpdateDepartmentRequest{CostCenter: str("  ")}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2, Name: "Ops", CostCenter: str("CC-200")}, nil)
+	suite.repo.On("GetByNameAndParent", mock.Anything, "Ops", (*int)(nil)).Return(nil, nil)
+	suite.repo.On("Update", mock.Anything, 2, map[string]interface{}{"cost_center": (*string)(nil)}).Return(nil)
+	suite.repo.On("GetByID", mock.Anything, 2, domain.TreeOptions{Depth: 1}).
+		Return(&models.Department{ID: 2, Name: "Ops"}, nil)
+
+	resp, err := suite.service.Update(context.Background(), 2, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), resp.CostCenter)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_ClearBudget() {
+	budget := int64(120000)
+	req := &dto.UpdateDepartmentRequest{ClearBudget: true}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 2).Return(&models.Department{ID: 2, Name: "Ops", Budget: &budget}, nil)
+	suite.repo.On("GetByNameAndParent", mock.Anything, "Ops", (*int)(nil)).Return(nil, nil)
+	suite.repo.On("Update", mock.Anything, 2, map[string]interface{}{"budget": nil}).Return(nil)
+	suite.repo.On("GetByID", mock.Anything, 2, domain.TreeOptions{Depth: 1}).
+		Return(&models.Department{ID: 2, Name: "Ops"}, nil)
+
+	resp, err := suite.service.Update(context.Background(), 2, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), resp.Budget)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_ClearBudgetWithBudget() {
+	budget := int64(120000)
+	req := &dto.UpdateDepartmentRequest{Budget: &budget, ClearBudget: true}
+
+	_, err := suite.service.Update(context.Background(), 2, req)
+
+	assert.Error(suite.T(), err)
+	suite.repo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestGetBudget_RollUp() {
+	budget := int64(300000)
+	suite.repo.On("GetBudget", mock.Anything, 1).Return([]models.DepartmentBudget{
+		{DepartmentID: 1, Name: "Sales", Budget: &budget, TotalBudget: 400000, Headcount: 1, TotalHeadcount: 3},
+		{DepartmentID: 2, Name: "East", Depth: 1, TotalBudget: 100000},
+	}, nil)
+
+	resp, err := suite.service.GetBudget(context.Background(), 1)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), resp, 2)
+	assert.InDelta(suite.T(), 133333.33, *resp[0].BudgetPerHead, 0.001)
+	assert.Nil(suite.T(), resp[1].BudgetPerHead, "no employees, no budget per head")
+}
+
+func (suite *DepartmentServiceTestSuite) TestGetBudget_NotFound() {
+	suite.repo.On("GetBudget", mock.Anything, 99).Return([]models.DepartmentBudget{}, nil)
+
+	resp, err := suite.service.GetBudget(context.Background(), 99)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrDepartmentNotFound)
+	assert.Nil(suite.T(), resp)
+}
+
+func (suite *DepartmentServiceTestSuite) TestAPIKey_CreateAndAuthenticate() {
+	admin := domain.WithPrincipal(context.Background(), &models.Principal{Subject: "alice", Method: domain.AuthMethodJWT, Admin: true})
+
+	var stored *models.APIKey
+	suite.apiKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.APIKey")).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*models.APIKey)
+			stored.ID = 7
+		}).Return(nil)
+
+	resp, err := suite.keyService.Create(admin, &dto.CreateAPIKeyRequest{Name: " ci ", Scopes: []string{"employees:hr", "employees:contacts"}})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "ci", resp.Name)
+	assert.True(suite.T(), strings.HasPrefix(resp.Key, domain.APIKeyPrefix))
+	assert.Equal(suite.T(), resp.Key[:12], resp.Prefix)
+	assert.NotContains(suite.T(), stored.KeyHash, resp.Key, "only hash of the key is stored")
+	assert.Equal(suite.T(), "alice", *stored.CreatedBy)
+	assert.Equal(suite.T(), []string{"employees:hr", "employees:contacts"}, resp.Scopes)
+
+	suite.apiKeyRepo.On("GetByHash", mock.Anything, stored.KeyHash).Return(stored, nil)
+	suite.apiKeyRepo.On("Touch", mock.Anything, 7, mock.Anything).Return(nil)
+
+	principal, err := suite.keyService.Authenticate(context.Background(), resp.Key)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "api-key:7", principal.Subject)
+	assert.Equal(suite.T(), domain.AuthMethodAPIKey, principal.Method)
+	assert.False(suite.T(), principal.Admin)
+	assert.Equal(suite.T(), []string{"employees:hr", "employees:contacts"}, principal.Scopes)
+}
+
+func (suite *DepartmentServiceTestSuite) TestAPIKey_AuthenticateRevoked() {
+	revokedAt := time.Now().Add(-time.Hour)
+	suite.apiKeyRepo.On("GetByHash", mock.Anything, hashAPIKey("osk_revoked")).
+		Return(&models.APIKey{ID: 3, Prefix: "osk_revoked", RevokedAt: &revokedAt}, nil)
+	suite.apiKeyRepo.On("GetByHash", mock.Anything, hashAPIKey("osk_unknown")).Return(nil, domain.ErrNotFound)
+
+	_, err := suite.keyService.Authenticate(context.Background(), "osk_revoked")
+	assert.ErrorIs(suite.T(), err, domain.ErrUnauthenticated)
+
+	_, err = suite.keyService.Authenticate(context.Background(), "osk_unknown")
+	assert.ErrorIs(suite.T(), err, domain.ErrUnauthenticated)
+	suite.apiKeyRepo.AssertNotCalled(suite.T(), "Touch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestAPIKey_RequiresAdmin() {
+	viewer := domain.WithPrincipal(context.Background(), &models.Principal{Subject: "bob", Method: domain.AuthMethodJWT})
+
+	_, err := suite.keyService.Create(viewer, &dto.CreateAPIKeyRequest{Name: "ci"})
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+
+	err = suite.keyService.Revoke(viewer, 1)
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	suite.apiKeyRepo.AssertNotCalled(suite.T(), "Revoke", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestAPIKey_CreateExpiredInPast() {
+	past := time.Now().Add(-time.Minute)
+
+	_, err := suite.keyService.Create(context.Background(), &dto.CreateAPIKeyRequest{Name: "ci", ExpiresAt: &past})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidExpiresAt)
+}
+
+func (suite *DepartmentServiceTestSuite) TestAPIKey_RevokeNotFound() {
+	suite.apiKeyRepo.On("Revoke", mock.Anything, 42, mock.Anything).Return(domain.ErrNotFound)
+
+	err := suite.keyService.Revoke(context.Background(), 42)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrAPIKeyNotFound)
+}
+
+// bob - context of non admin principal, its roles come from department grants
+func bob() context.Context {
+	return domain.WithPrincipal(context.Background(), &models.Principal{Subject: "bob", Method: domain.AuthMethodJWT})
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreate_RequiresEditorOnParent() {
+	parentID := 1
+	req := &dto.CreateDepartmentRequest{Name: "Sales", ParentID: &parentID}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 1).Return(&models.Department{ID: 1, Name: "HQ"}, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 1).Return([]string{domain.RoleViewer}, nil)
+
+	resp, err := suite.service.Create(bob(), req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	assert.Nil(suite.T(), resp)
+	suite.repo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestCreate_RootRequiresAdmin() {
+	resp, err := suite.service.Create(bob(), &dto.CreateDepartmentRequest{Name: "HQ"})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	assert.Nil(suite.T(), resp)
+	suite.grantRepo.AssertNotCalled(suite.T(), "GetRoles", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestUpdate_MoveRequiresBothParents() {
+	oldParentID, newParentID := 2, 3
+	req := &dto.UpdateDepartmentRequest{ParentID: &newParentID}
+
+	suite.repo.On("GetByIDSimple", mock.Anything, 5).Return(&models.Department{ID: 5, Name: "Dev", ParentID: &oldParentID}, nil)
+	suite.repo.On("Exists", mock.Anything, 3).Return(true, nil)
+	suite.repo.On("GetByIDSimple", mock.Anything, 3).Return(&models.Department{ID: 3, Name: "Ops"}, nil)
+	// Editor of the department subtree and old parent, no rights on new parent
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 5).Return([]string{domain.RoleEditor}, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 2).Return([]string{domain.RoleEditor}, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 3).Return([]string{}, nil)
+
+	resp, err := suite.service.Update(bob(), 5, req)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	assert.Nil(suite.T(), resp)
+	suite.repo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestDelete_RequiresAdminRole() {
+	suite.repo.On("Exists", mock.Anything, 5).Return(true, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 5).Return([]string{domain.RoleEditor}, nil)
+
+	err := suite.service.Delete(bob(), 5, &dto.DeleteDepartmentRequest{Mode: domain.ModeCascade})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	suite.repo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestTerminate_RequiresEditorOnEmployeeDepartment() {
+	suite.empRepo.On("GetByID", mock.Anything, 10).Return(&models.Employee{ID: 10, DepartmentID: 4, Status: domain.EmployeeStatusActive}, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 4).Return([]string{domain.RoleViewer}, nil)
+
+	resp, err := suite.empService.Terminate(bob(), 10, &dto.TerminateEmployeeRequest{})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	assert.Nil(suite.T(), resp)
+	suite.empRepo.AssertNotCalled(suite.T(), "Terminate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestListEmployees_InheritedViewerRole() {
+	suite.repo.On("Exists", mock.Anything, 4).Return(true, nil)
+	// Viewer granted on an ancestor of department 4
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 4).Return([]string{domain.RoleViewer}, nil)
+	suite.empRepo.On("ListByDepartment", mock.Anything, 4, domain.EmployeeFilter{}, (*models.Cursor)(nil), domain.DefaultPageLimit+1).
+		Return([]models.Employee{{ID: 10, FullName: "Anna Lis", DepartmentID: 4}}, nil)
+
+	page, err := suite.empService.ListByDepartment(bob(), 4, &dto.ListEmployeesRequest{})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), page.Items, 1)
+}
+
+func (suite *DepartmentServiceTestSuite) TestSchema_RequiresAdmin() {
+	title := "Lead"
+	required := true
+
+	_, err := suite.posService.Create(bob(), &dto.CreatePositionRequest{Title: "Lead"})
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	_, err = suite.posService.Update(bob(), 1, &dto.UpdatePositionRequest{Title: &title})
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	err = suite.posService.Delete(bob(), 1)
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+
+	_, err = suite.attrService.Create(bob(), &dto.CreateAttributeRequest{EntityType: domain.AttributeEntityEmployee, Name: "grade", Type: domain.AttributeTypeString})
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	_, err = suite.attrService.Update(bob(), 1, &dto.UpdateAttributeRequest{Required: &required})
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	err = suite.attrService.Delete(bob(), 1)
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+
+	_, err = suite.typeService.Save(bob(), "team", &dto.SaveDepartmentTypeRequest{})
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	err = suite.typeService.Delete(bob(), "team")
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+
+	suite.posRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+	suite.attrRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+	suite.deptTypeRepo.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+	suite.deptTypeRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestReads_RequireViewerRole() {
+	suite.repo.On("GetByID", mock.Anything, 4, mock.Anything).Return(&models.Department{ID: 4, Name: "Dev"}, nil)
+	suite.repo.On("Exists", mock.Anything, 4).Return(true, nil)
+	suite.repo.On("GetBudget", mock.Anything, 4).Return([]models.DepartmentBudget{{DepartmentID: 4}}, nil)
+	suite.vacancyRepo.On("GetHeadcount", mock.Anything, 4).Return([]models.DepartmentHeadcount{{DepartmentID: 4}}, nil)
+	// No grant on department 4 or its ancestors
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 4).Return([]string{}, nil)
+
+	_, err := suite.service.GetByID(bob(), 4, &dto.GetByIDRequest{Depth: 1, IncludeEmployees: true})
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	_, err = suite.service.GetStats(bob(), 4)
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	_, err = suite.service.GetBudget(bob(), 4)
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	_, err = suite.vacService.List(bob(), 4, &dto.ListVacanciesRequest{})
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	_, err = suite.vacService.GetHeadcount(bob(), 4)
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+
+	suite.repo.AssertNotCalled(suite.T(), "GetStats", mock.Anything, mock.Anything)
+	suite.vacancyRepo.AssertNotCalled(suite.T(), "ListByDepartment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestLists_FilteredByViewerRole() {
+	// Bob views department 2 and its child 4, but not root 1 and department 5
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 1).Return([]string{}, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 2).Return([]string{domain.RoleViewer}, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 4).Return([]string{domain.RoleViewer}, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 5).Return([]string{}, nil)
+
+	suite.repo.On("Search", mock.Anything, "dev", domain.MaxSearchLimit).Return([]models.DepartmentSearchResult{
+		{Department: models.Department{ID: 5, Name: "DevOps"}, Path: []models.DepartmentAncestor{{ID: 1, Name: "Company"}}},
+		{Department: models.Department{ID: 4, Name: "Dev"}, Path: []models.DepartmentAncestor{{ID: 1, Name: "Company"}, {ID: 2, Name: "Engineering"}}},
+	}, nil)
+	found, err := suite.service.Search(bob(), &dto.SearchDepartmentsRequest{Query: "dev"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found, 1)
+	assert.Equal(suite.T(), 4, found[0].ID)
+	assert.Equal(suite.T(), []dto.DepartmentPathItem{{ID: 2, Name: "Engineering"}}, found[0].Path)
+
+	suite.repo.On("Exists", mock.Anything, 5).Return(true, nil)
+	_, err = suite.service.ListChildren(bob(), 5, &dto.ListChildrenRequest{})
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+
+	suite.empRepo.On("GetByID", mock.Anything, 10).Return(&models.Employee{ID: 10, DepartmentID: 4}, nil)
+	reports := []models.Employee{{ID: 11, DepartmentID: 4}, {ID: 12, DepartmentID: 5}}
+	suite.empRepo.On("ListDirectReports", mock.Anything, 10).Return(reports, nil)
+	suite.empRepo.On("ListAllReports", mock.Anything, 10).Return(reports, nil)
+
+	direct, err := suite.empService.ListDirectReports(bob(), 10)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), direct, 1)
+	assert.Equal(suite.T(), 11, direct[0].ID)
+	all, err := suite.empService.ListAllReports(bob(), 10)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), all, 1)
+
+	suite.empRepo.On("GetSpanOfControl", mock.Anything).Return([]models.ManagerSpan{
+		{Employee: models.Employee{ID: 10, DepartmentID: 4}, DirectReports: 2},
+		{Employee: models.Employee{ID: 13, DepartmentID: 5}, DirectReports: 3},
+	}, nil)
+	spans, err := suite.empService.GetSpanOfControl(bob(), &dto.SpanOfControlRequest{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), spans, 1)
+	assert.Equal(suite.T(), 10, spans[0].Manager.ID)
+}
+
+func (suite *DepartmentServiceTestSuite) TestGrant_SaveByDepartmentAdmin() {
+	suite.repo.On("Exists", mock.Anything, 4).Return(true, nil)
+	suite.grantRepo.On("GetRoles", mock.Anything, "bob", 4).Return([]string{domain.RoleViewer, domain.RoleAdmin}, nil)
+	suite.grantRepo.On("Save", mock.Anything, mock.MatchedBy(func(g *models.DepartmentGrant) bool {
+		return g.Subject == "carol" && g.DepartmentID == 4 && g.Role == domain.RoleEditor && *g.GrantedBy == "bob"
+	})).Return(nil)
+
+	resp, err := suite.grantService.Save(bob(), 4, &dto.SaveGrantRequest{Subject: " carol ", Role: domain.RoleEditor})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "carol", resp.Subject)
+	suite.grantRepo.AssertExpectations(suite.T())
+}
+
+func (suite *DepartmentServiceTestSuite) TestGrant_InvalidRole() {
+	_, err := suite.grantService.Save(context.Background(), 4, &dto.SaveGrantRequest{Subject: "carol", Role: "owner"})
+
+	assert.Error(suite.T(), err)
+	suite.grantRepo.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func (suite *DepartmentServiceTestSuite) TestGrant_DeleteNotFound() {
+	suite.repo.On("Exists", mock.Anything, 4).Return(true, nil)
+	suite.grantRepo.On("Delete", mock.Anything, 4, 9).Return(domain.ErrNotFound)
+
+	err := suite.grantService.Delete(context.Background(), 4, 9)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrGrantNotFound)
+}
+
+// AUTHENTICATOR
+
+func TestAuthenticator_JWT(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"k1","use":"sig","n":"%s","e":"%s"}]}`,
+		base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()))
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(jwksFile, []byte(jwks), 0o600))
+
+	cfg := config.AuthCfg{
+		BootstrapKey: "bootstrap-secret",
+		JWT: config.JWTCfg{
+			HMACSecret:  "hmac-secret",
+			JWKSFile:    jwksFile,
+			Issuer:      "https://issuer.example",
+			RolesClaim:  "roles",
+			AdminRole:   "admin",
+			ScopesClaim: "scope",
+		},
+	}
+	auth, err := NewAuthenticator(cfg, nil, logger)
+	assert.NoError(t, err)
+
+	claims := func(exp time.Duration, roles ...interface{}) jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   "alice",
+			"iss":   "https://issuer.example",
+			"exp":   time.Now().Add(exp).Unix(),
+			"roles": roles,
+		}
+	}
+
+	t.Run("HS256", func(t *testing.T) {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims(time.Hour, "admin")).SignedString([]byte("hmac-secret"))
+
+		p, err := auth.Authenticate(context.Background(), token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", p.Subject)
+		assert.Equal(t, domain.AuthMethodJWT, p.Method)
+		assert.True(t, p.Admin)
+		assert.Empty(t, p.Scopes)
+	})
+
+	t.Run("Scope Claim", func(t *testing.T) {
+		c := claims(time.Hour, "viewer")
+		c["scope"] = "employees:hr employees:contacts"
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte("hmac-secret"))
+
+		p, err := auth.Authenticate(context.Background(), token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"employees:hr", "employees:contacts"}, p.Scopes)
+	})
+
+	t.Run("RS256 From JWKS", func(t *testing.T) {
+		tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims(time.Hour, "viewer"))
+		tok.Header["kid"] = "k1"
+		token, _ := tok.SignedString(rsaKey)
+
+		p, err := auth.Authenticate(context.Background(), token)
+
+		assert.NoError(t, err)
+		assert.False(t, p.Admin)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims(-time.Minute)).SignedString([]byte("hmac-secret"))
+
+		_, err := auth.Authenticate(context.Background(), token)
+
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("Wrong Issuer", func(t *testing.T) {
+		c := claims(time.Hour)
+		c["iss"] = "https://other.example"
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte("hmac-secret"))
+
+		_, err := auth.Authenticate(context.Background(), token)
+
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("Wrong Secret", func(t *testing.T) {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims(time.Hour)).SignedString([]byte("other"))
+
+		_, err := auth.Authenticate(context.Background(), token)
+
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("Bootstrap Key", func(t *testing.T) {
+		p, err := auth.Authenticate(context.Background(), "bootstrap-secret")
+
+		assert.NoError(t, err)
+		assert.True(t, p.Admin)
+	})
+
+	t.Run("No Credentials", func(t *testing.T) {
+		_, err := auth.Authenticate(context.Background(), "")
+
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+}
+
+func (suite *DepartmentServiceTestSuite) TestTracing_SpansAndErrors() {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)
+	dept := traceDepartmentService(suite.service, tracer)
+	emp := traceEmployeeService(suite.empService, tracer)
+
+	inSpan := mock.MatchedBy(func(ctx context.Context) bool {
+		return trace.SpanContextFromContext(ctx).IsValid()
+	})
+	suite.repo.On("Exists", inSpan, 7).Return(false, nil).Once()
+	suite.empRepo.On("GetByID", inSpan, 3).Return(&models.Employee{ID: 3, DepartmentID: 1}, nil).Once()
+	suite.empRepo.On("ListDirectReports", inSpan, 3).Return([]models.Employee{}, nil).Once()
+
+	_, err := dept.GetStats(context.Background(), 7)
+	assert.ErrorIs(suite.T(), err, domain.ErrDepartmentNotFound)
+
+	_, err = emp.ListDirectReports(context.Background(), 3)
+	assert.NoError(suite.T(), err)
+
+	spans := recorder.Ended()
+	if assert.Len(suite.T(), spans, 2) {
+		assert.Equal(suite.T(), "departmentService.GetStats", spans[0].Name())
+		assert.Equal(suite.T(), codes.Error, spans[0].Status().Code)
+		assert.Contains(suite.T(), spans[0].Attributes(), attribute.Int("department.id", 7))
+		assert.Len(suite.T(), spans[0].Events(), 1, "error is recorded as event")
+
+		assert.Equal(suite.T(), "employeeService.ListDirectReports", spans[1].Name())
+		assert.Equal(suite.T(), codes.Unset, spans[1].Status().Code)
+		assert.Contains(suite.T(), spans[1].Attributes(), attribute.Int("employee.id", 3))
+	}
+	suite.repo.AssertExpectations(suite.T())
+	suite.empRepo.AssertExpectations(suite.T())
 }
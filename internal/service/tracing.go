@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName - instrumentation name of service spans
+const tracerName = "github.com/tmozzze/org_struct_api/internal/service"
+
+// endSpan - records error of call on span and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracedDepartmentService - DepartmentService starting span around every method
+type tracedDepartmentService struct {
+	next   domain.DepartmentService
+	tracer trace.Tracer
+}
+
+// traceDepartmentService - wraps DepartmentService with spans of tracer
+func traceDepartmentService(next domain.DepartmentService, tracer trace.Tracer) domain.DepartmentService {
+	return &tracedDepartmentService{next: next, tracer: tracer}
+}
+
+func (s *tracedDepartmentService) Create(ctx context.Context, req *dto.CreateDepartmentRequest) (*dto.DepartmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "departmentService.Create")
+	resp, err := s.next.Create(ctx, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedDepartmentService) GetByID(ctx context.Context, id int, req *dto.GetByIDRequest) (*dto.DepartmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "departmentService.GetByID", trace.WithAttributes(attribute.Int("department.id", id)))
+	resp, err := s.next.GetByID(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedDepartmentService) Update(ctx context.Context, id int, req *dto.UpdateDepartmentRequest) (*dto.DepartmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "departmentService.Update", trace.WithAttributes(attribute.Int("department.id", id)))
+	resp, err := s.next.Update(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedDepartmentService) Delete(ctx context.Context, id int, req *dto.DeleteDepartmentRequest) error {
+	ctx, span := s.tracer.Start(ctx, "departmentService.Delete", trace.WithAttributes(attribute.Int("department.id", id)))
+	err := s.next.Delete(ctx, id, req)
+	endSpan(span, err)
+	return err
+}
+
+func (s *tracedDepartmentService) Search(ctx context.Context, req *dto.SearchDepartmentsRequest) ([]dto.DepartmentSearchResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "departmentService.Search")
+	resp, err := s.next.Search(ctx, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedDepartmentService) ListChildren(ctx context.Context, id int, req *dto.ListChildrenRequest) (*dto.DepartmentsPage, error) {
+	ctx, span := s.tracer.Start(ctx, "departmentService.ListChildren", trace.WithAttributes(attribute.Int("department.id", id)))
+	resp, err := s.next.ListChildren(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedDepartmentService) GetStats(ctx context.Context, id int) (*dto.DepartmentStatsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "departmentService.GetStats", trace.WithAttributes(attribute.Int("department.id", id)))
+	resp, err := s.next.GetStats(ctx, id)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedDepartmentService) GetBudget(ctx context.Context, id int) ([]dto.DepartmentBudgetResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "departmentService.GetBudget", trace.WithAttributes(attribute.Int("department.id", id)))
+	resp, err := s.next.GetBudget(ctx, id)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedDepartmentService) SetHead(ctx context.Context, id int, req *dto.SetHeadRequest) (*dto.DepartmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "departmentService.SetHead", trace.WithAttributes(attribute.Int("department.id", id)))
+	resp, err := s.next.SetHead(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedDepartmentService) Reorder(ctx context.Context, id int, req *dto.ReorderChildrenRequest) ([]dto.DepartmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "departmentService.Reorder", trace.WithAttributes(attribute.Int("department.id", id)))
+	resp, err := s.next.Reorder(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+// tracedEmployeeService - EmployeeService starting span around every method
+type tracedEmployeeService struct {
+	next   domain.EmployeeService
+	tracer trace.Tracer
+}
+
+// traceEmployeeService - wraps EmployeeService with spans of tracer
+func traceEmployeeService(next domain.EmployeeService, tracer trace.Tracer) domain.EmployeeService {
+	return &tracedEmployeeService{next: next, tracer: tracer}
+}
+
+func (s *tracedEmployeeService) Create(ctx context.Context, deptID int, req *dto.CreateEmployeeRequest) (*dto.EmployeeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.Create", trace.WithAttributes(attribute.Int("department.id", deptID)))
+	resp, err := s.next.Create(ctx, deptID, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) ListByDepartment(ctx context.Context, deptID int, req *dto.ListEmployeesRequest) (*dto.EmployeesPage, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.ListByDepartment", trace.WithAttributes(attribute.Int("department.id", deptID)))
+	resp, err := s.next.ListByDepartment(ctx, deptID, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) GetManagerChain(ctx context.Context, id int) ([]dto.ManagerChainItem, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.GetManagerChain", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.GetManagerChain(ctx, id)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) SetManager(ctx context.Context, id int, req *dto.SetManagerRequest) (*dto.EmployeeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.SetManager", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.SetManager(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) ListDirectReports(ctx context.Context, id int) ([]dto.EmployeeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.ListDirectReports", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.ListDirectReports(ctx, id)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) ListAllReports(ctx context.Context, id int) ([]dto.EmployeeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.ListAllReports", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.ListAllReports(ctx, id)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) GetManagementChain(ctx context.Context, id int) ([]dto.EmployeeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.GetManagementChain", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.GetManagementChain(ctx, id)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) GetSpanOfControl(ctx context.Context, req *dto.SpanOfControlRequest) ([]dto.ManagerSpanResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.GetSpanOfControl")
+	resp, err := s.next.GetSpanOfControl(ctx, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) ListAssignments(ctx context.Context, id int) ([]dto.AssignmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.ListAssignments", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.ListAssignments(ctx, id)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) CreateAssignment(ctx context.Context, id int, req *dto.CreateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.CreateAssignment", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.CreateAssignment(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) UpdateAssignment(ctx context.Context, id int, assignmentID int, req *dto.UpdateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.UpdateAssignment", trace.WithAttributes(attribute.Int("employee.id", id), attribute.Int("assignment.id", assignmentID)))
+	resp, err := s.next.UpdateAssignment(ctx, id, assignmentID, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) DeleteAssignment(ctx context.Context, id int, assignmentID int) error {
+	ctx, span := s.tracer.Start(ctx, "employeeService.DeleteAssignment", trace.WithAttributes(attribute.Int("employee.id", id), attribute.Int("assignment.id", assignmentID)))
+	err := s.next.DeleteAssignment(ctx, id, assignmentID)
+	endSpan(span, err)
+	return err
+}
+
+func (s *tracedEmployeeService) SetStatus(ctx context.Context, id int, req *dto.SetStatusRequest) (*dto.EmployeeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.SetStatus", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.SetStatus(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) Terminate(ctx context.Context, id int, req *dto.TerminateEmployeeRequest) (*dto.EmployeeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.Terminate", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.Terminate(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) Rehire(ctx context.Context, id int, req *dto.RehireEmployeeRequest) (*dto.EmployeeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.Rehire", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.Rehire(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s *tracedEmployeeService) SetAttributes(ctx context.Context, id int, req *dto.SetAttributesRequest) (*dto.EmployeeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "employeeService.SetAttributes", trace.WithAttributes(attribute.Int("employee.id", id)))
+	resp, err := s.next.SetAttributes(ctx, id, req)
+	endSpan(span, err)
+	return resp, err
+}
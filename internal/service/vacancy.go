@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/internal/domain/dto"
+	"github.com/tmozzze/org_struct_api/internal/domain/models"
+)
+
+// employeeServiceFunc - builds EmployeeService on repositories, used to hire inside a transaction
+type employeeServiceFunc func(repo domain.Repository) domain.EmployeeService
+
+type vacancyService struct {
+	repo     domain.Repository
+	employee employeeServiceFunc
+	log      *slog.Logger
+	validate *validator.Validate
+}
+
+func newVacancyService(
+	repo domain.Repository,
+	employee employeeServiceFunc,
+	log *slog.Logger,
+	validate *validator.Validate,
+) domain.VacancyService {
+	return &vacancyService{repo: repo, employee: employee, log: log, validate: validate}
+}
+
+// Create - Open a vacancy for a catalogue position in a department
+func (s *vacancyService) Create(ctx context.Context, deptID int, req *dto.CreateVacancyRequest) (*dto.VacancyResponse, error) {
+	const op = "service.vacancy.Create"
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	// Check department exists
+	exists, err := s.repo.Department().Exists(ctx, deptID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check department existence: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: department with id '%d' not found: %w", op, deptID, domain.ErrDepartmentNotFound)
+	}
+
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleEditor, &deptID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	position, err := s.getPosition(ctx, req.PositionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Parsing date
+	targetStartDate, err := parseDate(req.TargetStartDate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid date format for target_start_date: %w", op, err)
+	}
+
+	// Mapping DTO to model
+	vacancy := &models.Vacancy{
+		DepartmentID:    deptID,
+		PositionID:      position.ID,
+		TargetStartDate: targetStartDate,
+		Status:          domain.VacancyStatusOpen,
+	}
+
+	// Go to repo
+	if err := s.repo.Vacancy().Create(ctx, vacancy); err != nil {
+		return nil, fmt.Errorf("%s: failed to create vacancy: %w", op, err)
+	}
+	vacancy.Position = position
+
+	// Mapping model to DTO
+	resp := dto.NewVacancyResponse(*vacancy)
+	return &resp, nil
+}
+
+// List - List vacancies of department, optionally filtered by status
+func (s *vacancyService) List(ctx context.Context, deptID int, req *dto.ListVacanciesRequest) ([]dto.VacancyResponse, error) {
+	const op = "service.vacancy.List"
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	// Check department exists
+	exists, err := s.repo.Department().Exists(ctx, deptID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check department existence: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: department with id '%d' not found: %w", op, deptID, domain.ErrDepartmentNotFound)
+	}
+
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleViewer, &deptID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Go to repo
+	vacancies, err := s.repo.Vacancy().ListByDepartment(ctx, deptID, req.Status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list vacancies: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.VacancyResponse, len(vacancies))
+	for i, v := range vacancies {
+		resp[i] = dto.NewVacancyResponse(v)
+	}
+	return resp, nil
+}
+
+// Update - Update position or target start date of an open vacancy, or cancel it
+func (s *vacancyService) Update(ctx context.Context, deptID int, vacancyID int, req *dto.UpdateVacancyRequest) (*dto.VacancyResponse, error) {
+	const op = "service.vacancy.Update"
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	vacancy, err := s.getVacancy(ctx, deptID, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := authorize(ctx, s.repo, domain.RoleEditor, &deptID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if vacancy.Status != domain.VacancyStatusOpen {
+		return nil, fmt.Errorf("%s: vacancy with id '%d' is %s: %w", op, vacancyID, vacancy.Status, domain.ErrVacancyClosed)
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.PositionID != nil {
+		position, err := s.getPosition(ctx, *req.PositionID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		updates["position_id"] = position.ID
+	}
+	if req.TargetStartDate != nil {
+		targetStartDate, err := parseDate(req.TargetStartDate)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid date format for target_start_date: %w", op, err)
+		}
+		updates["target_start_date"] = targetStartDate
+	}
+
+	// Go to repo to update
+	if len(updates) > 0 {
+		if err := s.repo.Vacancy().Update(ctx, vacancyID, updates); err != nil {
+			return nil, fmt.Errorf("%s: failed to update vacancy: %w", op, err)
+		}
+	}
+
+	// Cancel only while still open
+	if req.Status != nil {
+		if err := s.repo.Vacancy().SetStatus(ctx, vacancyID, domain.VacancyStatusOpen, *req.Status); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%s: vacancy with id '%d' was closed: %w", op, vacancyID, domain.ErrVacancyClosed)
+			}
+			return nil, fmt.Errorf("%s: failed to set vacancy status: %w", op, err)
+		}
+	}
+
+	vacancy, err = s.getVacancy(ctx, deptID, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Mapping model to DTO
+	resp := dto.NewVacancyResponse(*vacancy)
+	return &resp, nil
+}
+
+// Fill - Hire an employee into an open vacancy and close it
+func (s *vacancyService) Fill(ctx context.Context, deptID int, vacancyID int, req *dto.FillVacancyRequest) (*dto.FillVacancyResponse, error) {
+	const op = "service.vacancy.Fill"
+
+	// Validation
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%s: validation failed: %w", op, err)
+	}
+
+	vacancy, err := s.getVacancy(ctx, deptID, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := authorize(ctx, s.repo, domain.RoleEditor, &deptID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if vacancy.Status != domain.VacancyStatusOpen {
+		return nil, fmt.Errorf("%s: vacancy with id '%d' is %s: %w", op, vacancyID, vacancy.Status, domain.ErrVacancyClosed)
+	}
+
+	// Hired at target start date by default
+	hiredAt := req.HiredAt
+	if hiredAt == nil && vacancy.TargetStartDate != nil {
+		str := vacancy.TargetStartDate.Format(domain.DateFormat)
+		hiredAt = &str
+	}
+
+	// Claim vacancy, hire and link employee in one transaction
+	var emp *dto.EmployeeResponse
+	err = s.repo.Transaction(ctx, func(repo domain.Repository) error {
+		// Claim vacancy before hiring, so it can't be filled twice
+		if err := repo.Vacancy().SetStatus(ctx, vacancyID, domain.VacancyStatusOpen, domain.VacancyStatusFilled); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("vacancy with id '%d' was closed: %w", vacancyID, domain.ErrVacancyClosed)
+			}
+			return fmt.Errorf("failed to set vacancy status: %w", err)
+		}
+
+		emp, err = s.employee(repo).Create(ctx, vacancy.DepartmentID, &dto.CreateEmployeeRequest{
+			FullName:       req.FullName,
+			PositionID:     &vacancy.PositionID,
+			HiredAt:        hiredAt,
+			ManagerID:      req.ManagerID,
+			Email:          req.Email,
+			Phone:          req.Phone,
+			EmployeeNumber: req.EmployeeNumber,
+			Attributes:     req.Attributes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create employee: %w", err)
+		}
+
+		// Link hired employee
+		if err := repo.Vacancy().Update(ctx, vacancyID, map[string]interface{}{"employee_id": emp.ID}); err != nil {
+			return fmt.Errorf("failed to link employee to vacancy: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	vacancy.Status = domain.VacancyStatusFilled
+	vacancy.EmployeeID = &emp.ID
+
+	// Mapping model to DTO
+	return &dto.FillVacancyResponse{
+		Vacancy:  dto.NewVacancyResponse(*vacancy),
+		Employee: *emp,
+	}, nil
+}
+
+// GetHeadcount - Get actual and planned headcount of department and every department of its subtree
+func (s *vacancyService) GetHeadcount(ctx context.Context, deptID int) ([]dto.HeadcountResponse, error) {
+	const op = "service.vacancy.GetHeadcount"
+
+	// Go to repo
+	rows, err := s.repo.Vacancy().GetHeadcount(ctx, deptID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get headcount: %w", op, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: department with id '%d' not found: %w", op, deptID, domain.ErrDepartmentNotFound)
+	}
+
+	// Check rights on department
+	if err := authorize(ctx, s.repo, domain.RoleViewer, &deptID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Mapping models to DTO
+	resp := make([]dto.HeadcountResponse, len(rows))
+	for i, row := range rows {
+		resp[i] = dto.NewHeadcountResponse(row)
+	}
+	return resp, nil
+}
+
+// getVacancy - get vacancy of department or ErrVacancyNotFound
+func (s *vacancyService) getVacancy(ctx context.Context, deptID int, vacancyID int) (*models.Vacancy, error) {
+	vacancy, err := s.repo.Vacancy().GetByID(ctx, vacancyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("vacancy with id '%d' not found: %w", vacancyID, domain.ErrVacancyNotFound)
+		}
+		return nil, fmt.Errorf("failed to get vacancy: %w", err)
+	}
+
+	// Vacancy of another department
+	if vacancy.DepartmentID != deptID {
+		return nil, fmt.Errorf("vacancy with id '%d' not found in department '%d': %w", vacancyID, deptID, domain.ErrVacancyNotFound)
+	}
+
+	return vacancy, nil
+}
+
+// getPosition - get catalogue position or ErrPositionNotFound
+func (s *vacancyService) getPosition(ctx context.Context, id int) (*models.Position, error) {
+	position, err := s.repo.Position().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("position with id '%d' not found: %w", id, domain.ErrPositionNotFound)
+		}
+		return nil, fmt.Errorf("failed to get position: %w", err)
+	}
+
+	return position, nil
+}
+
+// parseDate - parse optional date in DateFormat
+func parseDate(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	t, err := time.Parse(domain.DateFormat, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmozzze/org_struct_api/internal/config"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup_StdoutExporter(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	var out bytes.Buffer
+	shutdown, err := Setup(context.Background(), config.TracingCfg{
+		Exporter:    ExporterStdout,
+		ServiceName: "org_struct_api_test",
+		SampleRatio: 1,
+	}, &out)
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("tracing_test").Start(context.Background(), "test-span")
+	span.End()
+
+	// Shutdown flushes batched span to out
+	require.NoError(t, shutdown(context.Background()))
+
+	var exported struct {
+		Name        string
+		SpanContext struct {
+			TraceID string
+		}
+		Resource []struct {
+			Key   string
+			Value struct {
+				Value interface{}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &exported), "span should be written as JSON: %s", out.String())
+	assert.Equal(t, "test-span", exported.Name)
+	assert.Equal(t, span.SpanContext().TraceID().String(), exported.SpanContext.TraceID)
+
+	var serviceName interface{}
+	for _, kv := range exported.Resource {
+		if kv.Key == "service.name" {
+			serviceName = kv.Value.Value
+		}
+	}
+	assert.Equal(t, "org_struct_api_test", serviceName)
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), config.TracingCfg{Exporter: "jaeger"}, nil)
+
+	assert.ErrorContains(t, err, "unknown exporter 'jaeger'")
+}
@@ -0,0 +1,238 @@
+// Package client - typed Go client of org_struct_api HTTP API
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default retry settings
+const (
+	DefaultMaxAttempts = 3
+	DefaultBaseDelay   = 100 * time.Millisecond
+	DefaultMaxDelay    = 2 * time.Second
+	DefaultTimeout     = 30 * time.Second
+)
+
+// Client - client of org_struct_api, Department and Employee mirror domain services over HTTP
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+
+	department *DepartmentClient
+	employee   *EmployeeClient
+}
+
+// Option - configures Client
+type Option func(*Client)
+
+// WithToken - sends token (JWT or API key) as Bearer authorization on every request
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient - uses hc to send requests, default client has DefaultTimeout
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithRetry - retries idempotent requests up to maxAttempts attempts in total,
+// waiting exponential backoff with jitter from baseDelay up to maxDelay, 1 attempt disables retries
+func WithRetry(maxAttempts int, baseDelay, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.baseDelay = baseDelay
+		c.maxDelay = maxDelay
+	}
+}
+
+// New - creates client of API at baseURL, for example http://localhost:8080
+func New(baseURL string, opts ...Option) (*Client, error) {
+	const op = "client.New"
+
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%s: invalid base url '%s'", op, baseURL)
+	}
+
+	c := &Client{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		http:        &http.Client{Timeout: DefaultTimeout},
+		maxAttempts: DefaultMaxAttempts,
+		baseDelay:   DefaultBaseDelay,
+		maxDelay:    DefaultMaxDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxAttempts < 1 {
+		c.maxAttempts = 1
+	}
+
+	c.department = &DepartmentClient{c: c}
+	c.employee = &EmployeeClient{c: c}
+
+	return c, nil
+}
+
+// Department - department endpoints
+func (c *Client) Department() *DepartmentClient {
+	return c.department
+}
+
+// Employee - employee endpoints
+func (c *Client) Employee() *EmployeeClient {
+	return c.employee
+}
+
+// do - sends request with JSON body, retrying idempotent methods, and decodes JSON response into out,
+// nil out discards response body
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	op := method + " " + path
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("%s: failed to encode request: %w", op, err)
+		}
+	}
+
+	attempts := 1
+	if idempotent(method) {
+		attempts = c.maxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, target, payload)
+
+		var wait time.Duration
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || attempt == attempts {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		case retryable(resp.StatusCode) && attempt < attempts:
+			wait = retryAfter(resp)
+			drain(resp)
+		default:
+			defer drain(resp)
+			return decodeResponse(op, resp, out)
+		}
+
+		if wait == 0 {
+			wait = c.backoff(attempt)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// send - sends one attempt of request
+func (c *Client) send(ctx context.Context, method, target string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return c.http.Do(req)
+}
+
+// backoff - wait before next attempt, exponential with full jitter
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.baseDelay << (attempt - 1)
+	if delay <= 0 || delay > c.maxDelay {
+		delay = c.maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay) + 1
+}
+
+// decodeResponse - decodes successful response into out or error response into *Error
+func decodeResponse(op string, resp *http.Response, out interface{}) error {
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s: %w", op, decodeError(resp))
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s: failed to decode response: %w", op, err)
+	}
+	return nil
+}
+
+// idempotent - methods safe to send again after failure
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryable - statuses of overloaded or restarting server
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter - wait requested by Retry-After header in seconds, 0 when there is none
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// drain - reads rest of body so connection can be reused
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
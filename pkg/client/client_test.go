@@ -0,0 +1,280 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmozzze/org_struct_api/internal/domain"
+	"github.com/tmozzze/org_struct_api/pkg/client"
+)
+
+// newClient - client of server without real backoff waits
+func newClient(t *testing.T, h http.HandlerFunc) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+
+	c, err := client.New(server.URL, client.WithToken("secret"), client.WithRetry(3, time.Millisecond, 2*time.Millisecond))
+	require.NoError(t, err)
+	return c
+}
+
+func renderError(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+func TestNew_InvalidURL(t *testing.T) {
+	_, err := client.New("localhost:8080")
+	assert.Error(t, err)
+}
+
+func TestDepartment_GetByID(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/departments/7", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		query := r.URL.Query()
+		assert.Equal(t, "3", query.Get("depth"))
+		assert.Equal(t, "false", query.Get("include_employees"))
+		assert.Equal(t, "true", query.Get("include_stats"))
+		assert.False(t, query.Has("children_limit"))
+
+		json.NewEncoder(w).Encode(client.DepartmentResponse{ID: 7, Name: "Backend",
+			Children: []client.DepartmentResponse{{ID: 8, Name: "Platform"}}})
+	})
+
+	resp, err := c.Department().GetByID(context.Background(), 7, &client.GetByIDRequest{Depth: 3, IncludeStats: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Backend", resp.Name)
+	require.Len(t, resp.Children, 1)
+	assert.Equal(t, 8, resp.Children[0].ID)
+}
+
+func TestDepartment_CreateAndDelete(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var req client.CreateDepartmentRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(client.DepartmentResponse{ID: 3, Name: req.Name, ParentID: req.ParentID})
+		case http.MethodDelete:
+			assert.Equal(t, "/departments/3", r.URL.Path)
+			assert.Equal(t, client.ModeReassign, r.URL.Query().Get("mode"))
+			assert.Equal(t, "1", r.URL.Query().Get("reassign_to_department_id"))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	ctx := context.Background()
+	parentID := 1
+
+	dept, err := c.Department().Create(ctx, &client.CreateDepartmentRequest{Name: "Sales", ParentID: &parentID})
+	require.NoError(t, err)
+	assert.Equal(t, 3, dept.ID)
+	assert.Equal(t, &parentID, dept.ParentID)
+
+	err = c.Department().Delete(ctx, dept.ID, &client.DeleteDepartmentRequest{Mode: client.ModeReassign, ReassignToID: &parentID})
+	assert.NoError(t, err)
+}
+
+func TestEmployee_ListByDepartment(t *testing.T) {
+	next := "abc"
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/departments/2/employees", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "cur", query.Get("cursor"))
+		assert.Equal(t, "50", query.Get("limit"))
+		assert.Equal(t, "4", query.Get("position_id"))
+		assert.Equal(t, "senior", query.Get("attr.grade"))
+		json.NewEncoder(w).Encode(client.EmployeesPage{Items: []client.EmployeeResponse{{ID: 1, FullName: "Ann"}}, NextCursor: &next})
+	})
+	positionID := 4
+
+	page, err := c.Employee().ListByDepartment(context.Background(), 2, &client.ListEmployeesRequest{
+		PageRequest: client.PageRequest{Cursor: "cur", Limit: 50},
+		PositionID:  &positionID,
+		Attributes:  map[string]string{"grade": "senior"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, &next, page.NextCursor)
+}
+
+func TestErrors_MatchDomainSentinels(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    error
+		notWant error
+	}{
+		{
+			name:    "wrapped not found",
+			status:  http.StatusNotFound,
+			body:    `{"error":"service.department.GetByID: failed to get department by id: department not found"}`,
+			want:    domain.ErrDepartmentNotFound,
+			notWant: domain.ErrNotFound,
+		},
+		{
+			name:   "parent not found after details",
+			status: http.StatusNotFound,
+			body:   `{"error":"service.department.Update: parent department with id '9' not found: parent not found"}`,
+			want:   domain.ErrParentNotFound,
+		},
+		{
+			name:   "sentinel in the middle",
+			status: http.StatusBadRequest,
+			body:   `{"error":"service.department.ListChildren: invalid cursor: illegal base64 data at input byte 0"}`,
+			want:   domain.ErrInvalidCursor,
+		},
+		{
+			name:   "hierarchy rule",
+			status: http.StatusUnprocessableEntity,
+			body:   `{"error":"service.department.Create: department hierarchy rule violated"}`,
+			want:   client.ErrHierarchyRule,
+		},
+		{
+			name:   "unauthenticated",
+			status: http.StatusUnauthorized,
+			body:   `{"error":"authentication required"}`,
+			want:   client.ErrUnauthenticated,
+		},
+		{
+			name:    "text of other status is not matched",
+			status:  http.StatusInternalServerError,
+			body:    `{"error":"department not found"}`,
+			notWant: domain.ErrDepartmentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+				renderError(w, tt.status, tt.body)
+			})
+
+			_, err := c.Department().GetByID(context.Background(), 1, nil)
+
+			var apiErr *client.Error
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.status, apiErr.StatusCode)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+			if tt.notWant != nil {
+				assert.NotErrorIs(t, err, tt.notWant)
+			}
+		})
+	}
+}
+
+func TestErrors_Conflict(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		renderError(w, http.StatusConflict, `{"error":"email is already in use","conflict":{"id":12,"full_name":"Ann"}}`)
+	})
+
+	_, err := c.Employee().Create(context.Background(), 1, &client.CreateEmployeeRequest{FullName: "Ann", Position: "Dev"})
+
+	require.ErrorIs(t, err, client.ErrDuplicateEmail)
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	var existing client.EmployeeResponse
+	ok, err := apiErr.DecodeConflict(&existing)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 12, existing.ID)
+}
+
+func TestRetry_IdempotentCalls(t *testing.T) {
+	var calls atomic.Int32
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			renderError(w, http.StatusServiceUnavailable, `{"error":"unavailable"}`)
+			return
+		}
+		json.NewEncoder(w).Encode([]client.EmployeeResponse{{ID: 5}})
+	})
+
+	reports, err := c.Employee().ListDirectReports(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Len(t, reports, 1)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		renderError(w, http.StatusBadGateway, `{"error":"bad gateway"}`)
+	})
+
+	_, err := c.Department().GetStats(context.Background(), 1)
+
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetry_NotForNonIdempotentCalls(t *testing.T) {
+	var calls atomic.Int32
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		renderError(w, http.StatusServiceUnavailable, `{"error":"unavailable"}`)
+	})
+
+	_, err := c.Department().Create(context.Background(), &client.CreateDepartmentRequest{Name: "Sales"})
+	require.Error(t, err)
+	_, err = c.Department().Update(context.Background(), 1, &client.UpdateDepartmentRequest{})
+	require.Error(t, err)
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetry_NotForClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		renderError(w, http.StatusNotFound, `{"error":"employee not found"}`)
+	})
+
+	_, err := c.Employee().SetStatus(context.Background(), 1, &client.SetStatusRequest{Status: domain.EmployeeStatusOnLeave})
+
+	assert.ErrorIs(t, err, client.ErrEmployeeNotFound)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetry_StopsOnContextCancel(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		renderError(w, http.StatusServiceUnavailable, `{"error":"unavailable"}`)
+	}))
+	defer server.Close()
+
+	c, err := client.New(server.URL, client.WithRetry(5, time.Hour, time.Hour))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Department().GetBudget(ctx, 1)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+)
+
+// attributeFilterPrefix - query parameter prefix of custom attribute filters
+const attributeFilterPrefix = "attr."
+
+var _ domain.DepartmentService = (*DepartmentClient)(nil)
+
+// DepartmentClient - department endpoints, implements domain.DepartmentService
+type DepartmentClient struct {
+	c *Client
+}
+
+// Create - POST /departments
+func (d *DepartmentClient) Create(ctx context.Context, req *CreateDepartmentRequest) (*DepartmentResponse, error) {
+	var resp DepartmentResponse
+	if err := d.c.do(ctx, http.MethodPost, "/departments", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetByID - GET /departments/{id}, zero limits leave server defaults
+func (d *DepartmentClient) GetByID(ctx context.Context, id int, req *GetByIDRequest) (*DepartmentResponse, error) {
+	query := url.Values{}
+	if req != nil {
+		setInt(query, "depth", req.Depth)
+		query.Set("include_employees", strconv.FormatBool(req.IncludeEmployees))
+		setInt(query, "children_limit", req.ChildrenLimit)
+		setInt(query, "employees_limit", req.EmployeesLimit)
+		setBool(query, "include_stats", req.IncludeStats)
+		setBool(query, "include_secondary", req.IncludeSecondary)
+		setBool(query, "include_terminated", req.IncludeTerminated)
+	}
+
+	var resp DepartmentResponse
+	if err := d.c.do(ctx, http.MethodGet, departmentPath(id), query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Update - PATCH /departments/{id}
+func (d *DepartmentClient) Update(ctx context.Context, id int, req *UpdateDepartmentRequest) (*DepartmentResponse, error) {
+	var resp DepartmentResponse
+	if err := d.c.do(ctx, http.MethodPatch, departmentPath(id), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Delete - DELETE /departments/{id}, nil req deletes in cascade mode
+func (d *DepartmentClient) Delete(ctx context.Context, id int, req *DeleteDepartmentRequest) error {
+	query := url.Values{}
+	query.Set("mode", ModeCascade)
+	if req != nil && req.Mode != "" {
+		query.Set("mode", req.Mode)
+	}
+	if req != nil && req.ReassignToID != nil {
+		query.Set("reassign_to_department_id", strconv.Itoa(*req.ReassignToID))
+	}
+	return d.c.do(ctx, http.MethodDelete, departmentPath(id), query, nil, nil)
+}
+
+// Search - GET /departments/search
+func (d *DepartmentClient) Search(ctx context.Context, req *SearchDepartmentsRequest) ([]DepartmentSearchResponse, error) {
+	query := url.Values{}
+	query.Set("q", req.Query)
+	setInt(query, "limit", req.Limit)
+
+	var resp []DepartmentSearchResponse
+	if err := d.c.do(ctx, http.MethodGet, "/departments/search", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListChildren - GET /departments/{id}/children
+func (d *DepartmentClient) ListChildren(ctx context.Context, id int, req *ListChildrenRequest) (*DepartmentsPage, error) {
+	query := url.Values{}
+	if req != nil {
+		setPage(query, req.PageRequest)
+		setAttributes(query, req.Attributes)
+	}
+
+	var resp DepartmentsPage
+	if err := d.c.do(ctx, http.MethodGet, departmentPath(id)+"/children", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetStats - GET /departments/{id}/stats
+func (d *DepartmentClient) GetStats(ctx context.Context, id int) (*DepartmentStatsResponse, error) {
+	var resp DepartmentStatsResponse
+	if err := d.c.do(ctx, http.MethodGet, departmentPath(id)+"/stats", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetBudget - GET /departments/{id}/budget
+func (d *DepartmentClient) GetBudget(ctx context.Context, id int) ([]DepartmentBudgetResponse, error) {
+	var resp []DepartmentBudgetResponse
+	if err := d.c.do(ctx, http.MethodGet, departmentPath(id)+"/budget", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetHead - PUT /departments/{id}/head
+func (d *DepartmentClient) SetHead(ctx context.Context, id int, req *SetHeadRequest) (*DepartmentResponse, error) {
+	var resp DepartmentResponse
+	if err := d.c.do(ctx, http.MethodPut, departmentPath(id)+"/head", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Reorder - POST /departments/{id}/reorder
+func (d *DepartmentClient) Reorder(ctx context.Context, id int, req *ReorderChildrenRequest) ([]DepartmentResponse, error) {
+	var resp []DepartmentResponse
+	if err := d.c.do(ctx, http.MethodPost, departmentPath(id)+"/reorder", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func departmentPath(id int) string {
+	return "/departments/" + strconv.Itoa(id)
+}
+
+// setInt - sets positive value only, zero leaves server default
+func setInt(query url.Values, key string, v int) {
+	if v > 0 {
+		query.Set(key, strconv.Itoa(v))
+	}
+}
+
+// setBool - sets true only, false is server default
+func setBool(query url.Values, key string, v bool) {
+	if v {
+		query.Set(key, "true")
+	}
+}
+
+func setPage(query url.Values, page PageRequest) {
+	if page.Cursor != "" {
+		query.Set("cursor", page.Cursor)
+	}
+	setInt(query, "limit", page.Limit)
+}
+
+func setAttributes(query url.Values, attributes map[string]string) {
+	for name, value := range attributes {
+		query.Set(attributeFilterPrefix+name, value)
+	}
+}
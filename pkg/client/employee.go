@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+)
+
+var _ domain.EmployeeService = (*EmployeeClient)(nil)
+
+// EmployeeClient - employee endpoints, implements domain.EmployeeService
+type EmployeeClient struct {
+	c *Client
+}
+
+// Create - POST /departments/{id}/employees
+func (e *EmployeeClient) Create(ctx context.Context, deptID int, req *CreateEmployeeRequest) (*EmployeeResponse, error) {
+	var resp EmployeeResponse
+	if err := e.c.do(ctx, http.MethodPost, departmentPath(deptID)+"/employees", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListByDepartment - GET /departments/{id}/employees
+func (e *EmployeeClient) ListByDepartment(ctx context.Context, deptID int, req *ListEmployeesRequest) (*EmployeesPage, error) {
+	query := url.Values{}
+	if req != nil {
+		setPage(query, req.PageRequest)
+		if req.PositionID != nil {
+			query.Set("position_id", strconv.Itoa(*req.PositionID))
+		}
+		setBool(query, "include_terminated", req.IncludeTerminated)
+		setAttributes(query, req.Attributes)
+	}
+
+	var resp EmployeesPage
+	if err := e.c.do(ctx, http.MethodGet, departmentPath(deptID)+"/employees", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetManagerChain - GET /employees/{id}/manager-chain
+func (e *EmployeeClient) GetManagerChain(ctx context.Context, id int) ([]ManagerChainItem, error) {
+	var resp []ManagerChainItem
+	if err := e.c.do(ctx, http.MethodGet, employeePath(id)+"/manager-chain", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetManager - PUT /employees/{id}/manager
+func (e *EmployeeClient) SetManager(ctx context.Context, id int, req *SetManagerRequest) (*EmployeeResponse, error) {
+	return e.employee(ctx, http.MethodPut, employeePath(id)+"/manager", req)
+}
+
+// ListDirectReports - GET /employees/{id}/reports
+func (e *EmployeeClient) ListDirectReports(ctx context.Context, id int) ([]EmployeeResponse, error) {
+	return e.employees(ctx, employeePath(id)+"/reports")
+}
+
+// ListAllReports - GET /employees/{id}/reports/all
+func (e *EmployeeClient) ListAllReports(ctx context.Context, id int) ([]EmployeeResponse, error) {
+	return e.employees(ctx, employeePath(id)+"/reports/all")
+}
+
+// GetManagementChain - GET /employees/{id}/management-chain
+func (e *EmployeeClient) GetManagementChain(ctx context.Context, id int) ([]EmployeeResponse, error) {
+	return e.employees(ctx, employeePath(id)+"/management-chain")
+}
+
+// GetSpanOfControl - GET /reports/span-of-control, zero limits leave server defaults
+func (e *EmployeeClient) GetSpanOfControl(ctx context.Context, req *SpanOfControlRequest) ([]ManagerSpanResponse, error) {
+	query := url.Values{}
+	if req != nil {
+		setInt(query, "min", req.Min)
+		setInt(query, "max", req.Max)
+	}
+
+	var resp []ManagerSpanResponse
+	if err := e.c.do(ctx, http.MethodGet, "/reports/span-of-control", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListAssignments - GET /employees/{id}/assignments
+func (e *EmployeeClient) ListAssignments(ctx context.Context, id int) ([]AssignmentResponse, error) {
+	var resp []AssignmentResponse
+	if err := e.c.do(ctx, http.MethodGet, employeePath(id)+"/assignments", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateAssignment - POST /employees/{id}/assignments
+func (e *EmployeeClient) CreateAssignment(ctx context.Context, id int, req *CreateAssignmentRequest) (*AssignmentResponse, error) {
+	var resp AssignmentResponse
+	if err := e.c.do(ctx, http.MethodPost, employeePath(id)+"/assignments", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateAssignment - PATCH /employees/{id}/assignments/{assignment_id}
+func (e *EmployeeClient) UpdateAssignment(ctx context.Context, id int, assignmentID int, req *UpdateAssignmentRequest) (*AssignmentResponse, error) {
+	var resp AssignmentResponse
+	if err := e.c.do(ctx, http.MethodPatch, assignmentPath(id, assignmentID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteAssignment - DELETE /employees/{id}/assignments/{assignment_id}
+func (e *EmployeeClient) DeleteAssignment(ctx context.Context, id int, assignmentID int) error {
+	return e.c.do(ctx, http.MethodDelete, assignmentPath(id, assignmentID), nil, nil, nil)
+}
+
+// SetStatus - PUT /employees/{id}/status
+func (e *EmployeeClient) SetStatus(ctx context.Context, id int, req *SetStatusRequest) (*EmployeeResponse, error) {
+	return e.employee(ctx, http.MethodPut, employeePath(id)+"/status", req)
+}
+
+// Terminate - POST /employees/{id}/terminate, nil req terminates today
+func (e *EmployeeClient) Terminate(ctx context.Context, id int, req *TerminateEmployeeRequest) (*EmployeeResponse, error) {
+	if req == nil {
+		req = &TerminateEmployeeRequest{}
+	}
+	return e.employee(ctx, http.MethodPost, employeePath(id)+"/terminate", req)
+}
+
+// Rehire - POST /employees/{id}/rehire, nil req rehires today
+func (e *EmployeeClient) Rehire(ctx context.Context, id int, req *RehireEmployeeRequest) (*EmployeeResponse, error) {
+	if req == nil {
+		req = &RehireEmployeeRequest{}
+	}
+	return e.employee(ctx, http.MethodPost, employeePath(id)+"/rehire", req)
+}
+
+// SetAttributes - PUT /employees/{id}/attributes
+func (e *EmployeeClient) SetAttributes(ctx context.Context, id int, req *SetAttributesRequest) (*EmployeeResponse, error) {
+	return e.employee(ctx, http.MethodPut, employeePath(id)+"/attributes", req)
+}
+
+// employee - sends body and decodes one employee
+func (e *EmployeeClient) employee(ctx context.Context, method, path string, body interface{}) (*EmployeeResponse, error) {
+	var resp EmployeeResponse
+	if err := e.c.do(ctx, method, path, nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// employees - gets list of employees
+func (e *EmployeeClient) employees(ctx context.Context, path string) ([]EmployeeResponse, error) {
+	var resp []EmployeeResponse
+	if err := e.c.do(ctx, http.MethodGet, path, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func employeePath(id int) string {
+	return "/employees/" + strconv.Itoa(id)
+}
+
+func assignmentPath(id, assignmentID int) string {
+	return employeePath(id) + "/assignments/" + strconv.Itoa(assignmentID)
+}
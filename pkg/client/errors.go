@@ -0,0 +1,149 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/tmozzze/org_struct_api/internal/domain"
+)
+
+// Domain errors reported by API, errors of Client match them with errors.Is
+var (
+	ErrNotFound               = domain.ErrNotFound
+	ErrDepartmentNotFound     = domain.ErrDepartmentNotFound
+	ErrParentNotFound         = domain.ErrParentNotFound
+	ErrEmployeeNotFound       = domain.ErrEmployeeNotFound
+	ErrManagerNotFound        = domain.ErrManagerNotFound
+	ErrAssignmentNotFound     = domain.ErrAssignmentNotFound
+	ErrPositionNotFound       = domain.ErrPositionNotFound
+	ErrVacancyNotFound        = domain.ErrVacancyNotFound
+	ErrAttributeNotFound      = domain.ErrAttributeNotFound
+	ErrDepartmentTypeNotFound = domain.ErrDepartmentTypeNotFound
+	ErrAPIKeyNotFound         = domain.ErrAPIKeyNotFound
+	ErrGrantNotFound          = domain.ErrGrantNotFound
+
+	ErrUnauthenticated = domain.ErrUnauthenticated
+	ErrForbidden       = domain.ErrForbidden
+
+	ErrDuplicateName           = domain.ErrDuplicateName
+	ErrAlreadyExist            = domain.ErrAlreadyExist
+	ErrDuplicateEmail          = domain.ErrDuplicateEmail
+	ErrDuplicateEmployeeNumber = domain.ErrDuplicateEmployeeNumber
+	ErrDuplicateCostCenter     = domain.ErrDuplicateCostCenter
+	ErrPositionInUse           = domain.ErrPositionInUse
+	ErrVacancyClosed           = domain.ErrVacancyClosed
+	ErrDepartmentTypeInUse     = domain.ErrDepartmentTypeInUse
+	ErrEmployeeTerminated      = domain.ErrEmployeeTerminated
+	ErrEmployeeNotTerminated   = domain.ErrEmployeeNotTerminated
+	ErrCycleConstraint         = domain.ErrCycleConstraint
+
+	ErrHierarchyRule        = domain.ErrHierarchyRule
+	ErrLengthConstraint     = domain.ErrLengthConstraint
+	ErrEmptyConstraint      = domain.ErrEmptyConstraint
+	ErrAllocationConstraint = domain.ErrAllocationConstraint
+	ErrInvalidReassignToID  = domain.ErrInvalidReassignToID
+	ErrInvalidCursor        = domain.ErrInvalidCursor
+	ErrInvalidHead          = domain.ErrInvalidHead
+	ErrInvalidPrimary       = domain.ErrInvalidPrimary
+	ErrInvalidTerminatedAt  = domain.ErrInvalidTerminatedAt
+	ErrInvalidRehiredAt     = domain.ErrInvalidRehiredAt
+	ErrInvalidAttribute     = domain.ErrInvalidAttribute
+	ErrInvalidChildOrder    = domain.ErrInvalidChildOrder
+	ErrInvalidExpiresAt     = domain.ErrInvalidExpiresAt
+)
+
+// sentinels - domain errors by status API reports them with
+var sentinels = map[int][]error{
+	http.StatusNotFound: {
+		ErrNotFound, ErrDepartmentNotFound, ErrParentNotFound, ErrEmployeeNotFound, ErrManagerNotFound,
+		ErrAssignmentNotFound, ErrPositionNotFound, ErrVacancyNotFound, ErrAttributeNotFound,
+		ErrDepartmentTypeNotFound, ErrAPIKeyNotFound, ErrGrantNotFound,
+	},
+	http.StatusConflict: {
+		ErrDuplicateName, ErrAlreadyExist, ErrDuplicateEmail, ErrDuplicateEmployeeNumber, ErrDuplicateCostCenter,
+		ErrPositionInUse, ErrDepartmentTypeInUse, ErrVacancyClosed, ErrEmployeeTerminated, ErrEmployeeNotTerminated,
+		ErrCycleConstraint,
+	},
+	http.StatusBadRequest: {
+		ErrInvalidReassignToID, ErrInvalidCursor, ErrInvalidHead, ErrInvalidPrimary, ErrInvalidTerminatedAt,
+		ErrInvalidRehiredAt, ErrInvalidAttribute, ErrInvalidChildOrder, ErrInvalidExpiresAt,
+		ErrAllocationConstraint, ErrLengthConstraint, ErrEmptyConstraint,
+	},
+	http.StatusUnprocessableEntity: {ErrHierarchyRule},
+	http.StatusUnauthorized:        {ErrUnauthenticated},
+	http.StatusForbidden:           {ErrForbidden},
+}
+
+// errorBody - error response of API
+type errorBody struct {
+	Error    string          `json:"error"`
+	Conflict json.RawMessage `json:"conflict,omitempty"`
+}
+
+// decodeError - converts error response to *Error matching domain sentinel of message
+func decodeError(resp *http.Response) error {
+	var body errorBody
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil || body.Error == "" {
+		body.Error = http.StatusText(resp.StatusCode)
+	}
+
+	return &Error{
+		StatusCode: resp.StatusCode,
+		Message:    body.Error,
+		Conflict:   body.Conflict,
+		err:        matchSentinel(resp.StatusCode, body.Error),
+	}
+}
+
+// Error - error response of API, unwraps to domain error of message when there is one
+type Error struct {
+	StatusCode int
+	// Message - error text of response
+	Message string
+	// Conflict - raw JSON of existing entity the request conflicts with
+	Conflict json.RawMessage
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// DecodeConflict - decodes existing entity of conflict into v, false when response has none
+func (e *Error) DecodeConflict(v interface{}) (bool, error) {
+	if len(e.Conflict) == 0 {
+		return false, nil
+	}
+	if err := json.Unmarshal(e.Conflict, v); err != nil {
+		return true, fmt.Errorf("client.DecodeConflict: %w", err)
+	}
+	return true, nil
+}
+
+// matchSentinel - domain error of status whose text is one of ": " separated parts of message,
+// longest text wins so "department not found" is not taken for "not found"
+func matchSentinel(status int, message string) error {
+	var match error
+	for _, sentinel := range sentinels[status] {
+		text := sentinel.Error()
+		matched := message == text ||
+			strings.HasPrefix(message, text+": ") ||
+			strings.HasSuffix(message, ": "+text) ||
+			strings.Contains(message, ": "+text+": ")
+		if !matched {
+			continue
+		}
+		if match == nil || len(text) > len(match.Error()) {
+			match = sentinel
+		}
+	}
+	return match
+}
@@ -0,0 +1,51 @@
+package client
+
+import "github.com/tmozzze/org_struct_api/internal/domain/dto"
+
+// Request and response payloads of API, aliases let code outside this module name them
+
+// Departments
+type (
+	CreateDepartmentRequest  = dto.CreateDepartmentRequest
+	GetByIDRequest           = dto.GetByIDRequest
+	UpdateDepartmentRequest  = dto.UpdateDepartmentRequest
+	DeleteDepartmentRequest  = dto.DeleteDepartmentRequest
+	SearchDepartmentsRequest = dto.SearchDepartmentsRequest
+	ListChildrenRequest      = dto.ListChildrenRequest
+	SetHeadRequest           = dto.SetHeadRequest
+	ReorderChildrenRequest   = dto.ReorderChildrenRequest
+	DepartmentResponse       = dto.DepartmentResponse
+	DepartmentSearchResponse = dto.DepartmentSearchResponse
+	DepartmentStatsResponse  = dto.DepartmentStatsResponse
+	DepartmentBudgetResponse = dto.DepartmentBudgetResponse
+	DepartmentsPage          = dto.DepartmentsPage
+	SecondaryMemberResponse  = dto.SecondaryMemberResponse
+)
+
+// Employees
+type (
+	CreateEmployeeRequest    = dto.CreateEmployeeRequest
+	ListEmployeesRequest     = dto.ListEmployeesRequest
+	SetManagerRequest        = dto.SetManagerRequest
+	SetStatusRequest         = dto.SetStatusRequest
+	TerminateEmployeeRequest = dto.TerminateEmployeeRequest
+	RehireEmployeeRequest    = dto.RehireEmployeeRequest
+	SetAttributesRequest     = dto.SetAttributesRequest
+	SpanOfControlRequest     = dto.SpanOfControlRequest
+	EmployeeResponse         = dto.EmployeeResponse
+	EmployeesPage            = dto.EmployeesPage
+	ManagerChainItem         = dto.ManagerChainItem
+	ManagerSpanResponse      = dto.ManagerSpanResponse
+	CreateAssignmentRequest  = dto.CreateAssignmentRequest
+	UpdateAssignmentRequest  = dto.UpdateAssignmentRequest
+	AssignmentResponse       = dto.AssignmentResponse
+)
+
+// PageRequest - cursor and size of page
+type PageRequest = dto.PageRequest
+
+// Delete modes of DeleteDepartmentRequest
+const (
+	ModeCascade  = "cascade"
+	ModeReassign = "reassign"
+)
@@ -1,23 +1,147 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
-	"github.com/pressly/goose"
-	"github.com/tmozzze/org_struct_api/internal/config"
+	"github.com/pressly/goose/v3"
+	"github.com/tmozzze/org_struct_api/database/migrations"
 )
 
-// RunMigrations - apply database migrations using goose
-func RunMigrations(cfg config.Config, db *sql.DB) error {
-	const op = "database.RunMigrations"
+// MigrationResult - applied or rolled back migration
+type MigrationResult = goose.MigrationResult
 
-	if err := goose.SetDialect(cfg.DBDialect); err != nil {
-		return fmt.Errorf("%s: failed to apply migrations: %w", op, err)
+// MigrationStatus - migration with its state in database
+type MigrationStatus = goose.MigrationStatus
+
+// ErrNoMigration - there is no migration to roll back or apply again
+var ErrNoMigration = goose.ErrNoNextVersion
+
+// Migrator - applies migrations embedded into the binary
+type Migrator struct {
+	provider *goose.Provider
+}
+
+// NewMigrator - constructor for Migrator of db with goose dialect
+func NewMigrator(dialect string, db *sql.DB) (*Migrator, error) {
+	const op = "database.NewMigrator"
+
+	provider, err := goose.NewProvider(goose.Dialect(dialect), db, migrations.FS)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to load migrations: %w", op, err)
+	}
+
+	return &Migrator{provider: provider}, nil
+}
+
+// Up - apply every pending migration
+func (m *Migrator) Up(ctx context.Context) ([]*MigrationResult, error) {
+	const op = "database.Migrator.Up"
+
+	results, err := m.provider.Up(ctx)
+	if err != nil {
+		return results, fmt.Errorf("%s: failed to apply migrations: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// Down - roll back the newest applied migration
+func (m *Migrator) Down(ctx context.Context) (*MigrationResult, error) {
+	const op = "database.Migrator.Down"
+
+	result, err := m.provider.Down(ctx)
+	if err != nil {
+		return result, fmt.Errorf("%s: failed to roll back migration: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// To - apply or roll back migrations until database is at version, 0 rolls back everything
+func (m *Migrator) To(ctx context.Context, version int64) ([]*MigrationResult, error) {
+	const op = "database.Migrator.To"
+
+	current, err := m.Version(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var results []*MigrationResult
+	switch {
+	case version > current:
+		results, err = m.provider.UpTo(ctx, version)
+	case version < current:
+		results, err = m.provider.DownTo(ctx, version)
+	}
+	if err != nil {
+		return results, fmt.Errorf("%s: failed to migrate from %d to %d: %w", op, current, version, err)
 	}
 
-	if err := goose.Up(db, cfg.MigrationsDir); err != nil {
-		return fmt.Errorf("%s: failed to apply migrations: %w", op, err)
+	return results, nil
+}
+
+// Redo - roll back the newest applied migration and apply it again
+func (m *Migrator) Redo(ctx context.Context) ([]*MigrationResult, error) {
+	const op = "database.Migrator.Redo"
+
+	down, err := m.provider.Down(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to roll back migration: %w", op, err)
+	}
+
+	up, err := m.provider.UpByOne(ctx)
+	if err != nil {
+		return []*MigrationResult{down}, fmt.Errorf("%s: failed to apply migration again: %w", op, err)
+	}
+
+	return []*MigrationResult{down, up}, nil
+}
+
+// Status - every migration with its state in database
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	const op = "database.Migrator.Status"
+
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get migration status: %w", op, err)
+	}
+
+	return statuses, nil
+}
+
+// Version - version of the newest migration applied to database
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	const op = "database.Migrator.Version"
+
+	version, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get database version: %w", op, err)
+	}
+
+	return version, nil
+}
+
+// Latest - version of the newest embedded migration, 0 when there are none
+func (m *Migrator) Latest() int64 {
+	sources := m.provider.ListSources()
+	if len(sources) == 0 {
+		return 0
+	}
+	return sources[len(sources)-1].Version
+}
+
+// CheckMigrated - error when database is not at the newest embedded migration
+func (m *Migrator) CheckMigrated(ctx context.Context) error {
+	const op = "database.Migrator.CheckMigrated"
+
+	version, err := m.Version(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if latest := m.Latest(); version != latest {
+		return fmt.Errorf("%s: database is at migration %d, expected %d", op, version, latest)
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/tmozzze/org_struct_api/internal/config"
@@ -11,15 +12,25 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-// NewPostgresDB - initialize a new GORM DB connection to PostgreSQL
-func NewPostgresDB(cfg config.PostgresCfg) (*gorm.DB, error) {
+// slowQueryThreshold - queries running longer are logged as warnings
+const slowQueryThreshold = 200 * time.Millisecond
+
+// NewPostgresDB - initialize a new GORM DB connection to PostgreSQL,
+// queries are logged through log with request ID of query context
+func NewPostgresDB(cfg config.PostgresCfg, log *slog.Logger) (*gorm.DB, error) {
 	const op = "database.NewPostgresDB"
 
 	// DSN
 	dsn := cfg.DSN()
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: logger.NewSlogLogger(log.With(slog.String("op", "gorm")), logger.Config{
+			LogLevel:                  logger.Info,
+			SlowThreshold:             slowQueryThreshold,
+			IgnoreRecordNotFoundError: true,
+		}),
+		// Unique violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
 	})
 
 	if err != nil {